@@ -0,0 +1,283 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// statusesFlag is comma separated list of statuses
+type statusesFlag []allure.Status
+
+func (f *statusesFlag) String() string {
+	statuses := make([]string, 0, len(*f))
+	for _, status := range *f {
+		statuses = append(statuses, string(status))
+	}
+
+	return strings.Join(statuses, ",")
+}
+
+func (f *statusesFlag) Set(value string) error {
+	*f = nil
+	for _, status := range strings.Split(value, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			*f = append(*f, allure.Status(status))
+		}
+	}
+
+	return nil
+}
+
+// labelsFlag is repeated `name=value` label
+type labelsFlag []*allure.Label
+
+func (f *labelsFlag) String() string {
+	labels := make([]string, 0, len(*f))
+	for _, label := range *f {
+		labels = append(labels, label.Name+"="+label.GetValue())
+	}
+
+	return strings.Join(labels, " ")
+}
+
+func (f *labelsFlag) Set(value string) error {
+	name, labelValue, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("label must be name=value, got %q", value)
+	}
+	*f = append(*f, allure.NewLabel(allure.LabelType(name), labelValue))
+
+	return nil
+}
+
+func newFlagSet(name, args string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: allure-go %s [flags] %s\n", name, args)
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
+
+// load parses flags and loads folders passed as arguments merged into one.
+// Returns exit code 2 for wrong arguments and 1 if folders can't be loaded
+func load(flags *flag.FlagSet, args []string, stderr io.Writer) (*allure.Results, int) {
+	if err := flags.Parse(args); err != nil {
+		return nil, 2
+	}
+
+	if flags.NArg() == 0 {
+		_, _ = fmt.Fprintf(stderr, "allure-go %s: no folders passed\n", flags.Name())
+		flags.Usage()
+		return nil, 2
+	}
+
+	sources := make([]*allure.Results, 0, flags.NArg())
+	for _, dir := range flags.Args() {
+		results, err := allure.LoadResults(dir)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "allure-go %s: %s: %s\n", flags.Name(), dir, err)
+			return nil, 1
+		}
+		sources = append(sources, results)
+	}
+
+	return allure.MergeResults(sources...), 0
+}
+
+func write(name string, results *allure.Results, output string, stderr io.Writer) int {
+	if output == "" {
+		_, _ = fmt.Fprintf(stderr, "allure-go %s: -output is required\n", name)
+		return 2
+	}
+
+	if err := results.Write(allure.NewDirFileManager(output)); err != nil {
+		_, _ = fmt.Fprintf(stderr, "allure-go %s: %s\n", name, err)
+		return 1
+	}
+	_, _ = fmt.Fprintf(stderr, "allure-go %s: %d results are written to %s\n", name, len(results.Results), output)
+
+	return 0
+}
+
+func runMerge(args []string, _, stderr io.Writer) int {
+	flags := newFlagSet("merge", "<folder>...", stderr)
+	output := flags.String("output", "", "folder to write merged results to (required)")
+
+	results, code := load(flags, args, stderr)
+	if results == nil {
+		return code
+	}
+
+	return write(flags.Name(), results, *output, stderr)
+}
+
+func runFilter(args []string, _, stderr io.Writer) int {
+	var (
+		statuses statusesFlag
+		labels   labelsFlag
+	)
+
+	flags := newFlagSet("filter", "<folder>...", stderr)
+	output := flags.String("output", "", "folder to write selected results to (required)")
+	flags.Var(&statuses, "status", "comma separated statuses of results to select, e.g. failed,broken")
+	flags.Var(&labels, "label", "`name=value` label of results to select, can be repeated (all labels must match)")
+
+	results, code := load(flags, args, stderr)
+	if results == nil {
+		return code
+	}
+
+	selected := results.Select(func(result *allure.Result) bool {
+		return matchStatus(result, statuses) && matchLabels(result, labels)
+	})
+
+	return write(flags.Name(), selected, *output, stderr)
+}
+
+func matchStatus(result *allure.Result, statuses []allure.Status) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+
+	for _, status := range statuses {
+		if result.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchLabels(result *allure.Result, labels []*allure.Label) bool {
+	for _, label := range labels {
+		found := false
+		for _, l := range result.GetLabels(allure.LabelType(label.Name)) {
+			if l.GetValue() == label.GetValue() {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func runStats(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("stats", "<folder>...", stderr)
+	asJSON := flags.Bool("json", false, "print statistics as json")
+	top := flags.Int("top", 0, "print n slowest results")
+
+	results, code := load(flags, args, stderr)
+	if results == nil {
+		return code
+	}
+
+	stats := results.Stats()
+	if *asJSON {
+		content, err := sonic.Marshal(stats)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "allure-go stats: %s\n", err)
+			return 1
+		}
+		_, _ = fmt.Fprintln(stdout, string(content))
+		return 0
+	}
+
+	_, _ = fmt.Fprintf(stdout, "tests:    %d (%d results)\n", stats.Tests, stats.Results)
+	for _, status := range []allure.Status{allure.Passed, allure.Failed, allure.Broken, allure.Skipped, allure.Unknown} {
+		_, _ = fmt.Fprintf(stdout, "%-9s %d\n", status+":", stats.Statuses[status])
+	}
+	_, _ = fmt.Fprintf(stdout, "flaky:    %d\n", stats.Flaky)
+	_, _ = fmt.Fprintf(stdout, "duration: %s (wall %s)\n", stats.Duration, stats.Wall)
+
+	if *top > 0 {
+		_, _ = fmt.Fprintln(stdout, "slowest:")
+		for _, result := range results.Slowest(*top) {
+			_, _ = fmt.Fprintf(stdout, "  %8dms %s\n", result.Stop-result.Start, result.FullName)
+		}
+	}
+
+	return 0
+}
+
+func runRerunPlan(args []string, stdout, stderr io.Writer) int {
+	statuses := statusesFlag{allure.Failed, allure.Broken}
+
+	flags := newFlagSet("rerun-plan", "<folder>...", stderr)
+	output := flags.String("output", "", "file to write test plan to, stdout by default")
+	flags.Var(&statuses, "status", "comma separated statuses of tests to rerun")
+
+	results, code := load(flags, args, stderr)
+	if results == nil {
+		return code
+	}
+
+	content, err := sonic.Marshal(results.RerunPlan(statuses...))
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "allure-go rerun-plan: %s\n", err)
+		return 1
+	}
+
+	if *output == "" {
+		_, _ = fmt.Fprintln(stdout, string(content))
+		return 0
+	}
+
+	if err = os.WriteFile(*output, content, 0o644); err != nil {
+		_, _ = fmt.Fprintf(stderr, "allure-go rerun-plan: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("validate", "<folder>...", stderr)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		_, _ = fmt.Fprintln(stderr, "allure-go validate: no folders passed")
+		flags.Usage()
+		return 2
+	}
+
+	// folders are validated one by one, so duplicates are found within the folder
+	problems := 0
+	for _, dir := range flags.Args() {
+		results, err := allure.LoadResults(dir)
+		if err != nil {
+			_, _ = fmt.Fprintf(stdout, "%s: %s\n", dir, err)
+			problems++
+			continue
+		}
+
+		for _, problem := range results.Validate() {
+			_, _ = fmt.Fprintf(stdout, "%s: %s\n", dir, problem)
+			problems++
+		}
+	}
+
+	if problems > 0 {
+		_, _ = fmt.Fprintf(stderr, "allure-go validate: %d problems found\n", problems)
+		return 1
+	}
+	_, _ = fmt.Fprintf(stderr, "allure-go validate: %d folders are valid\n", flags.NArg())
+
+	return 0
+}
@@ -0,0 +1,63 @@
+// Command allure-go works with allure-results folders: merges them (e.g. folders of parallel CI shards),
+// filters results by status and labels, prints statistics, builds test plan to rerun failed tests and validates them.
+//
+//	allure-go merge -output ./allure-results ./shard-1 ./shard-2
+//	allure-go filter -output ./failed -status failed,broken -label owner=alice ./allure-results
+//	allure-go stats -top 5 ./allure-results
+//	allure-go rerun-plan -output testplan.json ./allure-results
+//	allure-go validate ./allure-results
+//
+// Every command accepts several folders, which are merged before the command is run (validate checks them one by one).
+// The same operations are available as methods of allure.Results (see MergeResults, Select, Stats, RerunPlan and Validate).
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = []command{
+	{name: "merge", summary: "merge folders into one, deduplicating results by UUID", run: runMerge},
+	{name: "filter", summary: "copy results with passed statuses and labels to new folder", run: runFilter},
+	{name: "stats", summary: "print number of tests by status and durations", run: runStats},
+	{name: "rerun-plan", summary: "print testplan.json selecting failed and broken tests", run: runRerunPlan},
+	{name: "validate", summary: "check results, containers and attachments of folders", run: runValidate},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		usage(stderr)
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
+	}
+
+	_, _ = fmt.Fprintf(stderr, "allure-go: unknown command %q\n", args[0])
+	usage(stderr)
+
+	return 2
+}
+
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Usage: allure-go <command> [flags] <allure-results folder>...")
+	_, _ = fmt.Fprintln(w, "\nCommands:")
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(w, "  %-11s %s\n", cmd.name, cmd.summary)
+	}
+	_, _ = fmt.Fprintln(w, "\nRun `allure-go <command> -h` for flags of the command.")
+}
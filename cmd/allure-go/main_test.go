@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// newShard prints results to new folder
+func newShard(t *testing.T, results ...*allure.Result) string {
+	dir := t.TempDir()
+	allure.SetFileManager(allure.NewDirFileManager(dir))
+	defer allure.SetFileManager(nil)
+
+	for _, result := range results {
+		require.NoError(t, result.Print())
+	}
+
+	return dir
+}
+
+func newResult(name string, status allure.Status, labels ...*allure.Label) *allure.Result {
+	result := allure.NewResult(name, "pkg/"+name)
+	result.Status = status
+	result.Stop = result.Start + 100
+	result.WithLabels(labels...)
+
+	return result
+}
+
+func runCmd(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(args, &out, &errOut)
+
+	return code, out.String(), errOut.String()
+}
+
+func TestRun_merge(t *testing.T) {
+	passed := newResult("Passed", allure.Passed)
+	passed.Attachments = append(passed.Attachments, allure.NewAttachment("log", allure.Text, []byte("log")))
+	failed := newResult("Failed", allure.Failed)
+
+	shardA, shardB := newShard(t, passed), newShard(t, failed, passed)
+	output := filepath.Join(t.TempDir(), "merged")
+
+	code, _, stderr := runCmd("merge", "-output", output, shardA, shardB)
+	require.Equal(t, 0, code, stderr)
+	require.Contains(t, stderr, "2 results are written")
+
+	merged, err := allure.LoadResults(output)
+	require.NoError(t, err)
+	require.Len(t, merged.Results, 2)
+	require.Empty(t, merged.Missing)
+
+	code, _, stderr = runCmd("merge", shardA)
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "-output is required")
+}
+
+func TestRun_filter(t *testing.T) {
+	shard := newShard(t,
+		newResult("Passed", allure.Passed, allure.OwnerLabel("alice")),
+		newResult("FailedAlice", allure.Failed, allure.OwnerLabel("alice")),
+		newResult("FailedBob", allure.Failed, allure.OwnerLabel("bob")),
+		newResult("Broken", allure.Broken),
+	)
+	output := t.TempDir()
+
+	code, _, stderr := runCmd("filter", "-output", output, "-status", "failed,broken", "-label", "owner=alice", shard)
+	require.Equal(t, 0, code, stderr)
+
+	filtered, err := allure.LoadResults(output)
+	require.NoError(t, err)
+	require.Len(t, filtered.Results, 1)
+	require.Equal(t, "FailedAlice", filtered.Results[0].Name)
+
+	code, _, stderr = runCmd("filter", "-output", output, "-label", "owner", shard)
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, `label must be name=value, got "owner"`)
+}
+
+func TestRun_stats(t *testing.T) {
+	shard := newShard(t, newResult("Passed", allure.Passed), newResult("Failed", allure.Failed))
+
+	code, stdout, _ := runCmd("stats", "-top", "1", shard)
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout, "tests:    2 (2 results)\n")
+	require.Contains(t, stdout, "passed:   1\n")
+	require.Contains(t, stdout, "failed:   1\n")
+	require.Contains(t, stdout, "duration: 200ms")
+	require.Contains(t, stdout, "slowest:\n       100ms pkg/")
+
+	code, stdout, _ = runCmd("stats", "-json", shard)
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout, `"tests":2`)
+}
+
+func TestRun_rerunPlan(t *testing.T) {
+	shard := newShard(t, newResult("Passed", allure.Passed), newResult("Failed", allure.Failed, allure.IDAllureLabel("7")))
+
+	code, stdout, _ := runCmd("rerun-plan", shard)
+	require.Equal(t, 0, code)
+	require.JSONEq(t, `{"version":"1.0","tests":[{"id":"7","selector":"pkg/Failed"}]}`, stdout)
+
+	output := filepath.Join(t.TempDir(), "testplan.json")
+	code, _, _ = runCmd("rerun-plan", "-status", "passed", "-output", output, shard)
+	require.Equal(t, 0, code)
+
+	content, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version":"1.0","tests":[{"selector":"pkg/Passed"}]}`, string(content))
+}
+
+func TestRun_validate(t *testing.T) {
+	valid := newShard(t, newResult("Passed", allure.Passed))
+	code, _, stderr := runCmd("validate", valid)
+	require.Equal(t, 0, code, stderr)
+	require.Contains(t, stderr, "1 folders are valid")
+
+	lost := newResult("Lost", allure.Passed)
+	lost.Attachments = append(lost.Attachments, &allure.Attachment{Name: "lost", Source: "lost-attachment.txt"})
+	invalid := newShard(t, lost)
+	require.NoError(t, os.Remove(filepath.Join(invalid, "lost-attachment.txt")))
+
+	code, stdout, stderr := runCmd("validate", valid, invalid)
+	require.Equal(t, 1, code)
+	require.Equal(t, invalid+": attachment lost-attachment.txt is not found\n", stdout)
+	require.Contains(t, stderr, "1 problems found")
+
+	code, stdout, _ = runCmd("validate", filepath.Join(t.TempDir(), "missing"))
+	require.Equal(t, 1, code)
+	require.Contains(t, stdout, "cannot read results folder")
+}
+
+func TestRun_usage(t *testing.T) {
+	code, _, stderr := runCmd()
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "Usage: allure-go <command>")
+
+	code, _, stderr = runCmd("publish")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, `unknown command "publish"`)
+
+	code, _, stderr = runCmd("stats")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "no folders passed")
+
+	code, _, stderr = runCmd("stats", filepath.Join(t.TempDir(), "missing"))
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "cannot read results folder")
+}
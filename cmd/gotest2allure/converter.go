@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// Nesting is the way subtests are reported
+type Nesting string
+
+const (
+	// Suites reports every test and subtest as separate result. Subtests are grouped by suite (top test) and sub suite (parent subtests)
+	Suites Nesting = "suites"
+	// Steps reports top tests as results and their subtests as nested steps
+	Steps Nesting = "steps"
+)
+
+// event is the line of `go test -json` output (see `go doc test2json`)
+type event struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+type testKey struct {
+	pkg  string
+	name string
+}
+
+// test is the test or subtest collected from events
+type test struct {
+	pkg      string
+	name     string // full name of the test in the package, e.g. TestA/case
+	action   string // pass, fail or skip, empty if the test didn't finish
+	start    time.Time
+	stop     time.Time
+	output   strings.Builder
+	children []*test
+}
+
+type pkgState struct {
+	name   string
+	action string
+	output strings.Builder
+	tests  []*test
+}
+
+// converter collects events of `go test -json` and builds allure results of the tests
+type converter struct {
+	nesting  Nesting
+	tests    map[testKey]*test
+	packages map[string]*pkgState
+	order    []*pkgState
+	echo     func(text string)
+}
+
+func newConverter(nesting Nesting) *converter {
+	return &converter{
+		nesting:  nesting,
+		tests:    make(map[testKey]*test),
+		packages: make(map[string]*pkgState),
+	}
+}
+
+// read collects events from r. Output of the tests and lines, which are not events (e.g. build errors), are passed to echo
+func (c *converter) read(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var e event
+			if trimmed := bytes.TrimSpace(line); bytes.HasPrefix(trimmed, []byte("{")) && sonic.Unmarshal(trimmed, &e) == nil {
+				c.add(e)
+				if e.Action == "output" {
+					c.print(e.Output)
+				}
+			} else {
+				c.print(string(line))
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read go test output: %w", err)
+		}
+	}
+}
+
+func (c *converter) print(text string) {
+	if c.echo != nil {
+		c.echo(text)
+	}
+}
+
+func (c *converter) pkg(name string) *pkgState {
+	p, ok := c.packages[name]
+	if !ok {
+		p = &pkgState{name: name}
+		c.packages[name] = p
+		c.order = append(c.order, p)
+	}
+
+	return p
+}
+
+func (c *converter) test(e event) *test {
+	key := testKey{pkg: e.Package, name: e.Test}
+	if t, ok := c.tests[key]; ok {
+		return t
+	}
+
+	t := &test{pkg: e.Package, name: e.Test, start: e.Time}
+	c.tests[key] = t
+
+	if i := strings.LastIndex(e.Test, "/"); i >= 0 {
+		if parent, ok := c.tests[testKey{pkg: e.Package, name: e.Test[:i]}]; ok {
+			parent.children = append(parent.children, t)
+			return t
+		}
+	}
+
+	p := c.pkg(e.Package)
+	p.tests = append(p.tests, t)
+
+	return t
+}
+
+// add handles one event
+func (c *converter) add(e event) {
+	if e.Test == "" {
+		p := c.pkg(e.Package)
+		switch e.Action {
+		case "output":
+			p.output.WriteString(e.Output)
+		case "pass", "fail", "skip":
+			p.action = e.Action
+		}
+		return
+	}
+
+	t := c.test(e)
+	switch e.Action {
+	case "output":
+		if !isFraming(e.Output) {
+			t.output.WriteString(e.Output)
+		}
+	case "pass", "fail", "skip":
+		t.action = e.Action
+		t.stop = e.Time
+		if t.stop.IsZero() && !t.start.IsZero() {
+			t.stop = t.start.Add(time.Duration(e.Elapsed * float64(time.Second)))
+		}
+	}
+}
+
+// isFraming checks whether the output line is printed by testing package around the test
+func isFraming(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"=== RUN", "=== PAUSE", "=== CONT", "=== NAME", "--- PASS", "--- FAIL", "--- SKIP"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// results returns allure results of collected tests
+func (c *converter) results() []*allure.Result {
+	var results []*allure.Result
+	for _, p := range c.order {
+		failed := false
+		for _, t := range p.tests {
+			if c.nesting == Steps {
+				results = append(results, c.newResult(t, nil))
+			} else {
+				results = append(results, c.suiteResults(t, nil)...)
+			}
+			failed = failed || t.failed()
+		}
+
+		if p.action == "fail" && !failed {
+			results = append(results, newPackageResult(p))
+		}
+	}
+
+	return results
+}
+
+// suiteResults returns results of the test and all its subtests
+func (c *converter) suiteResults(t *test, path []string) []*allure.Result {
+	results := []*allure.Result{c.newResult(t, path)}
+	path = append(path[:len(path):len(path)], t.shortName())
+	for _, child := range t.children {
+		results = append(results, c.suiteResults(child, path)...)
+	}
+
+	return results
+}
+
+func (c *converter) newResult(t *test, path []string) *allure.Result {
+	result := allure.NewResult(t.shortName(), t.pkg+"/"+t.name).
+		WithPackage(t.pkg).
+		WithParentSuite(t.pkg).
+		WithFrameWork("go test")
+	if len(path) == 0 {
+		result.WithSuite(t.name)
+	} else {
+		result.WithSuite(path[0])
+		if len(path) > 1 {
+			result.WithSubSuites(strings.Join(path[1:], "/"))
+		}
+	}
+
+	status, message, trace := t.status()
+	result.Status = status
+	result.SetStatusMessage(message)
+	result.SetStatusTrace(trace)
+	setTimes(&result.Start, &result.Stop, t)
+
+	if output := t.output.String(); output != "" {
+		result.Attachments = append(result.Attachments, allure.NewAttachment("Output", allure.Text, []byte(output)))
+	}
+
+	if c.nesting == Steps {
+		for _, child := range t.children {
+			result.Steps = append(result.Steps, newStep(child))
+		}
+	}
+
+	return result
+}
+
+func newStep(t *test) *allure.Step {
+	status, message, trace := t.status()
+	step := allure.NewSimpleStep(t.shortName())
+	step.Status = status
+	if message != "" || trace != "" {
+		step.WithStatusDetails(message, trace)
+	}
+	setTimes(&step.Start, &step.Stop, t)
+
+	if output := t.output.String(); output != "" {
+		step.WithAttachments(allure.NewAttachment("Output", allure.Text, []byte(output)))
+	}
+
+	for _, child := range t.children {
+		step.WithChild(newStep(child))
+	}
+
+	return step
+}
+
+// newPackageResult returns broken result of the package, which failed outside of its tests (build failure, TestMain, etc.)
+func newPackageResult(p *pkgState) *allure.Result {
+	result := allure.NewResult(p.name, p.name).
+		WithPackage(p.name).
+		WithParentSuite(p.name).
+		WithFrameWork("go test")
+	result.Status = allure.Broken
+	result.SetStatusMessage(fmt.Sprintf("package %s failed", p.name))
+	result.SetStatusTrace(p.output.String())
+	if output := p.output.String(); output != "" {
+		result.Attachments = append(result.Attachments, allure.NewAttachment("Output", allure.Text, []byte(output)))
+	}
+
+	return result
+}
+
+func setTimes(start, stop *int64, t *test) {
+	if !t.start.IsZero() {
+		*start = t.start.UnixMilli()
+	}
+	if !t.stop.IsZero() {
+		*stop = t.stop.UnixMilli()
+	} else {
+		*stop = *start
+	}
+}
+
+func (t *test) shortName() string {
+	return t.name[strings.LastIndex(t.name, "/")+1:]
+}
+
+func (t *test) failed() bool {
+	status, _, _ := t.status()
+
+	return status == allure.Failed || status == allure.Broken
+}
+
+// status maps result of the test to allure status: pass to passed, fail to failed (broken if the test panicked),
+// skip to skipped. Unfinished test (the test binary crashed or timed out) is broken
+func (t *test) status() (status allure.Status, message, trace string) {
+	output := t.output.String()
+	lines := meaningfulLines(output)
+
+	if t.action != "pass" {
+		if panicLine := findPanic(lines); panicLine != "" {
+			return allure.Broken, panicLine, output
+		}
+	}
+
+	switch t.action {
+	case "pass":
+		return allure.Passed, "", ""
+	case "skip":
+		return allure.Skipped, firstLine(lines), ""
+	case "fail":
+		if len(lines) == 0 && t.childFailed() {
+			return allure.Failed, "subtest failed", output
+		}
+		return allure.Failed, firstLine(lines), output
+	default:
+		return allure.Broken, "test did not finish", output
+	}
+}
+
+func (t *test) childFailed() bool {
+	for _, child := range t.children {
+		if child.failed() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func meaningfulLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+func findPanic(lines []string) string {
+	for _, line := range lines {
+		if strings.HasPrefix(line, "panic: ") {
+			return line
+		}
+	}
+
+	return ""
+}
+
+func firstLine(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return lines[0]
+}
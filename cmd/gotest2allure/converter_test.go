@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+func readEvents(t *testing.T, nesting Nesting) (*converter, []string) {
+	file, err := os.Open("testdata/events.json")
+	require.NoError(t, err)
+	defer file.Close()
+
+	var printed []string
+	c := newConverter(nesting)
+	c.echo = func(text string) { printed = append(printed, text) }
+	require.NoError(t, c.read(file))
+
+	return c, printed
+}
+
+func label(result *allure.Result, labelType allure.LabelType) string {
+	if l, ok := result.GetFirstLabel(labelType); ok {
+		return l.GetValue()
+	}
+
+	return ""
+}
+
+func TestConverter_results_suites(t *testing.T) {
+	c, printed := readEvents(t, Suites)
+	results := c.results()
+
+	type summary struct {
+		name, suite, subSuite string
+		status                allure.Status
+		message               string
+	}
+	var got []summary
+	for _, r := range results {
+		got = append(got, summary{r.Name, label(r, allure.Suite), label(r, allure.SubSuite), r.Status, r.GetStatusMessage()})
+	}
+
+	require.Equal(t, []summary{
+		{"TestPass", "TestPass", "", allure.Passed, ""},
+		{"TestTable", "TestTable", "", allure.Failed, "subtest failed"},
+		{"ok", "TestTable", "", allure.Passed, ""},
+		{"bad", "TestTable", "", allure.Failed, "subtest failed"},
+		{"deep", "TestTable", "bad", allure.Failed, "table_test.go:20: expected 1, got 2"},
+		{"TestSkip", "TestSkip", "", allure.Skipped, "skip_test.go:5: not ready"},
+		{"TestPanic", "TestPanic", "", allure.Broken, "panic: boom [recovered]"},
+		{"TestHang", "TestHang", "", allure.Broken, "test did not finish"},
+		{"example.com/broken", "", "", allure.Broken, "package example.com/broken failed"},
+	}, got)
+
+	pass := results[0]
+	require.Equal(t, "example.com/pkg/TestPass", pass.FullName)
+	require.Equal(t, "example.com/pkg", label(pass, allure.Package))
+	require.Equal(t, "example.com/pkg", label(pass, allure.ParentSuite))
+	require.Equal(t, int64(1704103200500), pass.Stop)
+	require.Equal(t, int64(500), pass.Stop-pass.Start)
+	require.Len(t, pass.Attachments, 1)
+	require.Equal(t, "    pass_test.go:10: hello\n", string(pass.Attachments[0].GetContent()))
+
+	broken := results[len(results)-1]
+	require.Contains(t, broken.GetStatusTrace(), "[build failed]")
+
+	require.Contains(t, printed, "broken.go:3:1: syntax error: non-declaration statement outside function body\n")
+	require.Contains(t, printed, "    pass_test.go:10: hello\n")
+}
+
+func TestConverter_results_steps(t *testing.T) {
+	c, _ := readEvents(t, Steps)
+	results := c.results()
+	require.Len(t, results, 6)
+
+	table := results[1]
+	require.Equal(t, "TestTable", table.Name)
+	require.Equal(t, allure.Failed, table.Status)
+	require.Len(t, table.Steps, 2)
+	require.Equal(t, "ok", table.Steps[0].Name)
+	require.Equal(t, allure.Passed, table.Steps[0].Status)
+
+	bad := table.Steps[1]
+	require.Equal(t, "bad", bad.Name)
+	require.Equal(t, allure.Failed, bad.Status)
+	require.Len(t, bad.Steps, 1)
+	require.Equal(t, "deep", bad.Steps[0].Name)
+	require.Equal(t, "table_test.go:20: expected 1, got 2", bad.Steps[0].StatusDetails.Message)
+	require.Len(t, bad.Steps[0].Attachments, 1)
+}
+
+func TestTest_status_passedWithPanicOutput(t *testing.T) {
+	passed := &test{action: "pass"}
+	passed.output.WriteString("panic: recovered by the test\n")
+	status, message, _ := passed.status()
+	require.Equal(t, allure.Passed, status)
+	require.Empty(t, message)
+
+	failed := &test{action: "fail"}
+	failed.output.WriteString("panic: boom\n")
+	status, message, _ = failed.status()
+	require.Equal(t, allure.Broken, status)
+	require.Equal(t, "panic: boom", message)
+}
+
+func TestIsFraming(t *testing.T) {
+	require.True(t, isFraming("=== RUN   TestA\n"))
+	require.True(t, isFraming("    --- FAIL: TestA/b (0.00s)\n"))
+	require.False(t, isFraming("    a_test.go:1: --- PASS\n"))
+}
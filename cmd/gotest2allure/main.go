@@ -0,0 +1,82 @@
+// Command gotest2allure converts `go test -json` output to allure results, so packages
+// with plain testing.T tests get their allure report as well:
+//
+//	go test -json ./... | gotest2allure -output ./allure-results
+//
+// Every test and subtest becomes allure result with status (pass - passed, fail - failed, skip - skipped,
+// panic or unfinished test - broken) and its output as text attachment.
+// With -subtests=steps subtests become nested steps of the top test's result.
+// Package, which failed outside of its tests (e.g. build failure), becomes broken result.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("gotest2allure", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var (
+		input    = flags.String("input", "-", "file with `go test -json` output, - for stdin")
+		output   = flags.String("output", "", "allure results directory (default $ALLURE_OUTPUT_PATH/$ALLURE_OUTPUT_FOLDER)")
+		subtests = flags.String("subtests", string(Suites), "how to report subtests: suites (separate results) or steps (steps of the top test)")
+		echo     = flags.Bool("echo", false, "print output of the tests to stdout, as go test does without -json")
+	)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	nesting := Nesting(*subtests)
+	if nesting != Suites && nesting != Steps {
+		_, _ = fmt.Fprintf(stderr, "gotest2allure: unknown -subtests value %q, expected %q or %q\n", *subtests, Suites, Steps)
+		return 2
+	}
+
+	in := stdin
+	if *input != "-" {
+		file, err := os.Open(*input)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "gotest2allure: %s\n", err)
+			return 1
+		}
+		defer file.Close()
+		in = file
+	}
+
+	if *output != "" {
+		allure.SetFileManager(allure.NewDirFileManager(*output))
+		defer allure.SetFileManager(nil)
+	}
+
+	c := newConverter(nesting)
+	c.echo = func(text string) {
+		if *echo {
+			_, _ = io.WriteString(stdout, text)
+		}
+	}
+	if err := c.read(in); err != nil {
+		_, _ = fmt.Fprintf(stderr, "gotest2allure: %s\n", err)
+		return 1
+	}
+
+	results := c.results()
+	for _, result := range results {
+		if err := result.Print(); err != nil {
+			_, _ = fmt.Fprintf(stderr, "gotest2allure: %s\n", err)
+			return 1
+		}
+	}
+	_, _ = fmt.Fprintf(stderr, "gotest2allure: %d results of %d packages are written\n", len(results), len(c.order))
+
+	return 0
+}
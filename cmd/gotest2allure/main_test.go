@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-input", "testdata/events.json", "-output", dir, "-subtests", "steps", "-echo"}, nil, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+	require.Contains(t, stdout.String(), "[build failed]")
+	require.Contains(t, stderr.String(), "6 results of 2 packages are written")
+
+	results, err := allure.LoadResults(dir)
+	require.NoError(t, err)
+	require.Len(t, results.Results, 6)
+	require.Empty(t, results.Missing)
+	require.Len(t, results.ByStatus(allure.Broken), 3)
+}
+
+func TestRun_stdin(t *testing.T) {
+	dir := t.TempDir()
+	input := `{"Action":"run","Package":"p","Test":"TestA"}` + "\n" + `{"Action":"pass","Package":"p","Test":"TestA"}`
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, run([]string{"-output", dir}, strings.NewReader(input), &stdout, &stderr))
+	require.Empty(t, stdout.String())
+
+	results, err := allure.LoadResults(dir)
+	require.NoError(t, err)
+	require.Len(t, results.ByStatus(allure.Passed), 1)
+}
+
+func TestRun_badArgs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 2, run([]string{"-subtests", "tree"}, nil, &stdout, &stderr))
+	require.Contains(t, stderr.String(), `unknown -subtests value "tree"`)
+
+	require.Equal(t, 1, run([]string{"-input", "testdata/missing.json"}, nil, &stdout, &stderr))
+}
@@ -72,6 +72,22 @@ func (s *FailsDemoSuite) TestAssertionFailInnerSteps(t provider.T) {
 	})
 }
 
+func (s *FailsDemoSuite) TestSoftAssertionsFail(t provider.T) {
+	t.Title("This test failed by soft asserts")
+	t.Description(`
+		This Test will be failed once, after all soft asserts are made.
+		Error text lists failed asserts:
+					2 of 3 soft asserts failed`)
+
+	t.Tags("fail", "assertions", "soft")
+
+	t.SoftAssert("Check user", func(a provider.Asserts) {
+		a.Equal("alice", "bob", "name")
+		a.Equal(30, 30, "age")
+		a.True(false, "is admin")
+	})
+}
+
 func (s *FailsDemoSuite) TestPanic(t provider.T) {
 	t.Title("This test panicked")
 	t.Description(`
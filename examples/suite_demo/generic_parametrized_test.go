@@ -0,0 +1,56 @@
+//go:build examples_new
+// +build examples_new
+
+package suite_demo
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/jackc/fake"
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/runner"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type City struct {
+	ID   string
+	Name string
+}
+
+type GenericParametrizedSuite struct {
+	suite.Suite
+	cities []City
+}
+
+func (s *GenericParametrizedSuite) BeforeAll(t provider.T) {
+	for i := 0; i < 10; i++ {
+		s.cities = append(s.cities, City{ID: strconv.Itoa(1000 + i), Name: fake.City()})
+	}
+}
+
+func (s *GenericParametrizedSuite) ParametrizedTests(r runner.TestRunner) {
+	runner.NewParametrizedTest(r, "Cities", s.cities, s.checkCity,
+		runner.WithCaseName(func(c City) string { return "City " + c.Name }),
+		runner.WithCaseAllureID(func(c City) string { return c.ID }),
+		runner.WithCaseLabels(func(c City) []*allure.Label { return []*allure.Label{allure.FeatureLabel("Cities")} }),
+	)
+}
+
+func (s *GenericParametrizedSuite) checkCity(t provider.T, city City) {
+	t.Parallel()
+	t.Require().NotEmpty(city.Name)
+}
+
+func TestGenericParametrizedSuite(t *testing.T) {
+	suite.RunSuite(t, new(GenericParametrizedSuite))
+}
+
+func TestGenericParametrizedRunner(t *testing.T) {
+	r := runner.NewRunner(t, "Generic parametrized runner")
+	runner.NewParametrizedTest(r, "Numbers", []int{1, 2, 3}, func(t provider.T, n int) {
+		t.Require().Greater(n, 0)
+	}, runner.WithTags("numbers"))
+	r.RunTests()
+}
@@ -1,6 +1,6 @@
 module github.com/ozontech/allure-go
 
-go 1.17
+go 1.18
 
 replace (
 	github.com/ozontech/allure-go/pkg/allure => ./pkg/allure
@@ -12,6 +12,7 @@ require (
 	github.com/jackc/fake v0.0.0-20150926172116-812a484cc733
 	github.com/ozontech/allure-go/pkg/allure v0.7.5
 	github.com/ozontech/allure-go/pkg/framework v0.7.5
+	github.com/stretchr/testify v1.10.0
 )
 
 require (
@@ -23,7 +24,6 @@ require (
 	github.com/klauspost/cpuid/v2 v2.2.9 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
 	golang.org/x/arch v0.0.0-20210923205945-b76863e36670 // indirect
 	golang.org/x/sys v0.22.0 // indirect
@@ -0,0 +1,139 @@
+package allure
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ArchiveFileManager is a FileManager that packs all created files into a single archive.
+// Archive is not valid until ArchiveFileManager.Close is called.
+// Files changed with UpdateFile (e.g. environment.properties) are kept in memory and packed once on Close.
+type ArchiveFileManager struct {
+	mu     sync.Mutex
+	closed bool
+
+	updated      map[string][]byte
+	updatedOrder []string
+
+	write func(name string, content []byte) error
+	close func() error
+}
+
+// NewZipFileManager Constructor. Returns ArchiveFileManager which writes zip archive to w.
+func NewZipFileManager(w io.Writer) *ArchiveFileManager {
+	zw := zip.NewWriter(w)
+
+	return &ArchiveFileManager{
+		write: func(name string, content []byte) error {
+			f, err := zw.CreateHeader(&zip.FileHeader{
+				Name:     name,
+				Method:   zip.Deflate,
+				Modified: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = f.Write(content)
+
+			return err
+		},
+		close: zw.Close,
+	}
+}
+
+// NewTarGzFileManager Constructor. Returns ArchiveFileManager which writes tar.gz archive to w.
+func NewTarGzFileManager(w io.Writer) *ArchiveFileManager {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	return &ArchiveFileManager{
+		write: func(name string, content []byte) error {
+			err := tw.WriteHeader(&tar.Header{
+				Name:    name,
+				Mode:    fileSystemPermissionCode,
+				Size:    int64(len(content)),
+				ModTime: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = tw.Write(content)
+
+			return err
+		},
+		close: func() error {
+			if err := tw.Close(); err != nil {
+				return err
+			}
+
+			return gw.Close()
+		},
+	}
+}
+
+// CreateFile adds new file to the archive
+func (m *ArchiveFileManager) CreateFile(name string, content []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errors.Errorf("cannot create %s: archive is already closed", name)
+	}
+
+	return m.write(name, content)
+}
+
+// UpdateFile calls update with the content of the previous update of the file (nil for the first one)
+// and keeps the result until Close, so the archive gets the file only once
+func (m *ArchiveFileManager) UpdateFile(name string, update func(content []byte) ([]byte, error)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errors.Errorf("cannot update %s: archive is already closed", name)
+	}
+
+	content, ok := m.updated[name]
+	newContent, err := update(content)
+	if err != nil {
+		return err
+	}
+
+	if m.updated == nil {
+		m.updated = make(map[string][]byte)
+	}
+	if !ok {
+		m.updatedOrder = append(m.updatedOrder, name)
+	}
+	m.updated[name] = append([]byte(nil), newContent...)
+
+	return nil
+}
+
+// Close writes updated files and flushes the archive. All next CreateFile calls will return error.
+func (m *ArchiveFileManager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
+	for _, name := range m.updatedOrder {
+		if err := m.write(name, m.updated[name]); err != nil {
+			_ = m.close()
+			return err
+		}
+	}
+
+	return m.close()
+}
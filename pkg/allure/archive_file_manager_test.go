@@ -0,0 +1,80 @@
+package allure
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipFileManager(t *testing.T) {
+	buf := &bytes.Buffer{}
+	fm := NewZipFileManager(buf)
+	require.NoError(t, fm.CreateFile("a-result.json", []byte(`{"name":"a"}`)))
+	require.NoError(t, fm.CreateFile("b-attachment.txt", []byte("text")))
+	require.NoError(t, fm.Close())
+	require.NoError(t, fm.Close())
+	require.Error(t, fm.CreateFile("c.txt", nil))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+	require.Equal(t, "a-result.json", zr.File[0].Name)
+
+	f, err := zr.File[1].Open()
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, "text", string(content))
+}
+
+func TestTarGzFileManager(t *testing.T) {
+	buf := &bytes.Buffer{}
+	fm := NewTarGzFileManager(buf)
+	require.NoError(t, fm.CreateFile("a-result.json", []byte(`{"name":"a"}`)))
+	require.NoError(t, fm.Close())
+	require.Error(t, fm.CreateFile("c.txt", nil))
+
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	header, err := tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "a-result.json", header.Name)
+	content, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"a"}`, string(content))
+
+	_, err = tr.Next()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestArchiveFileManager_UpdateFile(t *testing.T) {
+	buf := &bytes.Buffer{}
+	fm := NewZipFileManager(buf)
+	require.NoError(t, fm.CreateFile("a-result.json", []byte(`{}`)))
+	for _, value := range []string{"a", "b"} {
+		value := value
+		require.NoError(t, UpdateFile(fm, "environment.properties", func(content []byte) ([]byte, error) {
+			return append(content, value...), nil
+		}))
+	}
+	require.NoError(t, fm.Close())
+	require.Error(t, fm.UpdateFile("environment.properties", func(content []byte) ([]byte, error) { return content, nil }))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+	require.Equal(t, "environment.properties", zr.File[1].Name)
+
+	f, err := zr.File[1].Open()
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, "ab", string(content))
+}
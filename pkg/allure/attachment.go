@@ -120,5 +120,5 @@ func (a *Attachment) GetContent() []byte {
 
 // Print - Creates a file from `Attachment.content`. The file type is determined by its `Attachment.mimeType`.
 func (a *Attachment) Print() error {
-	return NewFileManager().CreateFile(a.Source, a.content)
+	return GetFileManager().CreateFile(a.Source, a.content)
 }
@@ -0,0 +1,277 @@
+package allure
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/bytedance/sonic"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const categoriesFileName = "categories.json"
+
+// Category is an implementation of the failure category used by Allure to classify test results.
+// Result belongs to the category if its status is one of Category.MatchedStatuses,
+// its status message and trace match Category.MessageRegex and Category.TraceRegex
+// and its flaky mark equals to Category.Flaky.
+type Category struct {
+	Name            string   `json:"name" yaml:"name"`                                           // Category name
+	MatchedStatuses []Status `json:"matchedStatuses,omitempty" yaml:"matchedStatuses,omitempty"` // Statuses of the results in the category
+	MessageRegex    string   `json:"messageRegex,omitempty" yaml:"messageRegex,omitempty"`       // Regex for StatusDetail.Message
+	TraceRegex      string   `json:"traceRegex,omitempty" yaml:"traceRegex,omitempty"`           // Regex for StatusDetail.Trace
+	Flaky           bool     `json:"flaky,omitempty" yaml:"flaky,omitempty"`                     // Category matches only flaky results if true, only not flaky otherwise
+}
+
+var (
+	categoriesMu       sync.Mutex
+	categories         []*Category
+	categoriesFileOnce sync.Once
+	categoriesFileErr  error
+)
+
+// NewCategory Constructor. Builds and returns a new `allure.Category` object.
+func NewCategory(name string) *Category {
+	return &Category{Name: name}
+}
+
+// WithMatchedStatuses Adds passed statuses to the `Category.MatchedStatuses`.
+// Returns a pointer to the current Category (for Fluent Interface).
+func (c *Category) WithMatchedStatuses(statuses ...Status) *Category {
+	c.MatchedStatuses = append(c.MatchedStatuses, statuses...)
+
+	return c
+}
+
+// WithMessageRegex Sets `Category.MessageRegex`.
+// Returns a pointer to the current Category (for Fluent Interface).
+func (c *Category) WithMessageRegex(regex string) *Category {
+	c.MessageRegex = regex
+
+	return c
+}
+
+// WithTraceRegex Sets `Category.TraceRegex`.
+// Returns a pointer to the current Category (for Fluent Interface).
+func (c *Category) WithTraceRegex(regex string) *Category {
+	c.TraceRegex = regex
+
+	return c
+}
+
+// WithFlaky Sets `Category.Flaky`.
+// Returns a pointer to the current Category (for Fluent Interface).
+func (c *Category) WithFlaky(flaky bool) *Category {
+	c.Flaky = flaky
+
+	return c
+}
+
+// Validate returns error if category has no name or its regexes cannot be compiled
+func (c *Category) Validate() error {
+	if c.Name == "" {
+		return errors.New("category name is empty")
+	}
+
+	if _, err := regexp.Compile(c.MessageRegex); err != nil {
+		return errors.Wrapf(err, "category %s has invalid messageRegex", c.Name)
+	}
+
+	if _, err := regexp.Compile(c.TraceRegex); err != nil {
+		return errors.Wrapf(err, "category %s has invalid traceRegex", c.Name)
+	}
+
+	return nil
+}
+
+// Matches returns true if result belongs to the category. Allure matches messages and traces with the whole regex
+// and flaky mark with StatusDetail.Flaky, so the same rules are used here.
+func (c *Category) Matches(status Status, details StatusDetail) bool {
+	if c.Flaky != details.Flaky {
+		return false
+	}
+
+	if len(c.MatchedStatuses) > 0 {
+		found := false
+		for _, s := range c.MatchedStatuses {
+			if s == status {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return fullMatch(c.MessageRegex, details.Message) && fullMatch(c.TraceRegex, details.Trace)
+}
+
+// AddCategories registers categories which will be printed to the `categories.json`.
+// Category with the same name as already registered one replaces it.
+func AddCategories(newCategories ...*Category) error {
+	for _, c := range newCategories {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+
+	categoriesMu.Lock()
+	defer categoriesMu.Unlock()
+
+	categories = mergeCategories(categories, newCategories)
+
+	return nil
+}
+
+// GetCategories returns copy of the registered categories.
+// The first call also loads categories from the file set in `ALLURE_CATEGORIES_PATH` (if any),
+// error of loading the file is returned by every call.
+func GetCategories() ([]*Category, error) {
+	categoriesFileOnce.Do(func() {
+		path := os.Getenv(categoriesPathEnvKey)
+		if path == "" {
+			return
+		}
+
+		fileCategories, err := LoadCategories(path)
+
+		categoriesMu.Lock()
+		defer categoriesMu.Unlock()
+
+		if err != nil {
+			categoriesFileErr = err
+			return
+		}
+
+		// categories registered with AddCategories take precedence
+		categories = mergeCategories(fileCategories, categories)
+	})
+
+	categoriesMu.Lock()
+	defer categoriesMu.Unlock()
+
+	return append([]*Category(nil), categories...), categoriesFileErr
+}
+
+// LoadCategories reads categories from JSON or YAML (`.yaml`/`.yml`) file.
+// If relative path is not found in the working directory, parent directories are checked,
+// so a single file can be shared by all packages of the repository.
+func LoadCategories(path string) ([]*Category, error) {
+	content, err := readFileUp(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read categories from %s", path)
+	}
+
+	var result []*Category
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &result)
+	default:
+		err = sonic.Unmarshal(content, &result)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse categories from %s", path)
+	}
+
+	for _, c := range result {
+		if err = c.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid categories in %s", path)
+		}
+	}
+
+	return result, nil
+}
+
+// PrintCategories merges registered categories into the `categories.json` of the output folder.
+// Categories printed by other packages of the run are kept. If there are no categories, nothing is printed.
+func PrintCategories() error {
+	registered, err := GetCategories()
+	if err != nil {
+		return err
+	}
+
+	if len(registered) == 0 {
+		return nil
+	}
+
+	err = UpdateFile(GetFileManager(), categoriesFileName, func(content []byte) ([]byte, error) {
+		var existing []*Category
+		if len(content) > 0 {
+			if err := sonic.Unmarshal(content, &existing); err != nil {
+				return nil, errors.Wrapf(err, "cannot parse %s", categoriesFileName)
+			}
+		}
+
+		return sonic.Marshal(mergeCategories(existing, registered))
+	})
+	if err != nil {
+		return errors.Wrap(err, "Cannot save categories")
+	}
+
+	return nil
+}
+
+// mergeCategories returns base categories with categories from override appended or replaced by name
+func mergeCategories(base, override []*Category) []*Category {
+	result := append([]*Category(nil), base...)
+
+	for _, c := range override {
+		replaced := false
+		for i, existing := range result {
+			if existing.Name == c.Name {
+				result[i] = c
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+func fullMatch(regex, s string) bool {
+	if regex == "" {
+		return true
+	}
+
+	matched, err := regexp.MatchString(`^(?s:`+regex+`)$`, s)
+
+	return err == nil && matched
+}
+
+// readFileUp reads file by path. Relative path is also looked up in all parent directories of the working directory.
+func readFileUp(path string) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err == nil || filepath.IsAbs(path) || !os.IsNotExist(err) {
+		return content, err
+	}
+
+	dir, wdErr := os.Getwd()
+	if wdErr != nil {
+		return nil, err
+	}
+
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, err
+		}
+		dir = parent
+
+		//nolint:gosec // path is set by user
+		if content, readErr := os.ReadFile(filepath.Join(dir, path)); readErr == nil {
+			return content, nil
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package allure
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/stretchr/testify/require"
+)
+
+func resetCategories() {
+	categoriesMu.Lock()
+	defer categoriesMu.Unlock()
+
+	categories = nil
+	categoriesFileOnce = sync.Once{}
+	categoriesFileErr = nil
+}
+
+func TestNewCategory(t *testing.T) {
+	category := NewCategory("Timeouts").
+		WithMatchedStatuses(Broken, Failed).
+		WithMessageRegex(".*timeout.*").
+		WithTraceRegex(".*context.*").
+		WithFlaky(true)
+
+	require.Equal(t, &Category{
+		Name:            "Timeouts",
+		MatchedStatuses: []Status{Broken, Failed},
+		MessageRegex:    ".*timeout.*",
+		TraceRegex:      ".*context.*",
+		Flaky:           true,
+	}, category)
+	require.NoError(t, category.Validate())
+}
+
+func TestCategory_Validate(t *testing.T) {
+	require.Error(t, NewCategory("").Validate())
+	require.Error(t, NewCategory("bad").WithMessageRegex("(").Validate())
+	require.Error(t, NewCategory("bad").WithTraceRegex("[").Validate())
+}
+
+func TestCategory_Matches(t *testing.T) {
+	category := NewCategory("Timeouts").WithMatchedStatuses(Broken).WithMessageRegex(".*timeout.*")
+
+	require.True(t, category.Matches(Broken, StatusDetail{Message: "request timeout\nexceeded"}))
+	require.False(t, category.Matches(Failed, StatusDetail{Message: "request timeout"}))
+	require.False(t, category.Matches(Broken, StatusDetail{Message: "connection refused"}))
+	require.True(t, NewCategory("All").Matches(Passed, StatusDetail{}))
+
+	flaky := NewCategory("Flaky").WithFlaky(true)
+	require.True(t, flaky.Matches(Passed, StatusDetail{Flaky: true}))
+	require.False(t, flaky.Matches(Passed, StatusDetail{}))
+	require.False(t, NewCategory("All").Matches(Passed, StatusDetail{Flaky: true}))
+}
+
+func TestAddCategories(t *testing.T) {
+	defer resetCategories()
+
+	require.NoError(t, AddCategories(NewCategory("A").WithFlaky(true), NewCategory("B")))
+	require.NoError(t, AddCategories(NewCategory("A")))
+	require.Error(t, AddCategories(NewCategory("C").WithMessageRegex("(")))
+
+	registered, err := GetCategories()
+	require.NoError(t, err)
+	require.Equal(t, []*Category{NewCategory("A"), NewCategory("B")}, registered)
+}
+
+func TestLoadCategories(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "categories.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte(`
+- name: Infrastructure
+  matchedStatuses: [broken]
+  messageRegex: ".*connection refused.*"
+- name: Flaky
+  flaky: true
+`), 0o644))
+
+	jsonPath := filepath.Join(dir, "categories.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`[{"name":"Infrastructure","matchedStatuses":["broken"],"messageRegex":".*connection refused.*"},{"name":"Flaky","flaky":true}]`), 0o644))
+
+	expected := []*Category{
+		NewCategory("Infrastructure").WithMatchedStatuses(Broken).WithMessageRegex(".*connection refused.*"),
+		NewCategory("Flaky").WithFlaky(true),
+	}
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		loaded, err := LoadCategories(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, loaded)
+	}
+
+	badPath := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(badPath, []byte(`[{"name":"Bad","messageRegex":"("}]`), 0o644))
+	_, err := LoadCategories(badPath)
+	require.Error(t, err)
+
+	_, err = LoadCategories(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
+
+func TestLoadCategories_ParentDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "categories.json"), []byte(`[{"name":"Root"}]`), 0o644))
+
+	nested := filepath.Join(dir, "a", "b")
+	require.NoError(t, os.MkdirAll(nested, os.ModePerm))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(nested))
+	defer os.Chdir(wd)
+
+	loaded, err := LoadCategories("categories.json")
+	require.NoError(t, err)
+	require.Equal(t, []*Category{NewCategory("Root")}, loaded)
+}
+
+func TestGetCategories_loadError(t *testing.T) {
+	defer resetCategories()
+
+	os.Setenv(categoriesPathEnvKey, filepath.Join(t.TempDir(), "missing.json"))
+	defer os.Unsetenv(categoriesPathEnvKey)
+
+	_, err := GetCategories()
+	require.Error(t, err)
+	_, err = GetCategories()
+	require.Error(t, err)
+	require.Error(t, PrintCategories())
+}
+
+func TestPrintCategories(t *testing.T) {
+	defer resetCategories()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name":"FromFile"},{"name":"Override","flaky":true}]`), 0o644))
+	os.Setenv(categoriesPathEnvKey, path)
+	defer os.Unsetenv(categoriesPathEnvKey)
+
+	fm := NewMemoryFileManager()
+	SetFileManager(fm)
+	defer SetFileManager(nil)
+
+	// printed by another package
+	require.NoError(t, fm.CreateFile(categoriesFileName, []byte(`[{"name":"Other"}]`)))
+
+	require.NoError(t, AddCategories(NewCategory("Override")))
+	require.NoError(t, PrintCategories())
+
+	content, ok := fm.GetFile(categoriesFileName)
+	require.True(t, ok)
+
+	var printed []*Category
+	require.NoError(t, sonic.Unmarshal(content, &printed))
+	require.Equal(t, []*Category{NewCategory("Other"), NewCategory("FromFile"), NewCategory("Override")}, printed)
+}
+
+func TestPrintCategories_Empty(t *testing.T) {
+	defer resetCategories()
+
+	fm := NewMemoryFileManager()
+	SetFileManager(fm)
+	defer SetFileManager(nil)
+
+	require.NoError(t, PrintCategories())
+	require.Empty(t, fm.Files())
+}
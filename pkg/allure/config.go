@@ -10,6 +10,9 @@ const (
 	testCasePatternEnvKey = "ALLURE_TESTCASE_PATTERN" // Indicates the URL pattern for TestCase. It must contain exactly one `%s`
 	tmsLinkPatternEnvKey  = "ALLURE_LINK_TMS_PATTERN" // Indicates the URL pattern for TmsLink. It must contain exactly one `%s`
 	defaultTagsEnvKey     = "ALLURE_LAUNCH_TAGS"      // Indicates the default tags that will mark all tests in the run. The tags must be specified separated by commas.
+
+	collectEnvironmentEnvKey = "ALLURE_COLLECT_ENVIRONMENT" // If `false` - Go version, platform, build info and CI variables are not added to environment.properties
+	categoriesPathEnvKey     = "ALLURE_CATEGORIES_PATH"     // Indicates the path to the JSON or YAML file with categories rules
 )
 
 // Attachment permission
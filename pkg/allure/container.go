@@ -100,7 +100,7 @@ func (container *Container) printContainer() error {
 		return errors.Wrap(err, "Failed marshal Result")
 	}
 
-	err = NewFileManager().CreateFile(container.UUID.String()+"-container.json", bResult)
+	err = GetFileManager().CreateFile(container.UUID.String()+"-container.json", bResult)
 	if err != nil {
 		return errors.Wrap(err, "Error write Result")
 	}
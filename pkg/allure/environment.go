@@ -0,0 +1,247 @@
+package allure
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const environmentFileName = "environment.properties"
+
+// ciEnvironmentKeys are CI variables copied to the environment.properties if they are set
+var ciEnvironmentKeys = []string{
+	"CI",
+	// GitHub Actions
+	"GITHUB_REPOSITORY", "GITHUB_REF_NAME", "GITHUB_SHA", "GITHUB_WORKFLOW", "GITHUB_RUN_ID",
+	// GitLab CI
+	"CI_PROJECT_PATH", "CI_COMMIT_REF_NAME", "CI_COMMIT_SHA", "CI_PIPELINE_ID", "CI_JOB_NAME",
+	// Jenkins
+	"JOB_NAME", "BUILD_NUMBER", "GIT_BRANCH", "GIT_COMMIT",
+	// TeamCity
+	"TEAMCITY_VERSION", "BUILD_VCS_NUMBER",
+}
+
+var (
+	environmentMu sync.RWMutex
+	environment   = make(map[string]string)
+)
+
+// SetEnvironment registers environment key/value pair which will be printed to the `environment.properties`.
+// It is safe to call from parallel tests. The latest value of the key wins.
+func SetEnvironment(key, value string) {
+	environmentMu.Lock()
+	defer environmentMu.Unlock()
+
+	environment[key] = value
+}
+
+// SetEnvironmentMap registers all passed environment key/value pairs (see SetEnvironment)
+func SetEnvironmentMap(kv map[string]string) {
+	environmentMu.Lock()
+	defer environmentMu.Unlock()
+
+	for k, v := range kv {
+		environment[k] = v
+	}
+}
+
+// GetEnvironment returns copy of environment values of the current process:
+// auto-collected values (see CollectEnvironment) overridden with values registered by SetEnvironment.
+func GetEnvironment() map[string]string {
+	result := make(map[string]string)
+	if collectEnvironmentEnabled() {
+		result = CollectEnvironment()
+	}
+
+	environmentMu.RLock()
+	defer environmentMu.RUnlock()
+
+	for k, v := range environment {
+		result[k] = v
+	}
+
+	return result
+}
+
+// CollectEnvironment returns Go version, GOOS/GOARCH, main module build info and CI variables
+func CollectEnvironment() map[string]string {
+	result := map[string]string{
+		"go.version": runtime.Version(),
+		"go.os":      runtime.GOOS,
+		"go.arch":    runtime.GOARCH,
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Path != "" {
+		result["go.module"] = info.Main.Path
+		if version := info.Main.Version; version != "" && version != "(devel)" {
+			result["go.module.version"] = version
+		}
+	}
+
+	for _, key := range ciEnvironmentKeys {
+		if value := os.Getenv(key); value != "" {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+// PrintEnvironment merges environment values of the current process (see GetEnvironment)
+// into the `environment.properties` of the output folder. Values of the current process override existing ones,
+// values printed by other packages of the run are kept.
+func PrintEnvironment() error {
+	values := GetEnvironment()
+
+	err := UpdateFile(GetFileManager(), environmentFileName, func(content []byte) ([]byte, error) {
+		merged, err := parseProperties(content)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range values {
+			merged[k] = v
+		}
+
+		return formatProperties(merged), nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "Cannot save environment")
+	}
+
+	return nil
+}
+
+func collectEnvironmentEnabled() bool {
+	return !strings.EqualFold(os.Getenv(collectEnvironmentEnvKey), "false")
+}
+
+// formatProperties returns properties sorted by key in the .properties file format
+func formatProperties(properties map[string]string) []byte {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf := bytes.Buffer{}
+	for _, k := range keys {
+		buf.WriteString(escapeProperty(k, true))
+		buf.WriteByte('=')
+		buf.WriteString(escapeProperty(properties[k], false))
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
+
+// parseProperties parses simplified .properties file format: one `key=value` (or `key:value`) pair per line,
+// `#` and `!` comments. Multiline values are not supported.
+func parseProperties(content []byte) (map[string]string, error) {
+	result := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		sep := indexUnescaped(line, "=:")
+		if sep < 0 {
+			result[unescapeProperty(line)] = ""
+			continue
+		}
+
+		key := strings.TrimRight(line[:sep], " \t\f")
+		value := strings.TrimLeft(line[sep+1:], " \t\f")
+		result[unescapeProperty(key)] = unescapeProperty(value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse %s", environmentFileName)
+	}
+
+	return result, nil
+}
+
+func escapeProperty(s string, isKey bool) string {
+	b := strings.Builder{}
+
+	for i, r := range s {
+		switch r {
+		case '\\', '=', ':', '#', '!':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case ' ':
+			if isKey || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func unescapeProperty(s string) string {
+	b := strings.Builder{}
+
+	escaped := false
+	for _, r := range s {
+		if !escaped {
+			if r == '\\' {
+				escaped = true
+			} else {
+				b.WriteRune(r)
+			}
+
+			continue
+		}
+
+		escaped = false
+		switch r {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func indexUnescaped(s string, chars string) int {
+	escaped := false
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case strings.ContainsRune(chars, r):
+			return i
+		}
+	}
+
+	return -1
+}
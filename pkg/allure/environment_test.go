@@ -0,0 +1,106 @@
+package allure
+
+import (
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func resetEnvironment() {
+	environmentMu.Lock()
+	defer environmentMu.Unlock()
+
+	environment = make(map[string]string)
+}
+
+func TestCollectEnvironment(t *testing.T) {
+	os.Setenv("GITHUB_SHA", "abcdef")
+	defer os.Unsetenv("GITHUB_SHA")
+
+	env := CollectEnvironment()
+	require.Equal(t, runtime.Version(), env["go.version"])
+	require.Equal(t, runtime.GOOS, env["go.os"])
+	require.Equal(t, runtime.GOARCH, env["go.arch"])
+	require.Equal(t, "abcdef", env["GITHUB_SHA"])
+	require.NotContains(t, env, "CI_COMMIT_SHA")
+}
+
+func TestGetEnvironment(t *testing.T) {
+	defer resetEnvironment()
+
+	SetEnvironment("go.os", "custom")
+	SetEnvironmentMap(map[string]string{"stand": "qa", "browser": "chrome"})
+
+	env := GetEnvironment()
+	require.Equal(t, "custom", env["go.os"])
+	require.Equal(t, "qa", env["stand"])
+	require.Equal(t, "chrome", env["browser"])
+	require.Equal(t, runtime.Version(), env["go.version"])
+}
+
+func TestGetEnvironment_NoCollect(t *testing.T) {
+	defer resetEnvironment()
+	os.Setenv(collectEnvironmentEnvKey, "false")
+	defer os.Unsetenv(collectEnvironmentEnvKey)
+
+	SetEnvironment("stand", "qa")
+	require.Equal(t, map[string]string{"stand": "qa"}, GetEnvironment())
+}
+
+func TestSetEnvironment_Parallel(t *testing.T) {
+	defer resetEnvironment()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			SetEnvironment("stand", "qa")
+			_ = GetEnvironment()
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, "qa", GetEnvironment()["stand"])
+}
+
+func TestPrintEnvironment(t *testing.T) {
+	defer resetEnvironment()
+	os.Setenv(collectEnvironmentEnvKey, "false")
+	defer os.Unsetenv(collectEnvironmentEnvKey)
+
+	fm := NewMemoryFileManager()
+	SetFileManager(fm)
+	defer SetFileManager(nil)
+
+	// printed by another package
+	require.NoError(t, fm.CreateFile(environmentFileName, []byte("# comment\nother=value\nstand=dev\n")))
+
+	SetEnvironment("stand", "qa")
+	SetEnvironment("url", "http://host:8080/a=b")
+	require.NoError(t, PrintEnvironment())
+
+	content, ok := fm.GetFile(environmentFileName)
+	require.True(t, ok)
+	require.Equal(t, "other=value\nstand=qa\nurl=http\\://host\\:8080/a\\=b\n", string(content))
+
+	props, err := parseProperties(content)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"other": "value", "stand": "qa", "url": "http://host:8080/a=b"}, props)
+}
+
+func TestProperties_EscapeRoundTrip(t *testing.T) {
+	values := map[string]string{
+		"key with spaces": " leading space",
+		"multi":           "line1\nline2\ttab",
+		"path":            `C:\go\bin`,
+		"empty":           "",
+	}
+
+	props, err := parseProperties(formatProperties(values))
+	require.NoError(t, err)
+	require.Equal(t, values, props)
+}
@@ -0,0 +1,159 @@
+package allure
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/bytedance/sonic"
+	"github.com/pkg/errors"
+)
+
+const executorFileName = "executor.json"
+
+// Executor is an implementation of the executor descriptor used by Allure to show Executor widget
+// and to link reports of the same build history.
+type Executor struct {
+	Name       string `json:"name,omitempty"`       // Executor name (e.g. "GitHub Actions")
+	Type       string `json:"type,omitempty"`       // Executor type (e.g. "github"). Used by Allure to pick the icon
+	URL        string `json:"url,omitempty"`        // Executor URL
+	BuildOrder int64  `json:"buildOrder,omitempty"` // Build number. Used by Allure to order history
+	BuildName  string `json:"buildName,omitempty"`  // Build name
+	BuildURL   string `json:"buildUrl,omitempty"`   // Build URL
+	ReportName string `json:"reportName,omitempty"` // Report name
+	ReportURL  string `json:"reportUrl,omitempty"`  // Report URL
+}
+
+var (
+	executorMu sync.Mutex
+	executor   *Executor
+	// executorPrinted has output folders and sinks (see printedKey), which executor is already printed to
+	executorPrinted = make(map[interface{}]bool)
+)
+
+// SetExecutor registers executor descriptor. If nothing is registered, executor is detected from CI variables.
+func SetExecutor(e *Executor) {
+	executorMu.Lock()
+	defer executorMu.Unlock()
+
+	executor = e
+}
+
+// GetExecutor returns executor registered with SetExecutor or detected from CI variables.
+// Returns nil if no executor can be found.
+func GetExecutor() *Executor {
+	executorMu.Lock()
+	defer executorMu.Unlock()
+
+	if executor != nil {
+		return executor
+	}
+
+	return detectExecutor()
+}
+
+// PrintExecutor writes `executor.json` to the output folder (FileManager) once.
+// If the output folder already has `executor.json` (e.g. printed by another package of the run), it is kept as is.
+func PrintExecutor() error {
+	e := GetExecutor()
+	if e == nil {
+		return nil
+	}
+
+	executorMu.Lock()
+	defer executorMu.Unlock()
+
+	fm := GetFileManager()
+	key, ok := printedKey(fm)
+	if ok && executorPrinted[key] {
+		return nil
+	}
+
+	err := UpdateFile(fm, executorFileName, func(content []byte) ([]byte, error) {
+		if len(content) > 0 {
+			return content, nil
+		}
+
+		return sonic.Marshal(e)
+	})
+	if err != nil {
+		return errors.Wrap(err, "Cannot save executor")
+	}
+
+	if ok {
+		executorPrinted[key] = true
+	}
+
+	return nil
+}
+
+// printedKey returns the key of the output folder for directory FileManager (a new one is created for every
+// GetFileManager call without SetFileManager) and FileManager itself for others.
+// FileManager of not comparable type can't be the key, so false is returned
+func printedKey(fm FileManager) (interface{}, bool) {
+	if dir, ok := fm.(*fileManager); ok {
+		return dir.resultsPath, true
+	}
+
+	return fm, reflect.TypeOf(fm).Comparable()
+}
+
+// detectExecutor returns Executor built from well known CI variables
+func detectExecutor() *Executor {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		server := os.Getenv("GITHUB_SERVER_URL")
+		repo := os.Getenv("GITHUB_REPOSITORY")
+		runNumber := os.Getenv("GITHUB_RUN_NUMBER")
+
+		return &Executor{
+			Name:       "GitHub Actions",
+			Type:       "github",
+			URL:        fmt.Sprintf("%s/%s/actions", server, repo),
+			BuildOrder: parseBuildOrder(runNumber),
+			BuildName:  fmt.Sprintf("%s #%s", os.Getenv("GITHUB_WORKFLOW"), runNumber),
+			BuildURL:   fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, os.Getenv("GITHUB_RUN_ID")),
+		}
+
+	case os.Getenv("GITLAB_CI") == "true":
+		return &Executor{
+			Name:       "GitLab CI",
+			Type:       "gitlab",
+			URL:        os.Getenv("CI_PROJECT_URL"),
+			BuildOrder: parseBuildOrder(os.Getenv("CI_PIPELINE_IID")),
+			BuildName:  fmt.Sprintf("%s #%s", os.Getenv("CI_PROJECT_PATH"), os.Getenv("CI_PIPELINE_IID")),
+			BuildURL:   os.Getenv("CI_PIPELINE_URL"),
+		}
+
+	case os.Getenv("JENKINS_URL") != "":
+		return &Executor{
+			Name:       "Jenkins",
+			Type:       "jenkins",
+			URL:        os.Getenv("JENKINS_URL"),
+			BuildOrder: parseBuildOrder(os.Getenv("BUILD_NUMBER")),
+			BuildName:  os.Getenv("BUILD_TAG"),
+			BuildURL:   os.Getenv("BUILD_URL"),
+		}
+
+	case os.Getenv("TEAMCITY_VERSION") != "":
+		return &Executor{
+			Name:       "TeamCity",
+			Type:       "teamcity",
+			BuildOrder: parseBuildOrder(os.Getenv("BUILD_NUMBER")),
+			BuildName:  os.Getenv("TEAMCITY_BUILDCONF_NAME"),
+		}
+	}
+
+	return nil
+}
+
+func parseBuildOrder(s string) int64 {
+	order, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return order
+}
@@ -0,0 +1,118 @@
+package allure
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/stretchr/testify/require"
+)
+
+func resetExecutor() {
+	executorMu.Lock()
+	defer executorMu.Unlock()
+
+	executor = nil
+	executorPrinted = make(map[interface{}]bool)
+}
+
+func TestGetExecutor_NotFound(t *testing.T) {
+	for _, key := range []string{"GITHUB_ACTIONS", "GITLAB_CI", "JENKINS_URL", "TEAMCITY_VERSION"} {
+		if os.Getenv(key) != "" {
+			t.Skipf("%s is set", key)
+		}
+	}
+
+	require.Nil(t, GetExecutor())
+	require.NoError(t, PrintExecutor())
+}
+
+func TestGetExecutor_GitLab(t *testing.T) {
+	envs := map[string]string{
+		"GITHUB_ACTIONS":  "",
+		"GITLAB_CI":       "true",
+		"CI_PROJECT_URL":  "https://gitlab.com/group/project",
+		"CI_PROJECT_PATH": "group/project",
+		"CI_PIPELINE_IID": "42",
+		"CI_PIPELINE_URL": "https://gitlab.com/group/project/-/pipelines/100500",
+	}
+	for k, v := range envs {
+		old, ok := os.LookupEnv(k)
+		os.Setenv(k, v)
+		defer func(k, old string, ok bool) {
+			if ok {
+				os.Setenv(k, old)
+			} else {
+				os.Unsetenv(k)
+			}
+		}(k, old, ok)
+	}
+
+	require.Equal(t, &Executor{
+		Name:       "GitLab CI",
+		Type:       "gitlab",
+		URL:        "https://gitlab.com/group/project",
+		BuildOrder: 42,
+		BuildName:  "group/project #42",
+		BuildURL:   "https://gitlab.com/group/project/-/pipelines/100500",
+	}, GetExecutor())
+}
+
+func TestPrintExecutor(t *testing.T) {
+	defer resetExecutor()
+
+	fm := NewMemoryFileManager()
+	SetFileManager(fm)
+	defer SetFileManager(nil)
+
+	e := &Executor{Name: "Local", Type: "local", BuildOrder: 3, BuildName: "local #3"}
+	SetExecutor(e)
+	require.Equal(t, e, GetExecutor())
+	require.NoError(t, PrintExecutor())
+
+	content, ok := fm.GetFile(executorFileName)
+	require.True(t, ok)
+
+	var printed Executor
+	require.NoError(t, sonic.Unmarshal(content, &printed))
+	require.Equal(t, *e, printed)
+
+	// printed only once
+	SetExecutor(&Executor{Name: "Other"})
+	require.NoError(t, PrintExecutor())
+	content, _ = fm.GetFile(executorFileName)
+	require.NoError(t, sonic.Unmarshal(content, &printed))
+	require.Equal(t, *e, printed)
+}
+
+func TestPrintExecutor_PerFileManager(t *testing.T) {
+	defer resetExecutor()
+	defer SetFileManager(nil)
+
+	SetExecutor(&Executor{Name: "Local"})
+	first, second := NewMemoryFileManager(), NewMemoryFileManager()
+
+	SetFileManager(first)
+	require.NoError(t, PrintExecutor())
+	SetFileManager(second)
+	require.NoError(t, PrintExecutor())
+
+	require.Contains(t, first.Files(), executorFileName)
+	require.Contains(t, second.Files(), executorFileName)
+}
+
+func TestPrintExecutor_KeepsExisting(t *testing.T) {
+	defer resetExecutor()
+
+	fm := NewMemoryFileManager()
+	SetFileManager(fm)
+	defer SetFileManager(nil)
+
+	require.NoError(t, fm.CreateFile(executorFileName, []byte(`{"name":"First"}`)))
+
+	SetExecutor(&Executor{Name: "Second"})
+	require.NoError(t, PrintExecutor())
+
+	content, _ := fm.GetFile(executorFileName)
+	require.Equal(t, `{"name":"First"}`, string(content))
+}
@@ -3,19 +3,54 @@ package allure
 import (
 	"os"
 	"path/filepath"
+	"sync"
 )
 
+// FileManager is a sink for everything allure-go produces: results, containers, attachments
+// and run-level descriptors. The file name is always relative to the results root.
 type FileManager interface {
 	CreateFile(name string, content []byte) error
 }
 
+var (
+	fileManagerMu      sync.RWMutex
+	currentFileManager FileManager
+)
+
+// SetFileManager registers process-wide FileManager that will be used to write all allure files.
+// Passing nil restores the default behaviour (writing to `$ALLURE_OUTPUT_PATH`/`$ALLURE_OUTPUT_FOLDER`).
+func SetFileManager(fm FileManager) {
+	fileManagerMu.Lock()
+	defer fileManagerMu.Unlock()
+
+	currentFileManager = fm
+}
+
+// GetFileManager returns registered FileManager.
+// If nothing was registered with SetFileManager, returns new directory FileManager (see NewFileManager).
+func GetFileManager() FileManager {
+	fileManagerMu.RLock()
+	defer fileManagerMu.RUnlock()
+
+	if currentFileManager != nil {
+		return currentFileManager
+	}
+
+	return NewFileManager()
+}
+
 type fileManager struct {
 	resultsPath string
 }
 
+// NewFileManager returns directory FileManager, which writes files to `$ALLURE_OUTPUT_PATH`/`$ALLURE_OUTPUT_FOLDER`
 func NewFileManager() FileManager {
-	resultsPath := getResultPath()
+	return NewDirFileManager(getResultPath())
+}
 
+// NewDirFileManager returns FileManager, which writes files to the resultsPath directory.
+// The directory is created if it does not exist.
+func NewDirFileManager(resultsPath string) FileManager {
 	fm := &fileManager{resultsPath: resultsPath}
 	fm.createOutputDir()
 
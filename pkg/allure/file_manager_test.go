@@ -59,3 +59,36 @@ func TestFileManager_CreateFile(t *testing.T) {
 	require.NoError(t, readErr)
 	require.Equal(t, fileContent, string(bytes))
 }
+
+func TestNewDirFileManager(t *testing.T) {
+	dir := t.TempDir() + "/custom-results"
+	fm := NewDirFileManager(dir)
+	require.DirExists(t, dir)
+
+	require.NoError(t, fm.CreateFile("test.txt", []byte("SOME TEXT")))
+	require.FileExists(t, dir+"/test.txt")
+}
+
+func TestGetFileManager_Default(t *testing.T) {
+	fm := GetFileManager()
+	defer os.RemoveAll(allureDir)
+
+	_, ok := fm.(*fileManager)
+	require.True(t, ok)
+}
+
+func TestSetFileManager(t *testing.T) {
+	mem := NewMemoryFileManager()
+	SetFileManager(mem)
+	defer SetFileManager(nil)
+
+	require.Equal(t, mem, GetFileManager())
+
+	result := NewResult("name", "full name")
+	require.NoError(t, result.Print())
+
+	content, ok := mem.GetFile(result.UUID.String() + "-result.json")
+	require.True(t, ok)
+	require.Contains(t, string(content), result.UUID.String())
+	require.NoDirExists(t, allureDir)
+}
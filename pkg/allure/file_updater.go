@@ -0,0 +1,126 @@
+package allure
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	lockFileSuffix   = ".lock"
+	lockRetryTimeout = 10 * time.Millisecond
+	lockWaitTimeout  = 30 * time.Second
+	lockStaleTimeout = 10 * time.Second
+)
+
+// FileUpdater is implemented by FileManager which is able to read and rewrite existing file atomically.
+// It is used to merge run-level files (e.g. environment.properties) produced by parallel tests and packages.
+type FileUpdater interface {
+	UpdateFile(name string, update func(content []byte) ([]byte, error)) error
+}
+
+// UpdateFile updates file with passed function. If FileManager does not implement FileUpdater,
+// update gets nil content and the result is written with FileManager.CreateFile.
+func UpdateFile(fm FileManager, name string, update func(content []byte) ([]byte, error)) error {
+	if updater, ok := fm.(FileUpdater); ok {
+		return updater.UpdateFile(name, update)
+	}
+
+	content, err := update(nil)
+	if err != nil {
+		return err
+	}
+
+	return fm.CreateFile(name, content)
+}
+
+// UpdateFile locks the file with `<name>.lock` file (so it is safe to call from several processes),
+// reads it, calls update and atomically replaces the file with the new content.
+func (m *fileManager) UpdateFile(name string, update func(content []byte) ([]byte, error)) error {
+	file := filepath.Join(m.resultsPath, name)
+
+	unlock, err := lockFile(file + lockFileSuffix)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	content, err := os.ReadFile(filepath.Clean(file))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "cannot read %s", name)
+	}
+
+	newContent, err := update(content)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(m.resultsPath, name+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "cannot update %s", name)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(newContent); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "cannot update %s", name)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return errors.Wrapf(err, "cannot update %s", name)
+	}
+
+	if err = os.Chmod(tmp.Name(), fileSystemPermissionCode); err != nil {
+		return errors.Wrapf(err, "cannot update %s", name)
+	}
+
+	return os.Rename(tmp.Name(), file)
+}
+
+// UpdateFile reads the file, calls update and saves the new content
+func (m *MemoryFileManager) UpdateFile(name string, update func(content []byte) ([]byte, error)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	newContent, err := update(m.files[name])
+	if err != nil {
+		return err
+	}
+
+	m.files[name] = append([]byte(nil), newContent...)
+
+	return nil
+}
+
+// lockFile creates lock file exclusively and returns function that removes it.
+// Lock files older than lockStaleTimeout are considered abandoned and removed.
+func lockFile(path string) (func(), error) {
+	deadline := time.Now().Add(lockWaitTimeout)
+
+	for {
+		//nolint:gosec // path is built from results path and constant file name
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, fileSystemPermissionCode)
+		if err == nil {
+			_ = f.Close()
+
+			return func() { _ = os.Remove(path) }, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, errors.Wrapf(err, "cannot lock %s", path)
+		}
+
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > lockStaleTimeout {
+			_ = os.Remove(path)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("cannot lock %s: timeout exceeded", path)
+		}
+
+		time.Sleep(lockRetryTimeout)
+	}
+}
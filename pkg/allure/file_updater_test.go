@@ -0,0 +1,88 @@
+package allure
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileManager_UpdateFile(t *testing.T) {
+	dir := t.TempDir()
+	fm := NewDirFileManager(dir)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := UpdateFile(fm, "counter.txt", func(content []byte) ([]byte, error) {
+				return append(content, []byte(strconv.Itoa(i)+"\n")...), nil
+			})
+			require.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	content, err := os.ReadFile(filepath.Join(dir, "counter.txt"))
+	require.NoError(t, err)
+	require.Len(t, bytes.Split(bytes.TrimSpace(content), []byte("\n")), 20)
+
+	files, _ := os.ReadDir(dir)
+	require.Len(t, files, 1)
+}
+
+func TestFileManager_UpdateFile_StaleLock(t *testing.T) {
+	dir := t.TempDir()
+	fm := NewDirFileManager(dir)
+
+	lock := filepath.Join(dir, "file.txt"+lockFileSuffix)
+	require.NoError(t, os.WriteFile(lock, nil, fileSystemPermissionCode))
+	stale := time.Now().Add(-2 * lockStaleTimeout)
+	require.NoError(t, os.Chtimes(lock, stale, stale))
+
+	require.NoError(t, UpdateFile(fm, "file.txt", func([]byte) ([]byte, error) {
+		return []byte("content"), nil
+	}))
+	require.NoFileExists(t, lock)
+}
+
+// createOnlyFileManager is FileManager without FileUpdater implementation
+type createOnlyFileManager struct {
+	files *MemoryFileManager
+}
+
+func (m createOnlyFileManager) CreateFile(name string, content []byte) error {
+	return m.files.CreateFile(name, content)
+}
+
+func TestUpdateFile_NotUpdater(t *testing.T) {
+	fm := createOnlyFileManager{NewMemoryFileManager()}
+	require.NoError(t, fm.CreateFile("file.txt", []byte("old")))
+
+	var got []byte
+	require.NoError(t, UpdateFile(fm, "file.txt", func(content []byte) ([]byte, error) {
+		got = content
+		return []byte("content"), nil
+	}))
+	require.Nil(t, got)
+
+	content, _ := fm.files.GetFile("file.txt")
+	require.Equal(t, "content", string(content))
+}
+
+func TestMemoryFileManager_UpdateFile(t *testing.T) {
+	fm := NewMemoryFileManager()
+	require.NoError(t, fm.CreateFile("file.txt", []byte("a")))
+	require.NoError(t, UpdateFile(fm, "file.txt", func(content []byte) ([]byte, error) {
+		return append(content, 'b'), nil
+	}))
+
+	content, _ := fm.GetFile("file.txt")
+	require.Equal(t, "ab", string(content))
+}
@@ -0,0 +1,123 @@
+package allure
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const httpErrorBodyLimit = 512
+
+// HTTPFileManager is a FileManager that uploads every created file to the results collector.
+// Each file is sent as a separate request to `<endpoint>/<file name>`.
+// Files changed with UpdateFile (e.g. environment.properties) are uploaded again with all their updates.
+type HTTPFileManager struct {
+	endpoint string
+	method   string
+	client   *http.Client
+	header   http.Header
+
+	mu      sync.Mutex
+	updated map[string][]byte
+}
+
+// NewHTTPFileManager Constructor. Returns HTTPFileManager which sends files with PUT requests
+// to the endpoint using http.DefaultClient.
+func NewHTTPFileManager(endpoint string) *HTTPFileManager {
+	return &HTTPFileManager{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		method:   http.MethodPut,
+		client:   http.DefaultClient,
+		header:   make(http.Header),
+		updated:  make(map[string][]byte),
+	}
+}
+
+// WithClient sets http.Client used for uploads.
+// Returns a pointer to the current HTTPFileManager (for Fluent Interface).
+func (m *HTTPFileManager) WithClient(client *http.Client) *HTTPFileManager {
+	m.client = client
+
+	return m
+}
+
+// WithMethod sets HTTP method used for uploads.
+// Returns a pointer to the current HTTPFileManager (for Fluent Interface).
+func (m *HTTPFileManager) WithMethod(method string) *HTTPFileManager {
+	m.method = method
+
+	return m
+}
+
+// WithHeader adds header to every upload request (e.g. authorization token).
+// Returns a pointer to the current HTTPFileManager (for Fluent Interface).
+func (m *HTTPFileManager) WithHeader(key, value string) *HTTPFileManager {
+	m.header.Add(key, value)
+
+	return m
+}
+
+// CreateFile uploads the file. Any non 2xx response is returned as error.
+func (m *HTTPFileManager) CreateFile(name string, content []byte) error {
+	req, err := http.NewRequest(m.method, m.endpoint+"/"+url.PathEscape(name), bytes.NewReader(content))
+	if err != nil {
+		return errors.Wrapf(err, "cannot build upload request for %s", name)
+	}
+
+	for key, values := range m.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", contentTypeByName(name))
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "cannot upload %s", name)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, httpErrorBodyLimit))
+
+		return errors.Errorf("cannot upload %s: unexpected status %s: %s", name, resp.Status, body)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// UpdateFile calls update with the content of the previous update of the file (nil for the first one)
+// and uploads the result, which replaces the file of the collector
+func (m *HTTPFileManager) UpdateFile(name string, update func(content []byte) ([]byte, error)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	newContent, err := update(m.updated[name])
+	if err != nil {
+		return err
+	}
+
+	if err = m.CreateFile(name, newContent); err != nil {
+		return err
+	}
+	m.updated[name] = append([]byte(nil), newContent...)
+
+	return nil
+}
+
+func contentTypeByName(name string) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+		return contentType
+	}
+
+	return "application/octet-stream"
+}
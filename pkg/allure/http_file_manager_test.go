@@ -0,0 +1,76 @@
+package allure
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPFileManager_CreateFile(t *testing.T) {
+	var (
+		method, path, contentType, token string
+		body                             []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		contentType, token = r.Header.Get("Content-Type"), r.Header.Get("Authorization")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	fm := NewHTTPFileManager(server.URL+"/results/").WithHeader("Authorization", "Bearer token")
+	require.NoError(t, fm.CreateFile("uuid-result.json", []byte(`{}`)))
+
+	require.Equal(t, http.MethodPut, method)
+	require.Equal(t, "/results/uuid-result.json", path)
+	require.Equal(t, "application/json", contentType)
+	require.Equal(t, "Bearer token", token)
+	require.Equal(t, `{}`, string(body))
+}
+
+func TestHTTPFileManager_WithMethod(t *testing.T) {
+	var method string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+	}))
+	defer server.Close()
+
+	fm := NewHTTPFileManager(server.URL).WithMethod(http.MethodPost).WithClient(server.Client())
+	require.NoError(t, fm.CreateFile("file.bin", []byte{1}))
+	require.Equal(t, http.MethodPost, method)
+}
+
+func TestHTTPFileManager_CreateFile_BadStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("access denied"))
+	}))
+	defer server.Close()
+
+	err := NewHTTPFileManager(server.URL).CreateFile("uuid-result.json", []byte(`{}`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "403")
+	require.Contains(t, err.Error(), "access denied")
+}
+
+func TestHTTPFileManager_UpdateFile(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+
+	fm := NewHTTPFileManager(server.URL).WithClient(server.Client())
+	for _, value := range []string{"a", "b"} {
+		value := value
+		require.NoError(t, UpdateFile(fm, "environment.properties", func(content []byte) ([]byte, error) {
+			return append(content, value...), nil
+		}))
+	}
+	require.Equal(t, []string{"a", "ab"}, bodies)
+}
@@ -0,0 +1,60 @@
+package allure
+
+import (
+	"sort"
+	"sync"
+)
+
+// MemoryFileManager is a FileManager that keeps all created files in memory.
+// Most often it is used in meta-tests to check what allure-go produces without touching the disk.
+type MemoryFileManager struct {
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+// NewMemoryFileManager Constructor. Returns pointer to new empty MemoryFileManager.
+func NewMemoryFileManager() *MemoryFileManager {
+	return &MemoryFileManager{files: make(map[string][]byte)}
+}
+
+// CreateFile saves copy of the content under the passed name. Existing file with the same name is overwritten.
+func (m *MemoryFileManager) CreateFile(name string, content []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.files[name] = append([]byte(nil), content...)
+
+	return nil
+}
+
+// GetFile returns content of the file and true if file exists
+func (m *MemoryFileManager) GetFile(name string) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	content, ok := m.files[name]
+
+	return content, ok
+}
+
+// Files returns sorted names of all created files
+func (m *MemoryFileManager) Files() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Reset removes all created files
+func (m *MemoryFileManager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.files = make(map[string][]byte)
+}
@@ -0,0 +1,49 @@
+package allure
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryFileManager_CreateFile(t *testing.T) {
+	fm := NewMemoryFileManager()
+	content := []byte("content")
+	require.NoError(t, fm.CreateFile("b.txt", content))
+	require.NoError(t, fm.CreateFile("a.txt", []byte("other")))
+
+	// content is copied
+	content[0] = 'X'
+
+	got, ok := fm.GetFile("b.txt")
+	require.True(t, ok)
+	require.Equal(t, "content", string(got))
+	require.Equal(t, []string{"a.txt", "b.txt"}, fm.Files())
+
+	_, ok = fm.GetFile("c.txt")
+	require.False(t, ok)
+}
+
+func TestMemoryFileManager_Reset(t *testing.T) {
+	fm := NewMemoryFileManager()
+	require.NoError(t, fm.CreateFile("a.txt", []byte("a")))
+	fm.Reset()
+	require.Empty(t, fm.Files())
+}
+
+func TestMemoryFileManager_Concurrent(t *testing.T) {
+	fm := NewMemoryFileManager()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = fm.CreateFile(NewAttachment("a", Text, nil).Source, []byte{byte(i)})
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, fm.Files(), 50)
+}
@@ -0,0 +1,233 @@
+package allure
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/bytedance/sonic"
+	"github.com/pkg/errors"
+)
+
+// MergeResults merges results folders loaded with LoadResults (e.g. of parallel CI shards) into one.
+// Results and containers are deduplicated by UUID, the first one wins. Environment properties, other files
+// and categories (by name) are merged with the same rule. Executor is the first one set.
+func MergeResults(sources ...*Results) *Results {
+	merged := &Results{
+		Environment: make(map[string]string),
+		Files:       make(map[string][]byte),
+		originals:   make(map[interface{}]original),
+	}
+
+	var (
+		seenResults    = make(map[string]bool)
+		seenContainers = make(map[string]bool)
+		seenMissing    = make(map[string]bool)
+		seenCategories = make(map[string]bool)
+	)
+
+	for _, src := range sources {
+		for _, result := range src.Results {
+			if id := result.UUID.String(); !seenResults[id] {
+				seenResults[id] = true
+				merged.Results = append(merged.Results, result)
+			}
+		}
+
+		for _, container := range src.Containers {
+			if id := container.UUID.String(); !seenContainers[id] {
+				seenContainers[id] = true
+				merged.Containers = append(merged.Containers, container)
+			}
+		}
+
+		for k, v := range src.Environment {
+			if _, ok := merged.Environment[k]; !ok {
+				merged.Environment[k] = v
+			}
+		}
+
+		for name, content := range src.Files {
+			if _, ok := merged.Files[name]; !ok {
+				merged.Files[name] = content
+			}
+		}
+
+		for _, source := range src.Missing {
+			if !seenMissing[source] {
+				seenMissing[source] = true
+				merged.Missing = append(merged.Missing, source)
+			}
+		}
+
+		if merged.Executor == nil {
+			merged.Executor = src.Executor
+		}
+
+		for v, o := range src.originals {
+			merged.originals[v] = o
+		}
+
+		for _, category := range src.Categories {
+			if !seenCategories[category.Name] {
+				seenCategories[category.Name] = true
+				merged.Categories = append(merged.Categories, category)
+			}
+		}
+	}
+
+	sort.SliceStable(merged.Results, func(i, j int) bool { return merged.Results[i].Start < merged.Results[j].Start })
+	sort.SliceStable(merged.Containers, func(i, j int) bool { return merged.Containers[i].Start < merged.Containers[j].Start })
+	merged.index()
+
+	return merged
+}
+
+// Select returns new Results with results for which f returns true. Containers keep only selected children,
+// containers without children are dropped. Environment, executor, categories and other files are kept
+func (r *Results) Select(f func(result *Result) bool) *Results {
+	selected := &Results{
+		Results:     r.Filter(f),
+		Environment: r.Environment,
+		Executor:    r.Executor,
+		Categories:  r.Categories,
+		Missing:     r.Missing,
+		Files:       r.Files,
+		originals:   r.originals,
+	}
+
+	ids := make(map[string]bool, len(selected.Results))
+	for _, result := range selected.Results {
+		ids[result.UUID.String()] = true
+	}
+
+	for _, container := range r.Containers {
+		c := *container
+		c.Children = nil
+		for _, child := range container.Children {
+			if ids[child.String()] {
+				c.Children = append(c.Children, child)
+			}
+		}
+
+		switch {
+		case len(c.Children) == len(container.Children):
+			// unchanged container keeps its original file
+			selected.Containers = append(selected.Containers, container)
+		case len(c.Children) > 0:
+			selected.Containers = append(selected.Containers, &c)
+		}
+	}
+	selected.index()
+
+	return selected
+}
+
+// Write writes all results, containers, their attachments, environment.properties, executor.json,
+// categories.json and other files to fm. Missing attachments are not written.
+// Results and containers which were not changed after LoadResults are written as they were loaded
+func (r *Results) Write(fm FileManager) error {
+	missing := make(map[string]bool, len(r.Missing))
+	for _, source := range r.Missing {
+		missing[source] = true
+	}
+
+	written := make(map[string]bool)
+	writeAttachments := func(attachments []*Attachment) error {
+		for _, a := range attachments {
+			if missing[a.Source] || written[a.Source] {
+				continue
+			}
+			written[a.Source] = true
+
+			if err := fm.CreateFile(a.Source, a.content); err != nil {
+				return errors.Wrapf(err, "cannot write attachment %s", a.Source)
+			}
+		}
+
+		return nil
+	}
+
+	writeStepAttachments := func(steps []*Step) (err error) {
+		walkSteps(steps, func(s *Step) {
+			if err == nil {
+				err = writeAttachments(s.Attachments)
+			}
+		})
+
+		return err
+	}
+
+	for _, result := range r.Results {
+		if err := writeAttachments(result.Attachments); err != nil {
+			return err
+		}
+		if err := writeStepAttachments(result.Steps); err != nil {
+			return err
+		}
+		if err := r.writeLoaded(fm, fmt.Sprintf("%s%s", result.UUID, resultFileSuffix), result); err != nil {
+			return err
+		}
+	}
+
+	for _, container := range r.Containers {
+		if err := writeStepAttachments(container.Befores); err != nil {
+			return err
+		}
+		if err := writeStepAttachments(container.Afters); err != nil {
+			return err
+		}
+		if err := r.writeLoaded(fm, fmt.Sprintf("%s%s", container.UUID, containerFileSuffix), container); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Environment) > 0 {
+		if err := fm.CreateFile(environmentFileName, formatProperties(r.Environment)); err != nil {
+			return errors.Wrap(err, "cannot write environment")
+		}
+	}
+
+	if r.Executor != nil {
+		if err := writeJSON(fm, executorFileName, r.Executor); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Categories) > 0 {
+		if err := writeJSON(fm, categoriesFileName, r.Categories); err != nil {
+			return err
+		}
+	}
+
+	for name, content := range r.Files {
+		if err := fm.CreateFile(name, content); err != nil {
+			return errors.Wrapf(err, "cannot write %s", name)
+		}
+	}
+
+	return nil
+}
+
+// writeLoaded writes original file of the result or the container v, if v was not changed after loading
+func (r *Results) writeLoaded(fm FileManager, name string, v interface{}) error {
+	content, err := sonic.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal %s", name)
+	}
+
+	if o, ok := r.originals[v]; ok && bytes.Equal(o.marshaled, content) {
+		content = o.content
+	}
+
+	return errors.Wrapf(fm.CreateFile(name, content), "cannot write %s", name)
+}
+
+func writeJSON(fm FileManager, name string, v interface{}) error {
+	content, err := sonic.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal %s", name)
+	}
+
+	return errors.Wrapf(fm.CreateFile(name, content), "cannot write %s", name)
+}
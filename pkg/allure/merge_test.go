@@ -0,0 +1,167 @@
+package allure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/stretchr/testify/require"
+)
+
+// printShard prints results and containers to new directory and loads it
+func printShard(t *testing.T, results []*Result, containers ...*Container) *Results {
+	dir := t.TempDir()
+	SetFileManager(NewDirFileManager(dir))
+	defer SetFileManager(nil)
+
+	for _, result := range results {
+		require.NoError(t, result.Print())
+	}
+	for _, container := range containers {
+		require.NoError(t, container.Print())
+	}
+
+	loaded, err := LoadResults(dir)
+	require.NoError(t, err)
+
+	return loaded
+}
+
+func newTestResult(name string, status Status, start, stop int64) *Result {
+	result := NewResult(name, "pkg/"+name)
+	result.Status = status
+	result.Start, result.Stop = start, stop
+
+	return result
+}
+
+func TestMergeResults(t *testing.T) {
+	first := newTestResult("First", Passed, 10, 20)
+	first.Attachments = append(first.Attachments, NewAttachment("log", Text, []byte("first log")))
+	second := newTestResult("Second", Failed, 5, 30)
+	second.Steps = append(second.Steps, NewSimpleStep("step").WithAttachments(NewAttachment("body", JSON, []byte(`{}`))))
+
+	container := NewContainer()
+	container.AddChild(first.UUID)
+	container.Befores = append(container.Befores, NewSimpleStep("setup"))
+
+	shardA := printShard(t, []*Result{first}, container)
+	shardA.Environment = map[string]string{"stand": "qa", "shard": "a"}
+	shardA.Categories = []*Category{NewCategory("Timeouts")}
+	shardB := printShard(t, []*Result{second, first})
+	shardB.Environment = map[string]string{"shard": "b", "os": "linux"}
+	shardB.Executor = &Executor{Name: "CI"}
+	shardB.Categories = []*Category{NewCategory("Flaky"), NewCategory("Timeouts").WithFlaky(true)}
+
+	merged := MergeResults(shardA, shardB)
+	require.Len(t, merged.Results, 2)
+	require.Equal(t, second.UUID, merged.Results[0].UUID)
+	require.Len(t, merged.Containers, 1)
+	require.Len(t, merged.GetContainers(first.UUID.String()), 1)
+	require.Equal(t, map[string]string{"stand": "qa", "shard": "a", "os": "linux"}, merged.Environment)
+	require.Equal(t, &Executor{Name: "CI"}, merged.Executor)
+	require.Equal(t, []*Category{NewCategory("Timeouts"), NewCategory("Flaky")}, merged.Categories)
+
+	fm := NewMemoryFileManager()
+	require.NoError(t, merged.Write(fm))
+	require.Len(t, fm.Files(), 8)
+
+	content, ok := fm.GetFile(first.Attachments[0].Source)
+	require.True(t, ok)
+	require.Equal(t, "first log", string(content))
+
+	content, ok = fm.GetFile(second.Steps[0].Attachments[0].Source)
+	require.True(t, ok)
+	require.Equal(t, "{}", string(content))
+
+	content, ok = fm.GetFile(environmentFileName)
+	require.True(t, ok)
+	require.Equal(t, "os=linux\nshard=a\nstand=qa\n", string(content))
+}
+
+func TestResults_Select(t *testing.T) {
+	passed := newTestResult("Passed", Passed, 1, 2)
+	failed := newTestResult("Failed", Failed, 3, 4)
+	failed.WithLabels(OwnerLabel("alice"))
+
+	suite := NewContainer()
+	suite.AddChild(passed.UUID)
+	suite.AddChild(failed.UUID)
+	suite.Befores = append(suite.Befores, NewSimpleStep("setup"))
+	passedOnly := NewContainer()
+	passedOnly.AddChild(passed.UUID)
+	passedOnly.Afters = append(passedOnly.Afters, NewSimpleStep("teardown"))
+
+	results := printShard(t, []*Result{passed, failed}, suite, passedOnly)
+	results.Environment = map[string]string{"stand": "qa"}
+
+	selected := results.Select(func(result *Result) bool { return result.Status == Failed })
+	require.Len(t, selected.Results, 1)
+	require.Equal(t, failed.UUID, selected.Results[0].UUID)
+	require.Len(t, selected.Containers, 1)
+	require.Equal(t, suite.UUID, selected.Containers[0].UUID)
+	require.Len(t, selected.Containers[0].Children, 1)
+	require.Len(t, selected.GetContainers(failed.UUID.String()), 1)
+	require.Equal(t, results.Environment, selected.Environment)
+
+	// source is not changed
+	require.Len(t, results.Results, 2)
+	for _, container := range results.Containers {
+		if container.UUID == suite.UUID {
+			require.Len(t, container.Children, 2)
+		}
+	}
+}
+
+func TestResults_Write_keepsOriginalFiles(t *testing.T) {
+	passed := newTestResult("Passed", Passed, 1, 2)
+	failed := newTestResult("Failed", Failed, 3, 4)
+	suite := NewContainer()
+	suite.AddChild(passed.UUID)
+	suite.AddChild(failed.UUID)
+	failedOnly := NewContainer()
+	failedOnly.AddChild(failed.UUID)
+
+	// files with a field unknown to allure-go, e.g. written by other adapters
+	dir := t.TempDir()
+	files := map[string]interface{}{
+		passed.UUID.String() + resultFileSuffix:        passed,
+		failed.UUID.String() + resultFileSuffix:        failed,
+		suite.UUID.String() + containerFileSuffix:      suite,
+		failedOnly.UUID.String() + containerFileSuffix: failedOnly,
+	}
+	for name, v := range files {
+		content, err := sonic.Marshal(v)
+		require.NoError(t, err)
+		content = append(content[:len(content)-1], `,"custom":"kept"}`...)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0o644))
+	}
+
+	loaded, err := LoadResults(dir)
+	require.NoError(t, err)
+	renamed, _ := loaded.GetResult(passed.UUID.String())
+	renamed.Name = "Renamed"
+
+	fm := NewMemoryFileManager()
+	require.NoError(t, MergeResults(loaded).Write(fm))
+	selected := NewMemoryFileManager()
+	require.NoError(t, loaded.Select(func(result *Result) bool { return result.Status == Failed }).Write(selected))
+
+	file := func(fm *MemoryFileManager, name string) string {
+		content, ok := fm.GetFile(name)
+		require.True(t, ok, name)
+		return string(content)
+	}
+
+	// unchanged files are written as they were loaded
+	require.Contains(t, file(fm, failed.UUID.String()+resultFileSuffix), `"custom":"kept"`)
+	require.Contains(t, file(fm, suite.UUID.String()+containerFileSuffix), `"custom":"kept"`)
+	require.Contains(t, file(selected, failed.UUID.String()+resultFileSuffix), `"custom":"kept"`)
+	require.Contains(t, file(selected, failedOnly.UUID.String()+containerFileSuffix), `"custom":"kept"`)
+
+	// changed ones are marshaled
+	require.Contains(t, file(fm, passed.UUID.String()+resultFileSuffix), `"name":"Renamed"`)
+	require.NotContains(t, file(fm, passed.UUID.String()+resultFileSuffix), `"custom"`)
+	require.NotContains(t, file(selected, suite.UUID.String()+containerFileSuffix), passed.UUID.String())
+}
@@ -3,6 +3,7 @@ package allure
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -15,10 +16,30 @@ import (
 // which Allure uses as additional information describing the test Step
 // (for example - request host or server address)
 type Parameter struct {
-	Name  string      `json:"name"`
-	Value interface{} `json:"value"`
+	Name     string        `json:"name"`
+	Value    interface{}   `json:"value"`
+	Excluded bool          `json:"excluded,omitempty"` // If true - parameter is not used to calculate Result.HistoryID
+	Mode     ParameterMode `json:"mode,omitempty"`     // Parameter's display mode
 }
 
+// ParameterMode describes how Allure displays the Parameter's value.
+type ParameterMode string
+
+// ParameterMode constants
+const (
+	ParameterModeDefault ParameterMode = "default" // Value is shown as is
+	ParameterModeMasked  ParameterMode = "masked"  // Value is replaced with MaskedParameterValue
+	ParameterModeHidden  ParameterMode = "hidden"  // Parameter is not shown in the report at all
+)
+
+func (m ParameterMode) String() string {
+	return string(m)
+}
+
+// MaskedParameterValue is written instead of the value of the parameter with ParameterModeMasked.
+// The real value never reaches the results folder.
+const MaskedParameterValue = "******"
+
 // NewParameter Constructor. Builds and returns a new `Parameter` object,
 // using `name` as the parameter name and `value`, as the value.
 func NewParameter(name string, value ...interface{}) *Parameter {
@@ -30,6 +51,22 @@ func NewParameter(name string, value ...interface{}) *Parameter {
 	}
 }
 
+// NewMaskedParameter Constructor. Builds and returns a new `Parameter` object with ParameterModeMasked.
+// Use it for tokens, passwords and other secrets.
+func NewMaskedParameter(name string, value ...interface{}) *Parameter {
+	return NewParameter(name, value...).WithMode(ParameterModeMasked)
+}
+
+// NewHiddenParameter Constructor. Builds and returns a new `Parameter` object with ParameterModeHidden.
+func NewHiddenParameter(name string, value ...interface{}) *Parameter {
+	return NewParameter(name, value...).WithMode(ParameterModeHidden)
+}
+
+// NewExcludedParameter Constructor. Builds and returns a new `Parameter` object excluded from the Result.HistoryID.
+func NewExcludedParameter(name string, value ...interface{}) *Parameter {
+	return NewParameter(name, value...).WithExcluded(true)
+}
+
 // NewParameters Constructor. Accepts a list of strings, separated by commas.
 // Each even string is considered a parameter name, and each  odd-value of the parameter.
 // If an odd number of lines is passed, the last line is discarded.
@@ -48,6 +85,195 @@ func NewParameters(kv ...interface{}) []*Parameter {
 	return result
 }
 
+// NewMaskedParameters Constructor. Works the same way as NewParameters, but all parameters have ParameterModeMasked.
+func NewMaskedParameters(kv ...interface{}) []*Parameter {
+	result := NewParameters(kv...)
+	for _, param := range result {
+		param.WithMode(ParameterModeMasked)
+	}
+
+	return result
+}
+
+// ParameterTag is the struct tag that configures parameters created by NewParametersFromValue.
+// Format: `allure:"name,option,..."`, options are `masked`, `hidden` and `excluded`.
+// Empty name keeps the field's name, `allure:"-"` skips the field.
+const ParameterTag = "allure"
+
+// NewParametersFromValue Constructor. Builds parameters describing the value.
+// Exported fields of struct (or pointer to struct) become separate parameters named after the fields,
+// nested structs are flattened with dot-separated names (e.g. `User.Login`).
+// Fields are configured with ParameterTag. Structs implementing fmt.Stringer, structs without exported fields
+// and values of other types become a single parameter with the passed name.
+func NewParametersFromValue(name string, value interface{}) []*Parameter {
+	v, path, _ := indirect(reflect.ValueOf(value), nil)
+	if isFlattened(v) {
+		if params := structParameters("", v, &Parameter{}, path); len(params) > 0 {
+			return params
+		}
+	}
+
+	return []*Parameter{newParameterFromValue(name, v, &Parameter{})}
+}
+
+// structParameters returns parameters of struct fields. path holds pointers dereferenced on the way to v,
+// field pointing to one of them (cyclic value) becomes a single parameter
+func structParameters(prefix string, v reflect.Value, opts *Parameter, path []uintptr) []*Parameter {
+	var (
+		t      = v.Type()
+		params []*Parameter
+	)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// exported fields of unexported embedded struct are promoted, like in encoding/json
+		if field.PkgPath != "" && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		fieldName, fieldOpts, skip := parseParameterTag(field, opts)
+		if skip {
+			continue
+		}
+
+		fieldV, fieldPath, cycle := indirect(v.Field(i), path)
+		if cycle || !isFlattened(fieldV) {
+			params = append(params, newParameterFromValue(joinParameterName(prefix, fieldName), fieldV, fieldOpts))
+			continue
+		}
+
+		nestedPrefix := joinParameterName(prefix, fieldName)
+		// fields of embedded struct are promoted
+		if field.Anonymous && field.Tag.Get(ParameterTag) == "" {
+			nestedPrefix = prefix
+		}
+
+		nested := structParameters(nestedPrefix, fieldV, fieldOpts, fieldPath)
+		if len(nested) == 0 {
+			nested = []*Parameter{newParameterFromValue(joinParameterName(prefix, fieldName), fieldV, fieldOpts)}
+		}
+		params = append(params, nested...)
+	}
+
+	return params
+}
+
+func joinParameterName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	if name == "" {
+		return prefix
+	}
+
+	return prefix + "." + name
+}
+
+// indirect dereferences pointers of v and appends them to path.
+// If v points to one of the pointers of path, it is returned as is with cycle set
+func indirect(v reflect.Value, path []uintptr) (_ reflect.Value, _ []uintptr, cycle bool) {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		ptr := v.Pointer()
+		for _, visited := range path {
+			if visited == ptr {
+				return v, path, true
+			}
+		}
+
+		path = append(path[:len(path):len(path)], ptr)
+		v = v.Elem()
+	}
+
+	return v, path, false
+}
+
+// isFlattened returns true if the value is struct, which fields become separate parameters
+func isFlattened(v reflect.Value) bool {
+	return v.Kind() == reflect.Struct && !isStringer(v)
+}
+
+func newParameterFromValue(name string, v reflect.Value, opts *Parameter) *Parameter {
+	var value interface{}
+	if v.IsValid() && v.CanInterface() {
+		value = v.Interface()
+	}
+
+	param := NewParameter(name, value)
+	param.Mode = opts.Mode
+	param.Excluded = opts.Excluded
+
+	return param
+}
+
+func isStringer(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return false
+	}
+
+	if _, ok := v.Interface().(fmt.Stringer); ok {
+		return true
+	}
+
+	if v.CanAddr() {
+		_, ok := v.Addr().Interface().(fmt.Stringer)
+		return ok
+	}
+
+	return false
+}
+
+// parseParameterTag returns name of the field's parameter and its options inherited from parent ones
+func parseParameterTag(field reflect.StructField, parent *Parameter) (string, *Parameter, bool) {
+	tag := field.Tag.Get(ParameterTag)
+	if tag == "-" {
+		return "", nil, true
+	}
+
+	opts := &Parameter{Mode: parent.Mode, Excluded: parent.Excluded}
+	parts := strings.Split(tag, ",")
+
+	name := strings.TrimSpace(parts[0])
+	if name == "" {
+		name = field.Name
+	}
+
+	for _, option := range parts[1:] {
+		switch strings.TrimSpace(option) {
+		case string(ParameterModeMasked):
+			opts.Mode = ParameterModeMasked
+		case string(ParameterModeHidden):
+			if opts.Mode != ParameterModeMasked {
+				opts.Mode = ParameterModeHidden
+			}
+		case "excluded":
+			opts.Excluded = true
+		}
+	}
+
+	return name, opts, false
+}
+
+// WithMode sets parameter's display mode.
+// Returns a pointer to the current Parameter (for Fluent Interface).
+func (p *Parameter) WithMode(mode ParameterMode) *Parameter {
+	p.Mode = mode
+
+	return p
+}
+
+// WithExcluded sets whether parameter should be excluded from the Result.HistoryID.
+// Returns a pointer to the current Parameter (for Fluent Interface).
+func (p *Parameter) WithExcluded(excluded bool) *Parameter {
+	p.Excluded = excluded
+
+	return p
+}
+
+// IsMasked returns true if parameter has ParameterModeMasked
+func (p *Parameter) IsMasked() bool {
+	return p.Mode == ParameterModeMasked
+}
+
 // GetValue returns param value as string
 func (p *Parameter) GetValue() string {
 	s := fmt.Sprint(p.Value)
@@ -170,8 +396,10 @@ func (p *Parameter) UnmarshalJSON(data []byte) error {
 	// TODO: refactor this in v2
 
 	var aux struct {
-		Name  string         `json:"name"`
-		Value parameterValue `json:"value"`
+		Name     string         `json:"name"`
+		Value    parameterValue `json:"value"`
+		Excluded bool           `json:"excluded"`
+		Mode     ParameterMode  `json:"mode"`
 	}
 
 	if err := sonic.Unmarshal(data, &aux); err != nil {
@@ -179,8 +407,10 @@ func (p *Parameter) UnmarshalJSON(data []byte) error {
 	}
 
 	*p = Parameter{
-		Name:  aux.Name,
-		Value: aux.Value.Inner(),
+		Name:     aux.Name,
+		Value:    aux.Value.Inner(),
+		Excluded: aux.Excluded,
+		Mode:     aux.Mode,
 	}
 
 	return nil
@@ -189,7 +419,12 @@ func (p *Parameter) UnmarshalJSON(data []byte) error {
 func (p *Parameter) MarshalJSON() ([]byte, error) {
 	var raw json.RawMessage
 
-	switch v := p.Value.(type) {
+	value := p.Value
+	if p.IsMasked() {
+		value = MaskedParameterValue
+	}
+
+	switch v := value.(type) {
 	case proto.Message:
 		res, err := protojson.MarshalOptions{
 			AllowPartial:      true,
@@ -210,11 +445,15 @@ func (p *Parameter) MarshalJSON() ([]byte, error) {
 	}
 
 	aux := struct {
-		Name  string          `json:"name"`
-		Value json.RawMessage `json:"value"`
+		Name     string          `json:"name"`
+		Value    json.RawMessage `json:"value"`
+		Excluded bool            `json:"excluded,omitempty"`
+		Mode     ParameterMode   `json:"mode,omitempty"`
 	}{
-		Name:  p.Name,
-		Value: raw,
+		Name:     p.Name,
+		Value:    raw,
+		Excluded: p.Excluded,
+		Mode:     p.Mode,
 	}
 
 	return sonic.Marshal(aux)
@@ -2,6 +2,7 @@ package allure
 
 import (
 	"testing"
+	"time"
 
 	"github.com/bytedance/sonic"
 	"github.com/stretchr/testify/require"
@@ -136,3 +137,148 @@ func TestParameterUnmarshal(t *testing.T) {
 		require.Equal(t, "map[a:[1 true 3.14]]", param.GetValue())
 	})
 }
+
+func TestNewParameter_Modes(t *testing.T) {
+	masked := NewMaskedParameter("token", "secret")
+	require.Equal(t, ParameterModeMasked, masked.Mode)
+	require.True(t, masked.IsMasked())
+	require.Equal(t, "secret", masked.GetValue())
+
+	hidden := NewHiddenParameter("session", "id")
+	require.Equal(t, ParameterModeHidden, hidden.Mode)
+	require.False(t, hidden.Excluded)
+
+	excluded := NewExcludedParameter("timestamp", 100500)
+	require.True(t, excluded.Excluded)
+	require.Empty(t, excluded.Mode)
+
+	params := NewMaskedParameters("login", "user", "password", "qwerty")
+	require.Len(t, params, 2)
+	for _, param := range params {
+		require.True(t, param.IsMasked())
+	}
+}
+
+func TestParameterMarshal_Modes(t *testing.T) {
+	t.Run("masked", func(t *testing.T) {
+		bytes, err := sonic.Marshal(NewMaskedParameter("token", "secret"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name":"token","value":"******","mode":"masked"}`, string(bytes))
+	})
+
+	t.Run("hidden excluded", func(t *testing.T) {
+		bytes, err := sonic.Marshal(NewHiddenParameter("session", "id").WithExcluded(true))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name":"session","value":"id","excluded":true,"mode":"hidden"}`, string(bytes))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		bytes, err := sonic.Marshal(NewParameter("host", "localhost"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name":"host","value":"localhost"}`, string(bytes))
+	})
+}
+
+func TestParameterUnmarshal_Modes(t *testing.T) {
+	const data = `{"name": "session", "value": "id", "excluded": true, "mode": "hidden"}`
+
+	var param Parameter
+
+	require.NoError(t, sonic.Unmarshal([]byte(data), &param))
+
+	require.Equal(t, Parameter{
+		Name:     "session",
+		Value:    "id",
+		Excluded: true,
+		Mode:     ParameterModeHidden,
+	}, param)
+}
+
+type paramsAddress struct {
+	City   string
+	Street string `allure:"street"`
+}
+
+type paramsBase struct {
+	ID int
+}
+
+type paramsCase struct {
+	paramsBase
+	Login    string
+	Password string `allure:",masked"`
+	Session  string `allure:"session,hidden,excluded"`
+	Address  *paramsAddress
+	Secret   paramsAddress `allure:"secret,masked"`
+	Created  time.Time
+	Ignored  string `allure:"-"`
+	internal string
+}
+
+func TestNewParametersFromValue_struct(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	value := &paramsCase{
+		paramsBase: paramsBase{ID: 7},
+		Login:      "alice",
+		Password:   "qwerty",
+		Session:    "sid",
+		Address:    &paramsAddress{City: "Moscow", Street: "Arbat"},
+		Secret:     paramsAddress{City: "Paris"},
+		Created:    created,
+		Ignored:    "ignored",
+		internal:   "internal",
+	}
+
+	params := NewParametersFromValue("case", value)
+	require.Equal(t, []*Parameter{
+		NewParameter("ID", 7),
+		NewParameter("Login", "alice"),
+		NewMaskedParameter("Password", "qwerty"),
+		NewHiddenParameter("session", "sid").WithExcluded(true),
+		NewParameter("Address.City", "Moscow"),
+		NewParameter("Address.street", "Arbat"),
+		NewMaskedParameter("secret.City", "Paris"),
+		NewMaskedParameter("secret.street", ""),
+		NewParameter("Created", created),
+	}, params)
+}
+
+func TestNewParametersFromValue_single(t *testing.T) {
+	require.Equal(t, []*Parameter{NewParameter("case", 42)}, NewParametersFromValue("case", 42))
+	require.Equal(t, []*Parameter{NewParameter("case", "text")}, NewParametersFromValue("case", "text"))
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.Equal(t, []*Parameter{NewParameter("case", created)}, NewParametersFromValue("case", created))
+
+	// struct without exported fields
+	require.Equal(t, []*Parameter{NewParameter("case", struct{ id int }{1})}, NewParametersFromValue("case", struct{ id int }{1}))
+
+	require.Equal(t, []*Parameter{NewParameter("case", nil)}, NewParametersFromValue("case", nil))
+}
+
+type paramsNode struct {
+	Name string
+	Next *paramsNode
+}
+
+func TestNewParametersFromValue_cycle(t *testing.T) {
+	first := &paramsNode{Name: "first"}
+	second := &paramsNode{Name: "second", Next: first}
+	first.Next = second
+
+	params := NewParametersFromValue("case", first)
+	require.Len(t, params, 3)
+	require.Equal(t, NewParameter("Name", "first"), params[0])
+	require.Equal(t, NewParameter("Next.Name", "second"), params[1])
+	require.Equal(t, "Next.Next", params[2].Name)
+
+	// the same pointer in sibling fields is not a cycle
+	shared := &paramsNode{Name: "shared"}
+	pair := struct{ A, B *paramsNode }{shared, shared}
+	require.Equal(t, []*Parameter{
+		NewParameter("A.Name", "shared"),
+		NewParameter("A.Next", (*paramsNode)(nil)),
+		NewParameter("B.Name", "shared"),
+		NewParameter("B.Next", (*paramsNode)(nil)),
+	}, NewParametersFromValue("pair", pair))
+}
@@ -0,0 +1,284 @@
+package allure
+
+import (
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/pkg/errors"
+)
+
+const (
+	resultFileSuffix    = "-result.json"
+	containerFileSuffix = "-container.json"
+)
+
+// Results is an indexed model of the allure-results folder loaded with LoadResults.
+// Containers are linked to their children and attachments are resolved to the content of their files.
+type Results struct {
+	Results     []*Result          // All results sorted by Result.Start
+	Containers  []*Container       // All containers sorted by Container.Start
+	Environment map[string]string  // Content of the environment.properties (empty if missing)
+	Executor    *Executor          // Content of the executor.json (nil if missing)
+	Categories  []*Category        // Content of the categories.json
+	Missing     []string           // Sources of the attachments whose files were not found
+	Files       map[string][]byte  // Content of all other files of the folder (by name), except resolved attachments
+	byUUID      map[string]*Result // Results by UUID
+	containerOf map[string][]*Container
+	originals   map[interface{}]original // Loaded files of results and containers by pointer
+}
+
+// original is the loaded file of a result or a container. Unchanged ones are written as is,
+// so fields unknown to allure-go (e.g. written by other adapters) are kept
+type original struct {
+	content   []byte
+	marshaled []byte // the parsed value marshaled right after loading
+}
+
+// LoadResults reads allure-results folder: all `*-result.json` and `*-container.json` files,
+// attachments, `environment.properties`, `executor.json` and `categories.json`.
+// Returns error if any of known files cannot be parsed.
+func LoadResults(dir string) (*Results, error) {
+	return LoadResultsFS(os.DirFS(dir))
+}
+
+// LoadResultsFS works the same way as LoadResults, but reads the root of fsys
+func LoadResultsFS(fsys fs.FS) (*Results, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read results folder")
+	}
+
+	res := &Results{
+		Environment: make(map[string]string),
+		Files:       make(map[string][]byte),
+		originals:   make(map[interface{}]original),
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read %s", name)
+		}
+
+		if err = res.add(name, content); err != nil {
+			return nil, err
+		}
+	}
+
+	res.link()
+
+	return res, nil
+}
+
+func (r *Results) add(name string, content []byte) error {
+	switch {
+	case strings.HasSuffix(name, resultFileSuffix):
+		result := new(Result)
+		if err := sonic.Unmarshal(content, result); err != nil {
+			return errors.Wrapf(err, "cannot parse %s", name)
+		}
+
+		result.ToPrint = true
+		r.Results = append(r.Results, result)
+		r.keepOriginal(result, content)
+
+	case strings.HasSuffix(name, containerFileSuffix):
+		container := new(Container)
+		if err := sonic.Unmarshal(content, container); err != nil {
+			return errors.Wrapf(err, "cannot parse %s", name)
+		}
+
+		r.Containers = append(r.Containers, container)
+		r.keepOriginal(container, content)
+
+	case name == environmentFileName:
+		env, err := parseProperties(content)
+		if err != nil {
+			return err
+		}
+
+		r.Environment = env
+
+	case name == executorFileName:
+		r.Executor = new(Executor)
+		if err := sonic.Unmarshal(content, r.Executor); err != nil {
+			return errors.Wrapf(err, "cannot parse %s", name)
+		}
+
+	case name == categoriesFileName:
+		if err := sonic.Unmarshal(content, &r.Categories); err != nil {
+			return errors.Wrapf(err, "cannot parse %s", name)
+		}
+
+	default:
+		r.Files[name] = content
+	}
+
+	return nil
+}
+
+// keepOriginal remembers loaded content of the result or the container v
+func (r *Results) keepOriginal(v interface{}, content []byte) {
+	if marshaled, err := sonic.Marshal(v); err == nil {
+		r.originals[v] = original{content: content, marshaled: marshaled}
+	}
+}
+
+// link builds indexes and resolves attachments. Attachment files are removed from Results.Files.
+func (r *Results) link() {
+	sort.SliceStable(r.Results, func(i, j int) bool { return r.Results[i].Start < r.Results[j].Start })
+	sort.SliceStable(r.Containers, func(i, j int) bool { return r.Containers[i].Start < r.Containers[j].Start })
+
+	attachments := make(map[string][]byte)
+	resolve := func(list []*Attachment) {
+		for _, a := range list {
+			content, ok := r.Files[a.Source]
+			if !ok {
+				content, ok = attachments[a.Source]
+			}
+
+			if !ok {
+				r.Missing = append(r.Missing, a.Source)
+				continue
+			}
+
+			a.content = content
+			attachments[a.Source] = content
+			delete(r.Files, a.Source)
+		}
+	}
+
+	for _, result := range r.Results {
+		resolve(result.Attachments)
+		walkSteps(result.Steps, func(s *Step) { resolve(s.Attachments) })
+	}
+
+	for _, container := range r.Containers {
+		walkSteps(container.Befores, func(s *Step) { resolve(s.Attachments) })
+		walkSteps(container.Afters, func(s *Step) { resolve(s.Attachments) })
+	}
+
+	r.index()
+}
+
+// index builds indexes of results by UUID and containers by their children
+func (r *Results) index() {
+	r.byUUID = make(map[string]*Result, len(r.Results))
+	r.containerOf = make(map[string][]*Container)
+
+	for _, result := range r.Results {
+		if _, ok := r.byUUID[result.UUID.String()]; !ok {
+			r.byUUID[result.UUID.String()] = result
+		}
+	}
+
+	for _, container := range r.Containers {
+		for _, child := range container.Children {
+			r.containerOf[child.String()] = append(r.containerOf[child.String()], container)
+		}
+	}
+}
+
+// GetResult returns result by its UUID
+func (r *Results) GetResult(uuid string) (*Result, bool) {
+	result, ok := r.byUUID[uuid]
+
+	return result, ok
+}
+
+// GetContainers returns all containers which have the result with passed UUID as a child
+func (r *Results) GetContainers(uuid string) []*Container {
+	return r.containerOf[uuid]
+}
+
+// GetChildren returns all loaded results of the container
+func (r *Results) GetChildren(container *Container) []*Result {
+	children := make([]*Result, 0, len(container.Children))
+
+	for _, child := range container.Children {
+		if result, ok := r.byUUID[child.String()]; ok {
+			children = append(children, result)
+		}
+	}
+
+	return children
+}
+
+// Filter returns all results for which f returns true
+func (r *Results) Filter(f func(result *Result) bool) []*Result {
+	filtered := make([]*Result, 0, len(r.Results))
+
+	for _, result := range r.Results {
+		if f(result) {
+			filtered = append(filtered, result)
+		}
+	}
+
+	return filtered
+}
+
+// ByStatus returns all results with passed status
+func (r *Results) ByStatus(status Status) []*Result {
+	return r.Filter(func(result *Result) bool {
+		return result.Status == status
+	})
+}
+
+// ByLabel returns all results having label with passed name and value
+func (r *Results) ByLabel(labelType LabelType, value string) []*Result {
+	return r.Filter(func(result *Result) bool {
+		for _, label := range result.GetLabels(labelType) {
+			if label.GetValue() == value {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// ByName returns all results with passed name or full name
+func (r *Results) ByName(name string) []*Result {
+	return r.Filter(func(result *Result) bool {
+		return result.Name == name || result.FullName == name
+	})
+}
+
+// ByHistoryID returns all results with passed history ID (e.g. all retries of the test)
+func (r *Results) ByHistoryID(historyID string) []*Result {
+	return r.Filter(func(result *Result) bool {
+		return result.HistoryID == historyID
+	})
+}
+
+// GetAttachments returns all attachments of the result, including attachments of its steps
+// and of befores/afters of its containers
+func (r *Results) GetAttachments(result *Result) []*Attachment {
+	attachments := append([]*Attachment(nil), result.Attachments...)
+	collect := func(s *Step) { attachments = append(attachments, s.Attachments...) }
+
+	walkSteps(result.Steps, collect)
+	for _, container := range r.GetContainers(result.UUID.String()) {
+		walkSteps(container.Befores, collect)
+		walkSteps(container.Afters, collect)
+	}
+
+	return attachments
+}
+
+// walkSteps calls f for all steps of the tree
+func walkSteps(steps []*Step, f func(s *Step)) {
+	for _, s := range steps {
+		f(s)
+		walkSteps(s.Steps, f)
+	}
+}
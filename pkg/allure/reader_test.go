@@ -0,0 +1,112 @@
+package allure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bytedance/sonic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadResults(t *testing.T) {
+	dir := t.TempDir()
+	SetFileManager(NewDirFileManager(dir))
+	defer SetFileManager(nil)
+
+	first := NewResult("First", "pkg/First")
+	first.Status = Passed
+	first.WithLabels(OwnerLabel("alice"), TagLabel("smoke"))
+	first.Attachments = append(first.Attachments, NewAttachment("log", Text, []byte("test log")))
+	step := NewSimpleStep("step").WithAttachments(NewAttachment("response", JSON, []byte(`{}`)))
+	first.Steps = append(first.Steps, step)
+	require.NoError(t, first.Done())
+
+	second := NewResult("Second", "pkg/Second")
+	second.Status = Failed
+	second.Start = first.Start + 1
+	second.Attachments = append(second.Attachments, &Attachment{Name: "lost", Source: "lost-attachment.txt"})
+	require.NoError(t, second.Print())
+	require.NoError(t, os.Remove(filepath.Join(dir, "lost-attachment.txt")))
+
+	container := NewContainer()
+	container.AddChild(first.UUID)
+	container.AddChild(second.UUID)
+	container.Befores = append(container.Befores, NewSimpleStep("setup").WithAttachments(NewAttachment("setup", Text, []byte("setup log"))))
+	require.NoError(t, container.Print())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, environmentFileName), []byte("stand=qa\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, executorFileName), []byte(`{"name":"Local"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, categoriesFileName), []byte(`[{"name":"Flaky","flaky":true}]`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "history.txt"), []byte("other"), 0o644))
+
+	results, err := LoadResults(dir)
+	require.NoError(t, err)
+
+	require.Len(t, results.Results, 2)
+	require.Equal(t, first.UUID, results.Results[0].UUID)
+	require.True(t, results.Results[0].ToPrint)
+	require.Len(t, results.Containers, 1)
+	require.Equal(t, map[string]string{"stand": "qa"}, results.Environment)
+	require.Equal(t, &Executor{Name: "Local"}, results.Executor)
+	require.Equal(t, []*Category{NewCategory("Flaky").WithFlaky(true)}, results.Categories)
+	require.Equal(t, []string{"lost-attachment.txt"}, results.Missing)
+	require.Equal(t, map[string][]byte{"history.txt": []byte("other")}, results.Files)
+
+	loaded, ok := results.GetResult(first.UUID.String())
+	require.True(t, ok)
+	require.Equal(t, "test log", string(loaded.Attachments[0].GetContent()))
+	require.Equal(t, "{}", string(loaded.Steps[0].Attachments[0].GetContent()))
+
+	_, ok = results.GetResult("unknown")
+	require.False(t, ok)
+
+	containers := results.GetContainers(second.UUID.String())
+	require.Len(t, containers, 1)
+	require.Equal(t, container.UUID, containers[0].UUID)
+	require.Len(t, results.GetChildren(containers[0]), 2)
+
+	attachments := results.GetAttachments(loaded)
+	require.Len(t, attachments, 3)
+	require.Equal(t, "setup log", string(attachments[2].GetContent()))
+
+	require.Len(t, results.ByStatus(Failed), 1)
+	require.Len(t, results.ByLabel(Owner, "alice"), 1)
+	require.Len(t, results.ByLabel(Tag, "regress"), 0)
+	require.Len(t, results.ByName("pkg/Second"), 1)
+	require.Len(t, results.ByHistoryID(first.HistoryID), 1)
+}
+
+func TestLoadResultsFS_Malformed(t *testing.T) {
+	fsys := fstest.MapFS{
+		"bad-result.json": &fstest.MapFile{Data: []byte(`{"name":`)},
+	}
+
+	_, err := LoadResultsFS(fsys)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bad-result.json")
+}
+
+func TestLoadResultsFS(t *testing.T) {
+	result := NewResult("Test", "pkg/Test")
+	content, err := sonic.Marshal(result)
+	require.NoError(t, err)
+
+	fsys := fstest.MapFS{
+		result.UUID.String() + resultFileSuffix: &fstest.MapFile{Data: content},
+		"nested/file.txt":                       &fstest.MapFile{Data: []byte("skipped")},
+	}
+
+	results, err := LoadResultsFS(fsys)
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	require.Empty(t, results.Files)
+	require.Empty(t, results.Containers)
+	require.Nil(t, results.Executor)
+}
+
+func TestLoadResults_NotFound(t *testing.T) {
+	_, err := LoadResults(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
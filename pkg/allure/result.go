@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 
@@ -280,7 +281,7 @@ func (result *Result) printResult() error {
 		return errors.Wrap(err, "Failed marshal Result")
 	}
 
-	err = NewFileManager().CreateFile(fmt.Sprintf("%s-result.json", result.UUID), bResult)
+	err = GetFileManager().CreateFile(fmt.Sprintf("%s-result.json", result.UUID), bResult)
 	if err != nil {
 		return errors.Wrap(err, "Cannot save Result")
 	}
@@ -343,6 +344,80 @@ func (result *Result) ToJSON() ([]byte, error) {
 	return sonic.Marshal(result)
 }
 
+// Clone returns deep copy of the result's description: names, IDs, labels, links, parameters and description fields.
+// Execution data (status, steps, attachments and timings) is not copied. The copy gets a new UUID.
+// Use it to create another attempt of the same test (e.g. retry) sharing the same HistoryID.
+func (result *Result) Clone() *Result {
+	result.m.RLock()
+	defer result.m.RUnlock()
+
+	clone := &Result{
+		UUID:           uuid.New(),
+		Name:           result.Name,
+		FullName:       result.FullName,
+		Stage:          result.Stage,
+		HistoryID:      result.HistoryID,
+		TestCaseID:     result.TestCaseID,
+		Description:    result.Description,
+		ExpectedResult: result.ExpectedResult,
+		ToPrint:        result.ToPrint,
+	}
+
+	for _, label := range result.Labels {
+		clone.Labels = append(clone.Labels, &Label{Name: label.Name, Value: label.Value})
+	}
+
+	for _, link := range result.Links {
+		newLink := *link
+		clone.Links = append(clone.Links, &newLink)
+	}
+
+	for _, param := range result.Parameters {
+		newParam := *param
+		clone.Parameters = append(clone.Parameters, &newParam)
+	}
+
+	return clone
+}
+
+// WithTestCaseFullName sets `Result.TestCaseID` as md5 hash of the test case's full name
+// (e.g. name of parametrized test without parameters), so all variants of the test case share it.
+// Returns a pointer to the current Result (for Fluent Interface).
+func (result *Result) WithTestCaseFullName(fullName string) *Result {
+	result.TestCaseID = getMD5Hash(fullName)
+
+	return result
+}
+
+// UpdateHistoryID sets `Result.HistoryID` as md5 hash of `Result.TestCaseID` and the result's parameters
+// sorted by name. Excluded parameters are skipped. Masked ones are hashed with the real value,
+// so cases differing only in them have different histories (the hash doesn't reveal the value).
+// Without parameters HistoryID is the same as the one set by NewResult.
+// Returns a pointer to the current Result (for Fluent Interface).
+func (result *Result) UpdateHistoryID() *Result {
+	result.m.Lock()
+	defer result.m.Unlock()
+
+	var params []string
+	for _, param := range result.Parameters {
+		if param == nil || param.Excluded {
+			continue
+		}
+
+		params = append(params, param.Name+"="+param.GetValue())
+	}
+
+	if len(params) == 0 {
+		result.HistoryID = getMD5Hash(result.TestCaseID)
+		return result
+	}
+
+	sort.Strings(params)
+	result.HistoryID = getMD5Hash(result.TestCaseID + "\n" + strings.Join(params, "\n"))
+
+	return result
+}
+
 // getMD5Hash ...
 func getMD5Hash(text string) string {
 	hash := md5.Sum([]byte(text))
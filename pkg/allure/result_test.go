@@ -461,3 +461,70 @@ func TestResult_Done(t *testing.T) {
 	require.NoError(t, readErr)
 	require.Equal(t, attachmentText, string(bytes))
 }
+
+func TestResult_Clone(t *testing.T) {
+	result := NewResult(testName, testFullName)
+	result.Description = "description"
+	result.Status = Failed
+	result.Steps = append(result.Steps, NewSimpleStep("step"))
+	result.WithLabels(OwnerLabel("alice"))
+	result.Links = append(result.Links, LinkLink("name", "url"))
+	result.Parameters = append(result.Parameters, NewMaskedParameter("token", "secret"))
+
+	clone := result.Clone()
+	require.NotEqual(t, result.UUID, clone.UUID)
+	require.Equal(t, result.Name, clone.Name)
+	require.Equal(t, result.FullName, clone.FullName)
+	require.Equal(t, result.HistoryID, clone.HistoryID)
+	require.Equal(t, result.TestCaseID, clone.TestCaseID)
+	require.Equal(t, result.Description, clone.Description)
+	require.Equal(t, result.Labels, clone.Labels)
+	require.Equal(t, result.Links, clone.Links)
+	require.Equal(t, result.Parameters, clone.Parameters)
+	require.True(t, clone.ToPrint)
+	require.Empty(t, clone.Status)
+	require.Empty(t, clone.Steps)
+
+	// deep copy
+	result.ReplaceNewLabel(Owner, "bob")
+	owner, ok := clone.GetFirstLabel(Owner)
+	require.True(t, ok)
+	require.Equal(t, "alice", owner.GetValue())
+}
+
+func TestResult_UpdateHistoryID(t *testing.T) {
+	result := NewResult(testName, testFullName)
+	historyID := result.HistoryID
+
+	result.UpdateHistoryID()
+	require.Equal(t, historyID, result.HistoryID)
+
+	result.Parameters = append(result.Parameters, NewParameter("b", 2), NewParameter("a", 1))
+	result.UpdateHistoryID()
+	require.NotEqual(t, historyID, result.HistoryID)
+
+	// order of parameters doesn't matter
+	other := NewResult(testName, testFullName)
+	other.Parameters = append(other.Parameters, NewParameter("a", 1), NewParameter("b", 2))
+	require.Equal(t, result.HistoryID, other.UpdateHistoryID().HistoryID)
+
+	// excluded parameters are skipped
+	other.Parameters = append(other.Parameters, NewExcludedParameter("time", "now"))
+	require.Equal(t, result.HistoryID, other.UpdateHistoryID().HistoryID)
+
+	// masked parameters are hashed with the real value
+	first := NewResult(testName, testFullName)
+	first.Parameters = append(first.Parameters, NewMaskedParameter("token", "one"))
+	second := NewResult(testName, testFullName)
+	second.Parameters = append(second.Parameters, NewMaskedParameter("token", "two"))
+	require.NotEqual(t, first.UpdateHistoryID().HistoryID, second.UpdateHistoryID().HistoryID)
+	require.NotContains(t, first.HistoryID, "one")
+}
+
+func TestResult_WithTestCaseFullName(t *testing.T) {
+	first := NewResult("Test_1", "Suite/Test/Test_1").WithTestCaseFullName("Suite/Test")
+	second := NewResult("Test_2", "Suite/Test/Test_2").WithTestCaseFullName("Suite/Test")
+
+	require.Equal(t, first.TestCaseID, second.TestCaseID)
+	require.Equal(t, NewResult("Test", "Suite/Test").TestCaseID, first.TestCaseID)
+}
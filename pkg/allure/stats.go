@@ -0,0 +1,97 @@
+package allure
+
+import (
+	"sort"
+	"time"
+)
+
+// Stats is the summary of the results folder
+type Stats struct {
+	Tests    int            `json:"tests"`    // Number of tests: results with distinct history ID
+	Results  int            `json:"results"`  // Number of results, including retries
+	Statuses map[Status]int `json:"statuses"` // Statuses of the last attempts of the tests
+	Flaky    int            `json:"flaky"`    // Number of tests, which last attempt is marked flaky
+	Duration time.Duration  `json:"duration"` // Sum of durations of all results
+	Wall     time.Duration  `json:"wall"`     // Time from the first start to the last stop
+}
+
+// Stats returns the summary of results. Tests are counted by history ID, so retries are counted once with the status of the last attempt
+func (r *Results) Stats() Stats {
+	stats := Stats{
+		Results:  len(r.Results),
+		Statuses: make(map[Status]int),
+	}
+
+	var first, last int64
+	for _, result := range r.Results {
+		if result.Stop > result.Start {
+			stats.Duration += time.Duration(result.Stop-result.Start) * time.Millisecond
+		}
+		if result.Start > 0 && (first == 0 || result.Start < first) {
+			first = result.Start
+		}
+		if result.Stop > last {
+			last = result.Stop
+		}
+	}
+	if last > first {
+		stats.Wall = time.Duration(last-first) * time.Millisecond
+	}
+
+	for _, result := range r.Latest() {
+		stats.Tests++
+		status := result.Status
+		if status == "" {
+			status = Unknown
+		}
+		stats.Statuses[status]++
+		if result.StatusDetails.Flaky {
+			stats.Flaky++
+		}
+	}
+
+	return stats
+}
+
+// Latest returns the last attempt (by start) of every test, i.e. the result with distinct history ID.
+// Results without history ID are considered distinct tests
+func (r *Results) Latest() []*Result {
+	var (
+		latest  []*Result
+		indexOf = make(map[string]int)
+	)
+
+	for _, result := range r.Results {
+		if result.HistoryID == "" {
+			latest = append(latest, result)
+			continue
+		}
+
+		i, ok := indexOf[result.HistoryID]
+		if !ok {
+			indexOf[result.HistoryID] = len(latest)
+			latest = append(latest, result)
+			continue
+		}
+
+		if result.Start >= latest[i].Start {
+			latest[i] = result
+		}
+	}
+
+	return latest
+}
+
+// Slowest returns at most n results with the longest duration, the longest first
+func (r *Results) Slowest(n int) []*Result {
+	sorted := append([]*Result(nil), r.Results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Stop-sorted[i].Start > sorted[j].Stop-sorted[j].Start
+	})
+
+	if n < len(sorted) {
+		sorted = sorted[:n]
+	}
+
+	return sorted
+}
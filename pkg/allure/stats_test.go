@@ -0,0 +1,42 @@
+package allure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_Stats(t *testing.T) {
+	attempt := newTestResult("Flaky", Failed, 1000, 2000)
+	retry := attempt.Clone()
+	retry.Status = Passed
+	retry.StatusDetails.Flaky = true
+	retry.Start, retry.Stop = 3000, 3500
+	failed := newTestResult("Failed", Broken, 1500, 5000)
+	noStatus := newTestResult("NoStatus", "", 1200, 1300)
+
+	results := &Results{Results: []*Result{attempt, failed, noStatus, retry}}
+	require.Equal(t, Stats{
+		Tests:    3,
+		Results:  4,
+		Statuses: map[Status]int{Passed: 1, Broken: 1, Unknown: 1},
+		Flaky:    1,
+		Duration: 5100 * time.Millisecond,
+		Wall:     4 * time.Second,
+	}, results.Stats())
+
+	require.Equal(t, []*Result{retry, failed, noStatus}, orderByName(results.Latest()))
+	require.Equal(t, []*Result{failed, attempt}, results.Slowest(2))
+	require.Len(t, results.Slowest(10), 4)
+}
+
+func orderByName(results []*Result) []*Result {
+	order := map[string]int{"Flaky": 0, "Failed": 1, "NoStatus": 2}
+	sorted := make([]*Result, len(results))
+	for _, result := range results {
+		sorted[order[result.Name]] = result
+	}
+
+	return sorted
+}
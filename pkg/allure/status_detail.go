@@ -2,6 +2,7 @@ package allure
 
 // StatusDetail ...
 type StatusDetail struct {
-	Message string `json:"message"` // Abridged version of the message
-	Trace   string `json:"trace"`   // Full message
+	Message string `json:"message"`         // Abridged version of the message
+	Trace   string `json:"trace"`           // Full message
+	Flaky   bool   `json:"flaky,omitempty"` // Marks the result as flaky (e.g. it passed only after retries)
 }
@@ -72,6 +72,15 @@ func (s *Step) WithNewParameters(kv ...interface{}) *Step {
 	return s
 }
 
+// WithNewMaskedParameters works the same way as WithNewParameters,
+// but all added parameters have ParameterModeMasked.
+// Returns pointer to the current Step (for Fluent Interface).
+func (s *Step) WithNewMaskedParameters(kv ...interface{}) *Step {
+	s.Parameters = append(s.Parameters, NewMaskedParameters(kv...)...)
+
+	return s
+}
+
 // WithStatusDetails accept error message and trace.
 // Returns pointer to the current Step (for Fluent Interface).
 func (s *Step) WithStatusDetails(message string, trace string) *Step {
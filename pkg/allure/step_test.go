@@ -17,8 +17,8 @@ func TestNewStep(t *testing.T) {
 	stepStart := time.Now().UnixNano() / int64(time.Millisecond)
 	stepStop := time.Now().UnixNano()/int64(time.Millisecond) + 1
 	parameters := []*Parameter{
-		{"Param1", []byte("val1")},
-		{"Param2", []byte("val2")},
+		{Name: "Param1", Value: []byte("val1")},
+		{Name: "Param2", Value: []byte("val2")},
 	}
 	step := NewStep(stepName, stepStatus, stepStart, stepStop, parameters)
 	assert.Equal(t, stepName, step.Name)
@@ -150,6 +150,15 @@ func TestStep_WithNewParameters_odd(t *testing.T) {
 	require.Equal(t, "val1", step.Parameters[0].GetValue())
 }
 
+func TestStep_WithNewMaskedParameters(t *testing.T) {
+	step := new(Step)
+	step.WithNewMaskedParameters("token", "secret")
+	require.Len(t, step.Parameters, 1)
+	require.Equal(t, "token", step.Parameters[0].Name)
+	require.Equal(t, "secret", step.Parameters[0].GetValue())
+	require.Equal(t, ParameterModeMasked, step.Parameters[0].Mode)
+}
+
 func TestStep_WithParameters(t *testing.T) {
 	step := new(Step)
 	step.WithParameters(NewParameter("param1", "val1"), NewParameter("param2", "val2"))
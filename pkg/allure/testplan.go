@@ -0,0 +1,54 @@
+package allure
+
+const testPlanVersion = "1.0"
+
+// TestPlan is the content of testplan.json, which selects tests to run (see ALLURE_TESTPLAN_PATH of pkg/framework)
+type TestPlan struct {
+	Version string          `json:"version"`
+	Tests   []*TestPlanTest `json:"tests"`
+}
+
+// TestPlanTest selects the test by its ALLURE_ID label or by full name
+type TestPlanTest struct {
+	ID       string `json:"id,omitempty"`
+	Selector string `json:"selector,omitempty"`
+}
+
+// RerunPlan returns test plan, which selects tests with the last attempt in one of statuses
+// (failed and broken, if statuses are not passed). Tests are selected by full name and ALLURE_ID label, if it is set
+func (r *Results) RerunPlan(statuses ...Status) *TestPlan {
+	if len(statuses) == 0 {
+		statuses = []Status{Failed, Broken}
+	}
+
+	plan := &TestPlan{Version: testPlanVersion, Tests: []*TestPlanTest{}}
+	seen := make(map[TestPlanTest]bool)
+
+	for _, result := range r.Latest() {
+		if !hasStatus(result, statuses) {
+			continue
+		}
+
+		test := TestPlanTest{Selector: result.FullName}
+		if label, ok := result.GetFirstLabel(AllureID); ok {
+			test.ID = label.GetValue()
+		}
+
+		if !seen[test] {
+			seen[test] = true
+			plan.Tests = append(plan.Tests, &test)
+		}
+	}
+
+	return plan
+}
+
+func hasStatus(result *Result, statuses []Status) bool {
+	for _, status := range statuses {
+		if result.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
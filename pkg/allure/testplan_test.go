@@ -0,0 +1,34 @@
+package allure
+
+import (
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_RerunPlan(t *testing.T) {
+	attempt := newTestResult("Flaky", Failed, 1, 2)
+	retry := attempt.Clone()
+	retry.Status = Passed
+	retry.Start = 3
+	failed := newTestResult("Failed", Failed, 1, 2)
+	failed.WithLabels(IDAllureLabel("42"))
+	broken := newTestResult("Broken", Broken, 1, 2)
+	skipped := newTestResult("Skipped", Skipped, 1, 2)
+
+	results := &Results{Results: []*Result{attempt, failed, broken, skipped, retry}}
+
+	plan := results.RerunPlan()
+	content, err := sonic.Marshal(plan)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version":"1.0","tests":[{"id":"42","selector":"pkg/Failed"},{"selector":"pkg/Broken"}]}`, string(content))
+
+	plan = results.RerunPlan(Skipped)
+	require.Equal(t, []*TestPlanTest{{Selector: "pkg/Skipped"}}, plan.Tests)
+
+	plan = (&Results{}).RerunPlan()
+	content, err = sonic.Marshal(plan)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version":"1.0","tests":[]}`, string(content))
+}
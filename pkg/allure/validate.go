@@ -0,0 +1,79 @@
+package allure
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Validate checks the results folder and returns all found problems:
+// results and containers without UUID or with duplicated one, results without name or with unknown status,
+// results and steps that stop before start, children of containers that are not loaded and missing attachments
+func (r *Results) Validate() []error {
+	var (
+		problems []error
+		seen     = make(map[uuid.UUID]bool)
+	)
+
+	for _, result := range r.Results {
+		id := result.UUID.String()
+		switch {
+		case result.UUID == uuid.Nil:
+			problems = append(problems, errors.Errorf("result %q has no uuid", result.FullName))
+		case seen[result.UUID]:
+			problems = append(problems, errors.Errorf("result %s: duplicated uuid", id))
+		}
+		seen[result.UUID] = true
+
+		if result.Name == "" {
+			problems = append(problems, errors.Errorf("result %s has no name", id))
+		}
+		if !isKnownStatus(result.Status) {
+			problems = append(problems, errors.Errorf("result %s has unknown status %q", id, result.Status))
+		}
+		if result.Stop != 0 && result.Stop < result.Start {
+			problems = append(problems, errors.Errorf("result %s stops before start", id))
+		}
+
+		walkSteps(result.Steps, func(s *Step) {
+			if s.Status != "" && !isKnownStatus(s.Status) {
+				problems = append(problems, errors.Errorf("result %s: step %q has unknown status %q", id, s.Name, s.Status))
+			}
+			if s.Stop != 0 && s.Stop < s.Start {
+				problems = append(problems, errors.Errorf("result %s: step %q stops before start", id, s.Name))
+			}
+		})
+	}
+
+	seenContainers := make(map[uuid.UUID]bool)
+	for _, container := range r.Containers {
+		id := container.UUID.String()
+		switch {
+		case container.UUID == uuid.Nil:
+			problems = append(problems, errors.New("container has no uuid"))
+		case seenContainers[container.UUID]:
+			problems = append(problems, errors.Errorf("container %s: duplicated uuid", id))
+		}
+		seenContainers[container.UUID] = true
+
+		for _, child := range container.Children {
+			if _, ok := r.byUUID[child.String()]; !ok {
+				problems = append(problems, errors.Errorf("container %s: child result %s is not found", id, child))
+			}
+		}
+	}
+
+	for _, source := range r.Missing {
+		problems = append(problems, errors.Errorf("attachment %s is not found", source))
+	}
+
+	return problems
+}
+
+func isKnownStatus(status Status) bool {
+	switch status {
+	case Passed, Failed, Skipped, Broken, Unknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,53 @@
+package allure
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_Validate(t *testing.T) {
+	valid := newTestResult("Valid", Passed, 1, 2)
+	valid.Steps = append(valid.Steps, &Step{Name: "step", Status: Passed, Start: 1, Stop: 2})
+	container := NewContainer()
+	container.AddChild(valid.UUID)
+	container.Befores = append(container.Befores, NewSimpleStep("setup"))
+
+	results := printShard(t, []*Result{valid}, container)
+	require.Empty(t, results.Validate())
+
+	invalid := newTestResult("", "done", 5, 4)
+	invalid.Steps = append(invalid.Steps, &Step{Name: "step", Status: "ok", Start: 2, Stop: 1})
+	duplicate := newTestResult("Duplicate", Passed, 1, 2)
+	duplicate.UUID = valid.UUID
+	noUUID := newTestResult("NoUUID", Passed, 1, 2)
+	noUUID.UUID = uuid.Nil
+
+	unknownChild := NewContainer()
+	unknownChild.AddChild(uuid.New())
+	unknownChild.Afters = append(unknownChild.Afters, NewSimpleStep("teardown"))
+
+	results.Results = append(results.Results, invalid, duplicate, noUUID)
+	results.Containers = append(results.Containers, unknownChild)
+	results.Missing = append(results.Missing, "lost-attachment.txt")
+	results.index()
+
+	var messages []string
+	for _, err := range results.Validate() {
+		messages = append(messages, err.Error())
+	}
+
+	id := invalid.UUID.String()
+	require.Equal(t, []string{
+		"result " + id + " has no name",
+		"result " + id + ` has unknown status "done"`,
+		"result " + id + " stops before start",
+		"result " + id + `: step "step" has unknown status "ok"`,
+		"result " + id + `: step "step" stops before start`,
+		"result " + valid.UUID.String() + ": duplicated uuid",
+		`result "pkg/NoUUID" has no uuid`,
+		"container " + unknownChild.UUID.String() + ": child result " + unknownChild.Children[0].String() + " is not found",
+		"attachment lost-attachment.txt is not found",
+	}, messages)
+}
@@ -0,0 +1,266 @@
+// Package check provides type-safe asserts: expected and actual values have the same type,
+// so comparing int32 with int fails at compile time instead of the run.
+// Every assert is added as allure step, like asserts of provider.Asserts.
+// Asserts accept provider.T and provider.StepCtx, and stop the test on failure, if t is wrapped with Require:
+//
+//	check.Equal(t, 5, user.ID)
+//	check.MapHas(sCtx, headers, "Content-Type")
+//	check.Len(check.Require(t), users, 3)
+package check
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/wrapper"
+	"github.com/stretchr/testify/assert"
+)
+
+// ProviderT is implemented by provider.T and provider.StepCtx
+type ProviderT interface {
+	Step(step *allure.Step)
+	Errorf(format string, args ...interface{})
+	FailNow()
+}
+
+// Ordered is the constraint of types, which support <, <=, >= and > operators
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+type required struct {
+	ProviderT
+}
+
+// Require wraps t, so failed asserts stop the test (steps are named REQUIRE instead of ASSERT)
+func Require(t ProviderT) ProviderT {
+	if _, ok := t.(required); ok {
+		return t
+	}
+	return required{ProviderT: t}
+}
+
+// decorate runs assertFunc as the step of t
+func decorate(t ProviderT, name string, assertFunc func(t wrapper.TestingT) bool, params []*allure.Parameter, msgAndArgs ...interface{}) {
+	var asserts wrapper.AssertsWrapper
+	if r, ok := t.(required); ok {
+		t = r.ProviderT
+		asserts = wrapper.NewRequire(t)
+	} else {
+		asserts = wrapper.NewAsserts(t)
+	}
+
+	asserts.Decorate(t, name, assertFunc, params, msgAndArgs...)
+}
+
+// Equal asserts that values are equal (with reflect.DeepEqual, []byte are compared with bytes.Equal)
+func Equal[T any](t ProviderT, expected, actual T, msgAndArgs ...interface{}) {
+	decorate(t, "Equal",
+		func(t wrapper.TestingT) bool { return assert.Equal(t, expected, actual, msgAndArgs...) },
+		typedParams[T]("Expected", expected, "Actual", actual),
+		msgAndArgs...,
+	)
+}
+
+// NotEqual asserts that values are not equal
+func NotEqual[T any](t ProviderT, expected, actual T, msgAndArgs ...interface{}) {
+	decorate(t, "Not Equal",
+		func(t wrapper.TestingT) bool { return assert.NotEqual(t, expected, actual, msgAndArgs...) },
+		typedParams[T]("Expected", expected, "Actual", actual),
+		msgAndArgs...,
+	)
+}
+
+// Zero asserts that value is zero value of its type
+func Zero[T comparable](t ProviderT, actual T, msgAndArgs ...interface{}) {
+	var zero T
+	decorate(t, "Zero",
+		func(t wrapper.TestingT) bool {
+			if actual == zero {
+				return true
+			}
+			return assert.Fail(t, fmt.Sprintf("Should be zero, but was %s", wrapper.FormatValue(actual)), msgAndArgs...)
+		},
+		typedParams[T]("Actual", actual),
+		msgAndArgs...,
+	)
+}
+
+// NotZero asserts that value is not zero value of its type
+func NotZero[T comparable](t ProviderT, actual T, msgAndArgs ...interface{}) {
+	var zero T
+	decorate(t, "Not Zero",
+		func(t wrapper.TestingT) bool {
+			if actual != zero {
+				return true
+			}
+			return assert.Fail(t, fmt.Sprintf("Should not be zero, but was %s", wrapper.FormatValue(actual)), msgAndArgs...)
+		},
+		typedParams[T]("Actual", actual),
+		msgAndArgs...,
+	)
+}
+
+// Greater asserts that e1 > e2
+func Greater[T Ordered](t ProviderT, e1, e2 T, msgAndArgs ...interface{}) {
+	compare(t, "Greater", e1, e2, e1 > e2, ">", msgAndArgs...)
+}
+
+// GreaterOrEqual asserts that e1 >= e2
+func GreaterOrEqual[T Ordered](t ProviderT, e1, e2 T, msgAndArgs ...interface{}) {
+	compare(t, "Greater Or Equal", e1, e2, e1 >= e2, ">=", msgAndArgs...)
+}
+
+// Less asserts that e1 < e2
+func Less[T Ordered](t ProviderT, e1, e2 T, msgAndArgs ...interface{}) {
+	compare(t, "Less", e1, e2, e1 < e2, "<", msgAndArgs...)
+}
+
+// LessOrEqual asserts that e1 <= e2
+func LessOrEqual[T Ordered](t ProviderT, e1, e2 T, msgAndArgs ...interface{}) {
+	compare(t, "Less Or Equal", e1, e2, e1 <= e2, "<=", msgAndArgs...)
+}
+
+func compare[T Ordered](t ProviderT, name string, e1, e2 T, ok bool, op string, msgAndArgs ...interface{}) {
+	decorate(t, name,
+		func(t wrapper.TestingT) bool {
+			if ok {
+				return true
+			}
+			return assert.Fail(t, fmt.Sprintf("%s is not %s %s", wrapper.FormatValue(e1), op, wrapper.FormatValue(e2)), msgAndArgs...)
+		},
+		typedParams[T]("First", e1, "Second", e2),
+		msgAndArgs...,
+	)
+}
+
+// Contains asserts that the slice contains the element
+func Contains[T comparable](t ProviderT, list []T, element T, msgAndArgs ...interface{}) {
+	decorate(t, "Contains",
+		func(t wrapper.TestingT) bool {
+			if indexOf(list, element) >= 0 {
+				return true
+			}
+			return assert.Fail(t, fmt.Sprintf("%s does not contain %s", wrapper.FormatValue(list), wrapper.FormatValue(element)), msgAndArgs...)
+		},
+		typedParams[T]("Target Slice", list, "Should Contain", element),
+		msgAndArgs...,
+	)
+}
+
+// NotContains asserts that the slice doesn't contain the element
+func NotContains[T comparable](t ProviderT, list []T, element T, msgAndArgs ...interface{}) {
+	decorate(t, "Not Contains",
+		func(t wrapper.TestingT) bool {
+			i := indexOf(list, element)
+			if i < 0 {
+				return true
+			}
+			return assert.Fail(t, fmt.Sprintf("%s contains %s at index %d", wrapper.FormatValue(list), wrapper.FormatValue(element), i), msgAndArgs...)
+		},
+		typedParams[T]("Target Slice", list, "Should Not Contain", element),
+		msgAndArgs...,
+	)
+}
+
+// Len asserts that the slice has the length
+func Len[T any](t ProviderT, list []T, length int, msgAndArgs ...interface{}) {
+	decorate(t, "Len",
+		func(t wrapper.TestingT) bool {
+			if len(list) == length {
+				return true
+			}
+			return assert.Fail(t, fmt.Sprintf("%s should have %d item(s), but has %d", wrapper.FormatValue(list), length, len(list)), msgAndArgs...)
+		},
+		append(typedParams[T]("Actual", list), allure.NewParameter("Expected Length", length)),
+		msgAndArgs...,
+	)
+}
+
+// ElementsMatch asserts that the slices have the same elements ignoring their order
+func ElementsMatch[T any](t ProviderT, listA, listB []T, msgAndArgs ...interface{}) {
+	decorate(t, "Elements Match",
+		func(t wrapper.TestingT) bool { return assert.ElementsMatch(t, listA, listB, msgAndArgs...) },
+		typedParams[T]("ListA", listA, "ListB", listB),
+		msgAndArgs...,
+	)
+}
+
+// MapHas asserts that the map has the key
+func MapHas[K comparable, V any](t ProviderT, m map[K]V, key K, msgAndArgs ...interface{}) {
+	decorate(t, "Map Has",
+		func(t wrapper.TestingT) bool {
+			if _, ok := m[key]; ok {
+				return true
+			}
+			return assert.Fail(t, fmt.Sprintf("map has no key %s", wrapper.FormatValue(key)), msgAndArgs...)
+		},
+		mapParams[K, V]("Key", key),
+		msgAndArgs...,
+	)
+}
+
+// MapHasEntry asserts that the map has the key with the value
+func MapHasEntry[K comparable, V any](t ProviderT, m map[K]V, key K, value V, msgAndArgs ...interface{}) {
+	decorate(t, "Map Has Entry",
+		func(t wrapper.TestingT) bool {
+			actual, ok := m[key]
+			if !ok {
+				return assert.Fail(t, fmt.Sprintf("map has no key %s", wrapper.FormatValue(key)), msgAndArgs...)
+			}
+			return assert.Equal(t, value, actual, msgAndArgs...)
+		},
+		mapParams[K, V]("Key", key, "Value", value),
+		msgAndArgs...,
+	)
+}
+
+// ErrorAs asserts that error in err's chain has type E and returns it
+func ErrorAs[E error](t ProviderT, err error, msgAndArgs ...interface{}) E {
+	var target E
+	decorate(t, "Error As",
+		func(t wrapper.TestingT) bool { return assert.ErrorAs(t, err, &target, msgAndArgs...) },
+		allure.NewParameters("Error", fmt.Sprint(err), "Type", typeName[E]()),
+		msgAndArgs...,
+	)
+
+	return target
+}
+
+func indexOf[T comparable](list []T, element T) int {
+	for i, e := range list {
+		if e == element {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// typedParams returns parameters with formatted values and "Type" parameter with type T
+func typedParams[T any](kv ...interface{}) []*allure.Parameter {
+	params := make([]*allure.Parameter, 0, len(kv)/2+1)
+	for i := 0; i+1 < len(kv); i += 2 {
+		params = append(params, allure.NewParameter(kv[i].(string), wrapper.FormatValue(kv[i+1])))
+	}
+
+	return append(params, allure.NewParameter("Type", typeName[T]()))
+}
+
+// mapParams returns parameters with formatted values and "Type" parameter with type of the map
+func mapParams[K comparable, V any](kv ...interface{}) []*allure.Parameter {
+	params := make([]*allure.Parameter, 0, len(kv)/2+1)
+	for i := 0; i+1 < len(kv); i += 2 {
+		params = append(params, allure.NewParameter(kv[i].(string), wrapper.FormatValue(kv[i+1])))
+	}
+
+	return append(params, allure.NewParameter("Type", typeName[map[K]V]()))
+}
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
@@ -0,0 +1,205 @@
+package check
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/wrapper"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+var (
+	_ ProviderT = (provider.T)(nil)
+	_ ProviderT = (provider.StepCtx)(nil)
+)
+
+type providerTMock struct {
+	steps        []*allure.Step
+	errorF       bool
+	errorFString string
+	failNow      bool
+}
+
+func newMock() *providerTMock {
+	return &providerTMock{steps: make([]*allure.Step, 0)}
+}
+
+func (p *providerTMock) Step(step *allure.Step) {
+	p.steps = append(p.steps, step)
+}
+
+func (p *providerTMock) Errorf(format string, msgAndArgs ...interface{}) {
+	p.errorFString = format
+	p.errorF = true
+}
+
+func (p *providerTMock) FailNow() {
+	p.failNow = true
+}
+
+func paramsOf(step *allure.Step) map[string]string {
+	params := make(map[string]string)
+	for _, param := range step.Parameters {
+		params[param.Name] = param.GetValue()
+	}
+	return params
+}
+
+func TestEqual(t *testing.T) {
+	mockT := newMock()
+	Equal(mockT, int32(5), 5)
+	Equal(mockT, []string{"a"}, []string{"b"}, "names")
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: Equal", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, map[string]string{"Expected": "5", "Actual": "5", "Type": "int32"}, paramsOf(steps[0]))
+
+	require.Equal(t, "ASSERT: names", steps[1].Name)
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.Equal(t, map[string]string{"Expected": `[]string{"a"}`, "Actual": `[]string{"b"}`, "Type": "[]string"}, paramsOf(steps[1]))
+	require.True(t, mockT.errorF)
+	require.False(t, mockT.failNow)
+}
+
+func TestEqual_truncatedParams(t *testing.T) {
+	long := make([]int, 1000)
+	mockT := newMock()
+	Equal(mockT, long, long)
+
+	params := paramsOf(mockT.steps[0])
+	require.LessOrEqual(t, len(params["Actual"]), wrapper.GetDiffThresholds().MaxLength+len("<... truncated>"))
+	require.True(t, strings.HasSuffix(params["Actual"], "<... truncated>"))
+}
+
+func TestRequire(t *testing.T) {
+	mockT := newMock()
+	req := Require(mockT)
+	require.Equal(t, req, Require(req))
+
+	NotEqual(req, "a", "b")
+	require.False(t, mockT.failNow)
+
+	NotEqual(req, "a", "a")
+	require.True(t, mockT.failNow)
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "REQUIRE: Not Equal", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, allure.Failed, steps[1].Status)
+}
+
+func TestZero(t *testing.T) {
+	mockT := newMock()
+	Zero(mockT, time.Duration(0))
+	Zero(mockT, "a")
+	NotZero(mockT, 1.5)
+	NotZero(mockT, 0)
+
+	steps := mockT.steps
+	require.Len(t, steps, 4)
+	require.Equal(t, "ASSERT: Zero", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, map[string]string{"Actual": "0s", "Type": "time.Duration"}, paramsOf(steps[0]))
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.Equal(t, "ASSERT: Not Zero", steps[2].Name)
+	require.Equal(t, allure.Passed, steps[2].Status)
+	require.Equal(t, allure.Failed, steps[3].Status)
+}
+
+func TestOrdered(t *testing.T) {
+	mockT := newMock()
+	Greater(mockT, 2, 1)
+	GreaterOrEqual(mockT, "a", "b")
+	Less(mockT, uint8(1), 2)
+	LessOrEqual(mockT, 1.5, 1)
+
+	steps := mockT.steps
+	require.Len(t, steps, 4)
+	require.Equal(t, "ASSERT: Greater", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, map[string]string{"First": "2", "Second": "1", "Type": "int"}, paramsOf(steps[0]))
+
+	require.Equal(t, "ASSERT: Greater Or Equal", steps[1].Name)
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.Equal(t, "ASSERT: Less", steps[2].Name)
+	require.Equal(t, allure.Passed, steps[2].Status)
+	require.Equal(t, "uint8", paramsOf(steps[2])["Type"])
+	require.Equal(t, "ASSERT: Less Or Equal", steps[3].Name)
+	require.Equal(t, allure.Failed, steps[3].Status)
+}
+
+func TestSlices(t *testing.T) {
+	mockT := newMock()
+	ids := []int64{1, 2, 3}
+	Contains(mockT, ids, 2)
+	Contains(mockT, ids, 4)
+	NotContains(mockT, ids, 4)
+	NotContains(mockT, ids, 1)
+	Len(mockT, ids, 3)
+	Len(mockT, ids, 2)
+	ElementsMatch(mockT, ids, []int64{3, 2, 1})
+	ElementsMatch(mockT, ids, []int64{1, 2})
+
+	steps := mockT.steps
+	require.Len(t, steps, 8)
+	for i, name := range []string{"Contains", "Not Contains", "Len", "Elements Match"} {
+		require.Equal(t, "ASSERT: "+name, steps[2*i].Name)
+		require.Equal(t, allure.Passed, steps[2*i].Status, name)
+		require.Equal(t, allure.Failed, steps[2*i+1].Status, name)
+	}
+
+	require.Equal(t, map[string]string{"Target Slice": "[]int64{1, 2, 3}", "Should Contain": "2", "Type": "int64"}, paramsOf(steps[0]))
+	require.Equal(t, map[string]string{"Actual": "[]int64{1, 2, 3}", "Expected Length": "3", "Type": "int64"}, paramsOf(steps[4]))
+}
+
+func TestMapHas(t *testing.T) {
+	mockT := newMock()
+	headers := map[string]string{"Content-Type": "application/json"}
+	MapHas(mockT, headers, "Content-Type")
+	MapHas(mockT, headers, "Accept")
+	MapHasEntry(mockT, headers, "Content-Type", "application/json")
+	MapHasEntry(mockT, headers, "Content-Type", "text/plain")
+	MapHasEntry(mockT, headers, "Accept", "text/plain")
+
+	steps := mockT.steps
+	require.Len(t, steps, 5)
+	require.Equal(t, "ASSERT: Map Has", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, map[string]string{"Key": "Content-Type", "Type": "map[string]string"}, paramsOf(steps[0]))
+	require.Equal(t, allure.Failed, steps[1].Status)
+
+	require.Equal(t, "ASSERT: Map Has Entry", steps[2].Name)
+	require.Equal(t, allure.Passed, steps[2].Status)
+	require.Equal(t, map[string]string{"Key": "Content-Type", "Value": "application/json", "Type": "map[string]string"}, paramsOf(steps[2]))
+	require.Equal(t, allure.Failed, steps[3].Status)
+	require.Equal(t, allure.Failed, steps[4].Status)
+}
+
+func TestErrorAs(t *testing.T) {
+	mockT := newMock()
+	_, err := os.Open("missing-file")
+	pathErr := ErrorAs[*fs.PathError](mockT, errors.Wrap(err, "open"))
+	require.NotNil(t, pathErr)
+	require.Equal(t, "missing-file", pathErr.Path)
+
+	pathErr = ErrorAs[*fs.PathError](mockT, errors.New("other"))
+	require.Nil(t, pathErr)
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: Error As", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, "*fs.PathError", paramsOf(steps[0])["Type"])
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.Equal(t, "other", paramsOf(steps[1])["Error"])
+}
@@ -1,9 +1,12 @@
 package helper
 
 import (
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
 	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/wrapper"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,10 +17,7 @@ type a struct {
 }
 
 func (a *a) Decorate(name string, assertFunc func(t wrapper.TestingT) bool, params []*allure.Parameter, msgAndArgs ...interface{}) {
-	dec := a.asserts.(interface {
-		Decorate(p wrapper.Provider, name string, assertFunc func(t wrapper.TestingT) bool, params []*allure.Parameter, msgAndArgs ...interface{})
-	})
-	dec.Decorate(a.t, name, assertFunc, params, msgAndArgs...)
+	a.asserts.Decorate(a.t, name, assertFunc, params, msgAndArgs...)
 }
 
 // Exactly ...
@@ -218,3 +218,103 @@ func (a *a) InDelta(expected, actual interface{}, delta float64, msgAndArgs ...i
 func (a *a) Eventually(condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
 	a.asserts.Eventually(a.t, condition, waitFor, tick, msgAndArgs...)
 }
+
+// Panics ...
+func (a *a) Panics(f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	a.asserts.Panics(a.t, f, msgAndArgs...)
+}
+
+// NotPanics ...
+func (a *a) NotPanics(f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	a.asserts.NotPanics(a.t, f, msgAndArgs...)
+}
+
+// PanicsWithError ...
+func (a *a) PanicsWithError(errString string, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	a.asserts.PanicsWithError(a.t, errString, f, msgAndArgs...)
+}
+
+// ErrorContains ...
+func (a *a) ErrorContains(theError error, contains string, msgAndArgs ...interface{}) {
+	a.asserts.ErrorContains(a.t, theError, contains, msgAndArgs...)
+}
+
+// FileExists ...
+func (a *a) FileExists(path string, msgAndArgs ...interface{}) {
+	a.asserts.FileExists(a.t, path, msgAndArgs...)
+}
+
+// NoFileExists ...
+func (a *a) NoFileExists(path string, msgAndArgs ...interface{}) {
+	a.asserts.NoFileExists(a.t, path, msgAndArgs...)
+}
+
+// NotRegexp ...
+func (a *a) NotRegexp(rx interface{}, str interface{}, msgAndArgs ...interface{}) {
+	a.asserts.NotRegexp(a.t, rx, str, msgAndArgs...)
+}
+
+// InEpsilon ...
+func (a *a) InEpsilon(expected, actual interface{}, epsilon float64, msgAndArgs ...interface{}) {
+	a.asserts.InEpsilon(a.t, expected, actual, epsilon, msgAndArgs...)
+}
+
+// InDeltaSlice ...
+func (a *a) InDeltaSlice(expected, actual interface{}, delta float64, msgAndArgs ...interface{}) {
+	a.asserts.InDeltaSlice(a.t, expected, actual, delta, msgAndArgs...)
+}
+
+// YAMLEq ...
+func (a *a) YAMLEq(expected, actual string, msgAndArgs ...interface{}) {
+	a.asserts.YAMLEq(a.t, expected, actual, msgAndArgs...)
+}
+
+// NotElementsMatch ...
+func (a *a) NotElementsMatch(listA interface{}, listB interface{}, msgAndArgs ...interface{}) {
+	a.asserts.NotElementsMatch(a.t, listA, listB, msgAndArgs...)
+}
+
+// Never ...
+func (a *a) Never(condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	a.asserts.Never(a.t, condition, waitFor, tick, msgAndArgs...)
+}
+
+// EventuallyWithT ...
+func (a *a) EventuallyWithT(condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	a.asserts.EventuallyWithT(a.t, condition, waitFor, tick, msgAndArgs...)
+}
+
+// Positive ...
+func (a *a) Positive(e interface{}, msgAndArgs ...interface{}) {
+	a.asserts.Positive(a.t, e, msgAndArgs...)
+}
+
+// Negative ...
+func (a *a) Negative(e interface{}, msgAndArgs ...interface{}) {
+	a.asserts.Negative(a.t, e, msgAndArgs...)
+}
+
+// IsIncreasing ...
+func (a *a) IsIncreasing(object interface{}, msgAndArgs ...interface{}) {
+	a.asserts.IsIncreasing(a.t, object, msgAndArgs...)
+}
+
+// IsDecreasing ...
+func (a *a) IsDecreasing(object interface{}, msgAndArgs ...interface{}) {
+	a.asserts.IsDecreasing(a.t, object, msgAndArgs...)
+}
+
+// HTTPStatusCode ...
+func (a *a) HTTPStatusCode(handler http.HandlerFunc, method, url string, values url.Values, statuscode int, msgAndArgs ...interface{}) {
+	a.asserts.HTTPStatusCode(a.t, handler, method, url, values, statuscode, msgAndArgs...)
+}
+
+// HTTPBodyContains ...
+func (a *a) HTTPBodyContains(handler http.HandlerFunc, method, url string, values url.Values, str interface{}, msgAndArgs ...interface{}) {
+	a.asserts.HTTPBodyContains(a.t, handler, method, url, values, str, msgAndArgs...)
+}
+
+// Expect returns expectation of actual value, which is checked with matchers
+func (a *a) Expect(actual interface{}) matcher.Expectation {
+	return matcher.Expect(a.asserts, a.t, actual)
+}
@@ -2,14 +2,20 @@ package helper
 
 import (
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
 	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/wrapper"
 )
 
@@ -43,6 +49,19 @@ func (p *providerTMock) FailNow() {
 	p.failNow = true
 }
 
+func TestAssert_Expect(t *testing.T) {
+	mockT := newMock()
+	NewAssertsHelper(mockT).Expect("abc").To(matcher.MatchRegexp("^a"))
+	NewRequireHelper(mockT).Expect("abc").To(matcher.HaveLen(2))
+
+	require.Len(t, mockT.steps, 2)
+	require.Equal(t, `ASSERT: Expect to match regexp "^a"`, mockT.steps[0].Name)
+	require.Equal(t, allure.Passed, mockT.steps[0].Status)
+	require.Equal(t, "REQUIRE: Expect to have length 2", mockT.steps[1].Name)
+	require.Equal(t, allure.Failed, mockT.steps[1].Status)
+	require.True(t, mockT.failNow)
+}
+
 func TestAssert_Decorate_Success(t *testing.T) {
 	mockT := newMock()
 	a := NewAssertsHelper(mockT)
@@ -2517,3 +2536,110 @@ func TestRequireEventually_Fail(t *testing.T) {
 	require.True(t, mockT.failNow)
 	require.Equal(t, "\n%s", mockT.errorFString)
 }
+
+func TestHelperExtended_Success(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	handler := func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("hello")) }
+
+	mockT := newMock()
+	h := NewAssertsHelper(mockT)
+	h.Panics(func() { panic("whoops") })
+	h.NotPanics(func() {})
+	h.PanicsWithError("whoops", func() { panic(errors.New("whoops")) })
+	h.ErrorContains(errors.New("connection refused"), "refused")
+	h.FileExists(file)
+	h.NoFileExists(file + ".missing")
+	h.NotRegexp("^[0-9]+$", "abc")
+	h.InEpsilon(100, 101, 0.05)
+	h.InDeltaSlice([]float64{1, 2}, []float64{1.05, 2}, 0.1)
+	h.YAMLEq("a: 1\nb: 2\n", "b: 2\na: 1\n")
+	h.NotElementsMatch([]int{1, 2}, []int{2, 3})
+	h.Never(func() bool { return false }, 20*time.Millisecond, 5*time.Millisecond)
+	h.EventuallyWithT(func(c *assert.CollectT) {}, time.Second, 5*time.Millisecond)
+	h.Positive(1)
+	h.Negative(-1)
+	h.IsIncreasing([]int{1, 2, 3})
+	h.IsDecreasing([]int{3, 2, 1})
+	h.HTTPStatusCode(handler, http.MethodGet, "/", nil, http.StatusOK)
+	h.HTTPBodyContains(handler, http.MethodGet, "/", nil, "hello")
+
+	names := []string{
+		"ASSERT: Panics",
+		"ASSERT: Not Panics",
+		"ASSERT: Panics With Error",
+		"ASSERT: Error Contains",
+		"ASSERT: File Exists",
+		"ASSERT: No File Exists",
+		"ASSERT: Not Regexp",
+		"ASSERT: In Epsilon",
+		"ASSERT: In Delta Slice",
+		"ASSERT: YAML Equal",
+		"ASSERT: Not Elements Match",
+		"ASSERT: Never",
+		"ASSERT: Eventually With T",
+		"ASSERT: Positive",
+		"ASSERT: Negative",
+		"ASSERT: Is Increasing",
+		"ASSERT: Is Decreasing",
+		"ASSERT: HTTP Status Code",
+		"ASSERT: HTTP Body Contains",
+	}
+	require.Len(t, mockT.steps, len(names))
+	for i, step := range mockT.steps {
+		require.Equal(t, names[i], step.Name)
+		require.Equal(t, allure.Passed, step.Status, step.Name)
+		require.NotEmpty(t, step.Parameters, step.Name)
+	}
+	require.False(t, mockT.errorF)
+	require.False(t, mockT.failNow)
+}
+
+func TestHelperExtended_Fail(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	handler := func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("hello")) }
+
+	asserts := []func(mockT *providerTMock){
+		func(mockT *providerTMock) { NewRequireHelper(mockT).Panics(func() {}) },
+		func(mockT *providerTMock) { NewRequireHelper(mockT).NotPanics(func() { panic("whoops") }) },
+		func(mockT *providerTMock) { NewRequireHelper(mockT).PanicsWithError("whoops", func() {}) },
+		func(mockT *providerTMock) {
+			NewRequireHelper(mockT).ErrorContains(errors.New("connection refused"), "timeout")
+		},
+		func(mockT *providerTMock) { NewRequireHelper(mockT).FileExists(file + ".missing") },
+		func(mockT *providerTMock) { NewRequireHelper(mockT).NoFileExists(file) },
+		func(mockT *providerTMock) { NewRequireHelper(mockT).NotRegexp("^[0-9]+$", "123") },
+		func(mockT *providerTMock) { NewRequireHelper(mockT).InEpsilon(100, 110, 0.05) },
+		func(mockT *providerTMock) {
+			NewRequireHelper(mockT).InDeltaSlice([]float64{1, 2}, []float64{1.5, 2}, 0.1)
+		},
+		func(mockT *providerTMock) { NewRequireHelper(mockT).YAMLEq("a: 1\n", "a: 2\n") },
+		func(mockT *providerTMock) { NewRequireHelper(mockT).NotElementsMatch([]int{1, 2}, []int{2, 1}) },
+		func(mockT *providerTMock) {
+			NewRequireHelper(mockT).Never(func() bool { return true }, 20*time.Millisecond, 5*time.Millisecond)
+		},
+		func(mockT *providerTMock) {
+			NewRequireHelper(mockT).EventuallyWithT(func(c *assert.CollectT) { assert.Fail(c, "never") }, 20*time.Millisecond, 5*time.Millisecond)
+		},
+		func(mockT *providerTMock) { NewRequireHelper(mockT).Positive(-1) },
+		func(mockT *providerTMock) { NewRequireHelper(mockT).Negative(1) },
+		func(mockT *providerTMock) { NewRequireHelper(mockT).IsIncreasing([]int{3, 2, 1}) },
+		func(mockT *providerTMock) { NewRequireHelper(mockT).IsDecreasing([]int{1, 2, 3}) },
+		func(mockT *providerTMock) {
+			NewRequireHelper(mockT).HTTPStatusCode(handler, http.MethodGet, "/", nil, http.StatusNotFound)
+		},
+		func(mockT *providerTMock) {
+			NewRequireHelper(mockT).HTTPBodyContains(handler, http.MethodGet, "/", nil, "bye")
+		},
+	}
+	for _, assertFunc := range asserts {
+		mockT := newMock()
+		assertFunc(mockT)
+		require.Len(t, mockT.steps, 1)
+		require.Equal(t, allure.Failed, mockT.steps[0].Status, mockT.steps[0].Name)
+		require.True(t, strings.HasPrefix(mockT.steps[0].Name, "REQUIRE: "))
+		require.True(t, mockT.errorF, mockT.steps[0].Name)
+		require.Equal(t, true, mockT.failNow, mockT.steps[0].Name)
+	}
+}
@@ -1,9 +1,12 @@
 package helper
 
 import (
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,4 +58,24 @@ type AssertsHelper interface {
 	NotZero(i interface{}, msgAndArgs ...interface{})
 	InDelta(expected, actual interface{}, delta float64, msgAndArgs ...interface{})
 	Eventually(condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	Panics(f assert.PanicTestFunc, msgAndArgs ...interface{})
+	NotPanics(f assert.PanicTestFunc, msgAndArgs ...interface{})
+	PanicsWithError(errString string, f assert.PanicTestFunc, msgAndArgs ...interface{})
+	ErrorContains(theError error, contains string, msgAndArgs ...interface{})
+	FileExists(path string, msgAndArgs ...interface{})
+	NoFileExists(path string, msgAndArgs ...interface{})
+	NotRegexp(rx interface{}, str interface{}, msgAndArgs ...interface{})
+	InEpsilon(expected, actual interface{}, epsilon float64, msgAndArgs ...interface{})
+	InDeltaSlice(expected, actual interface{}, delta float64, msgAndArgs ...interface{})
+	YAMLEq(expected, actual string, msgAndArgs ...interface{})
+	NotElementsMatch(listA interface{}, listB interface{}, msgAndArgs ...interface{})
+	Never(condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	EventuallyWithT(condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	Positive(e interface{}, msgAndArgs ...interface{})
+	Negative(e interface{}, msgAndArgs ...interface{})
+	IsIncreasing(object interface{}, msgAndArgs ...interface{})
+	IsDecreasing(object interface{}, msgAndArgs ...interface{})
+	HTTPStatusCode(handler http.HandlerFunc, method, url string, values url.Values, statuscode int, msgAndArgs ...interface{})
+	HTTPBodyContains(handler http.HandlerFunc, method, url string, values url.Values, str interface{}, msgAndArgs ...interface{})
+	Expect(actual interface{}) matcher.Expectation
 }
@@ -0,0 +1,102 @@
+// Package matcher provides the fluent API of asserts with matchers:
+//
+//	t.Expect(user).To(matcher.HaveField("ID", 5))
+//	t.Require().Expect(resp).To(matcher.HaveStatus(http.StatusOK))
+//	sCtx.Expect(err).NotTo(matcher.BeNil())
+//
+// Matcher is the public extension point: any type implementing it is checked as the step
+// "ASSERT: Expect to <description>" (or REQUIRE, if it is checked with t.Require()) with parameters of the matcher,
+// so custom matchers are reported in the same way as built-in asserts.
+package matcher
+
+import (
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/wrapper"
+	"github.com/stretchr/testify/assert"
+)
+
+// Matcher checks actual value of the expectation
+type Matcher interface {
+	// Match returns nil if actual value matches, otherwise error, which describes the mismatch
+	Match(actual interface{}) error
+	// Describe returns what is expected of the value, e.g. "have status 200". It is used in the name of the step
+	Describe() string
+	// Params returns parameters of the step
+	Params(actual interface{}) []*allure.Parameter
+}
+
+// Expectation checks actual value with matchers
+type Expectation interface {
+	// To asserts that actual value matches the matcher
+	To(matcher Matcher, msgAndArgs ...interface{})
+	// NotTo asserts that actual value doesn't match the matcher
+	NotTo(matcher Matcher, msgAndArgs ...interface{})
+}
+
+type expectation struct {
+	decorator wrapper.Decorator
+	provider  wrapper.Provider
+	actual    interface{}
+}
+
+// Expect returns expectation of actual value, which runs matchers as steps of provider with the decorator.
+// The decorator defines ASSERT or REQUIRE semantic, see wrapper.NewAsserts and wrapper.NewRequire
+func Expect(decorator wrapper.Decorator, provider wrapper.Provider, actual interface{}) Expectation {
+	return &expectation{decorator: decorator, provider: provider, actual: actual}
+}
+
+func (e *expectation) To(matcher Matcher, msgAndArgs ...interface{}) {
+	e.decorator.Decorate(e.provider, "Expect to "+matcher.Describe(),
+		func(t wrapper.TestingT) bool {
+			if err := matcher.Match(e.actual); err != nil {
+				return assert.Fail(t, err.Error(), msgAndArgs...)
+			}
+			return true
+		},
+		matcher.Params(e.actual),
+		msgAndArgs...,
+	)
+}
+
+func (e *expectation) NotTo(matcher Matcher, msgAndArgs ...interface{}) {
+	e.decorator.Decorate(e.provider, "Expect not to "+matcher.Describe(),
+		func(t wrapper.TestingT) bool {
+			if err := matcher.Match(e.actual); err == nil {
+				return assert.Fail(t, "Should not "+matcher.Describe(), msgAndArgs...)
+			}
+			return true
+		},
+		matcher.Params(e.actual),
+		msgAndArgs...,
+	)
+}
+
+type funcMatcher struct {
+	description string
+	match       func(actual interface{}) error
+}
+
+// New returns matcher with the description, which checks values with match function.
+// Step of the matcher has "Actual" parameter
+//
+//	beEven := matcher.New("be even", func(actual interface{}) error {
+//		if actual.(int)%2 != 0 {
+//			return fmt.Errorf("%d is odd", actual)
+//		}
+//		return nil
+//	})
+func New(description string, match func(actual interface{}) error) Matcher {
+	return &funcMatcher{description: description, match: match}
+}
+
+func (m *funcMatcher) Match(actual interface{}) error {
+	return m.match(actual)
+}
+
+func (m *funcMatcher) Describe() string {
+	return m.description
+}
+
+func (m *funcMatcher) Params(actual interface{}) []*allure.Parameter {
+	return allure.NewParameters("Actual", wrapper.FormatValue(actual))
+}
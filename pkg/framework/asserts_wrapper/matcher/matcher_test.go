@@ -0,0 +1,122 @@
+package matcher
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/wrapper"
+)
+
+type providerTMock struct {
+	steps   []*allure.Step
+	errors  []string
+	failNow bool
+}
+
+func newMock() *providerTMock {
+	return &providerTMock{steps: make([]*allure.Step, 0)}
+}
+
+func (p *providerTMock) Step(step *allure.Step) {
+	p.steps = append(p.steps, step)
+}
+
+func (p *providerTMock) Errorf(format string, args ...interface{}) {
+	p.errors = append(p.errors, fmt.Sprintf(format, args...))
+}
+
+func (p *providerTMock) FailNow() {
+	p.failNow = true
+}
+
+func paramsOf(step *allure.Step) map[string]string {
+	params := make(map[string]string)
+	for _, param := range step.Parameters {
+		params[param.Name] = param.GetValue()
+	}
+	return params
+}
+
+// beEven is the custom matcher implemented by the user
+type beEven struct{}
+
+func (beEven) Match(actual interface{}) error {
+	n, ok := actual.(int)
+	if !ok {
+		return errors.New("not an int")
+	}
+	if n%2 != 0 {
+		return fmt.Errorf("%d is odd", n)
+	}
+	return nil
+}
+
+func (beEven) Describe() string {
+	return "be even"
+}
+
+func (beEven) Params(actual interface{}) []*allure.Parameter {
+	return allure.NewParameters("Number", actual)
+}
+
+func TestExpectation_To(t *testing.T) {
+	mockT := newMock()
+	Expect(wrapper.NewAsserts(mockT), mockT, 4).To(beEven{})
+	Expect(wrapper.NewAsserts(mockT), mockT, 5).To(beEven{})
+
+	require.Len(t, mockT.steps, 2)
+	require.Equal(t, "ASSERT: Expect to be even", mockT.steps[0].Name)
+	require.Equal(t, allure.Passed, mockT.steps[0].Status)
+	require.Equal(t, map[string]string{"Number": "4"}, paramsOf(mockT.steps[0]))
+
+	require.Equal(t, allure.Failed, mockT.steps[1].Status)
+	require.Equal(t, map[string]string{"Number": "5"}, paramsOf(mockT.steps[1]))
+	require.Len(t, mockT.errors, 1)
+	require.Contains(t, mockT.errors[0], "5 is odd")
+	require.False(t, mockT.failNow)
+}
+
+func TestExpectation_To_require(t *testing.T) {
+	mockT := newMock()
+	Expect(wrapper.NewRequire(mockT), mockT, 4).To(beEven{})
+	require.False(t, mockT.failNow)
+
+	Expect(wrapper.NewRequire(mockT), mockT, 5).To(beEven{}, "number %d", 5)
+	require.True(t, mockT.failNow)
+	require.Equal(t, "REQUIRE: number 5", mockT.steps[1].Name)
+	require.Equal(t, allure.Failed, mockT.steps[1].Status)
+}
+
+func TestExpectation_NotTo(t *testing.T) {
+	mockT := newMock()
+	Expect(wrapper.NewAsserts(mockT), mockT, 5).NotTo(beEven{})
+	Expect(wrapper.NewAsserts(mockT), mockT, 4).NotTo(beEven{})
+
+	require.Equal(t, "ASSERT: Expect not to be even", mockT.steps[0].Name)
+	require.Equal(t, allure.Passed, mockT.steps[0].Status)
+	require.Equal(t, allure.Failed, mockT.steps[1].Status)
+	require.Len(t, mockT.errors, 1)
+	require.Contains(t, mockT.errors[0], "Should not be even")
+}
+
+func TestNew(t *testing.T) {
+	positive := New("be positive", func(actual interface{}) error {
+		if actual.(int) <= 0 {
+			return fmt.Errorf("%d is not positive", actual)
+		}
+		return nil
+	})
+	require.Equal(t, "be positive", positive.Describe())
+	require.NoError(t, positive.Match(1))
+	require.EqualError(t, positive.Match(-1), "-1 is not positive")
+
+	mockT := newMock()
+	Expect(wrapper.NewAsserts(mockT), mockT, -1).To(positive)
+	require.Equal(t, "ASSERT: Expect to be positive", mockT.steps[0].Name)
+	require.Equal(t, allure.Failed, mockT.steps[0].Status)
+	require.Equal(t, map[string]string{"Actual": "-1"}, paramsOf(mockT.steps[0]))
+}
@@ -0,0 +1,450 @@
+package matcher
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/wrapper"
+	"github.com/stretchr/testify/assert"
+)
+
+type equalMatcher struct {
+	expected interface{}
+}
+
+// Equal matches values equal to expected (with assert.ObjectsAreEqual)
+func Equal(expected interface{}) Matcher {
+	return &equalMatcher{expected: expected}
+}
+
+func (m *equalMatcher) Match(actual interface{}) error {
+	if assert.ObjectsAreEqual(m.expected, actual) {
+		return nil
+	}
+	return fmt.Errorf("expected %s, but got %s", wrapper.FormatValue(m.expected), wrapper.FormatValue(actual))
+}
+
+func (m *equalMatcher) Describe() string {
+	return "equal " + wrapper.FormatValue(m.expected)
+}
+
+func (m *equalMatcher) Params(actual interface{}) []*allure.Parameter {
+	return allure.NewParameters("Expected", wrapper.FormatValue(m.expected), "Actual", wrapper.FormatValue(actual))
+}
+
+type nilMatcher struct{}
+
+// BeNil matches nil and typed nil values (pointers, slices, maps, channels, functions and interfaces)
+func BeNil() Matcher {
+	return nilMatcher{}
+}
+
+func (nilMatcher) Match(actual interface{}) error {
+	if isNil(actual) {
+		return nil
+	}
+	return fmt.Errorf("expected nil, but got %s", wrapper.FormatValue(actual))
+}
+
+func (nilMatcher) Describe() string {
+	return "be nil"
+}
+
+func (nilMatcher) Params(actual interface{}) []*allure.Parameter {
+	return allure.NewParameters("Actual", wrapper.FormatValue(actual))
+}
+
+type emptyMatcher struct{}
+
+// BeEmpty matches nil, zero values, empty strings, slices, maps and channels, and pointers to empty values
+func BeEmpty() Matcher {
+	return emptyMatcher{}
+}
+
+func (emptyMatcher) Match(actual interface{}) error {
+	if isEmpty(actual) {
+		return nil
+	}
+	return fmt.Errorf("expected empty value, but got %s", wrapper.FormatValue(actual))
+}
+
+func (emptyMatcher) Describe() string {
+	return "be empty"
+}
+
+func (emptyMatcher) Params(actual interface{}) []*allure.Parameter {
+	return allure.NewParameters("Actual", wrapper.FormatValue(actual))
+}
+
+type lenMatcher struct {
+	length int
+}
+
+// HaveLen matches strings, slices, arrays, maps and channels with the length
+func HaveLen(length int) Matcher {
+	return &lenMatcher{length: length}
+}
+
+func (m *lenMatcher) Match(actual interface{}) error {
+	length, ok := lengthOf(actual)
+	if !ok {
+		return fmt.Errorf("%s has no length", wrapper.FormatValue(actual))
+	}
+	if length != m.length {
+		return fmt.Errorf("%s should have %d item(s), but has %d", wrapper.FormatValue(actual), m.length, length)
+	}
+	return nil
+}
+
+func (m *lenMatcher) Describe() string {
+	return fmt.Sprintf("have length %d", m.length)
+}
+
+func (m *lenMatcher) Params(actual interface{}) []*allure.Parameter {
+	return allure.NewParameters("Actual", wrapper.FormatValue(actual), "Expected Length", m.length)
+}
+
+type containMatcher struct {
+	element interface{}
+}
+
+// ContainElement matches slices and arrays, which contain the element, and strings, which contain the substring
+func ContainElement(element interface{}) Matcher {
+	return &containMatcher{element: element}
+}
+
+func (m *containMatcher) Match(actual interface{}) error {
+	if s, ok := actual.(string); ok {
+		substr, ok := m.element.(string)
+		if !ok {
+			return fmt.Errorf("string can contain only string, but got %s", wrapper.FormatValue(m.element))
+		}
+		if !strings.Contains(s, substr) {
+			return fmt.Errorf("%s does not contain %s", wrapper.FormatValue(actual), wrapper.FormatValue(m.element))
+		}
+		return nil
+	}
+
+	v := reflect.ValueOf(actual)
+	if !v.IsValid() || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) {
+		return fmt.Errorf("%s is not a slice, an array or a string", wrapper.FormatValue(actual))
+	}
+	for i := 0; i < v.Len(); i++ {
+		if assert.ObjectsAreEqual(m.element, v.Index(i).Interface()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s does not contain %s", wrapper.FormatValue(actual), wrapper.FormatValue(m.element))
+}
+
+func (m *containMatcher) Describe() string {
+	return "contain " + wrapper.FormatValue(m.element)
+}
+
+func (m *containMatcher) Params(actual interface{}) []*allure.Parameter {
+	return allure.NewParameters("Target", wrapper.FormatValue(actual), "Should Contain", wrapper.FormatValue(m.element))
+}
+
+type keyMatcher struct {
+	key interface{}
+}
+
+// HaveKey matches maps with the key
+func HaveKey(key interface{}) Matcher {
+	return &keyMatcher{key: key}
+}
+
+func (m *keyMatcher) Match(actual interface{}) error {
+	v := reflect.ValueOf(actual)
+	if !v.IsValid() || v.Kind() != reflect.Map {
+		return fmt.Errorf("%s is not a map", wrapper.FormatValue(actual))
+	}
+	for _, key := range v.MapKeys() {
+		if assert.ObjectsAreEqual(m.key, key.Interface()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("map has no key %s", wrapper.FormatValue(m.key))
+}
+
+func (m *keyMatcher) Describe() string {
+	return "have key " + wrapper.FormatValue(m.key)
+}
+
+func (m *keyMatcher) Params(actual interface{}) []*allure.Parameter {
+	return allure.NewParameters("Map", wrapper.FormatValue(actual), "Key", wrapper.FormatValue(m.key))
+}
+
+type regexpMatcher struct {
+	pattern string
+}
+
+// MatchRegexp matches strings and []byte with the regular expression
+func MatchRegexp(pattern string) Matcher {
+	return &regexpMatcher{pattern: pattern}
+}
+
+func (m *regexpMatcher) Match(actual interface{}) error {
+	rx, err := regexp.Compile(m.pattern)
+	if err != nil {
+		return fmt.Errorf("invalid regexp %q: %v", m.pattern, err)
+	}
+
+	var s string
+	switch v := actual.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("%s is not a string or []byte", wrapper.FormatValue(actual))
+	}
+	if !rx.MatchString(s) {
+		return fmt.Errorf("%q does not match %q", s, m.pattern)
+	}
+	return nil
+}
+
+func (m *regexpMatcher) Describe() string {
+	return fmt.Sprintf("match regexp %q", m.pattern)
+}
+
+func (m *regexpMatcher) Params(actual interface{}) []*allure.Parameter {
+	return allure.NewParameters("Regexp", m.pattern, "Actual", wrapper.FormatValue(actual))
+}
+
+type fieldMatcher struct {
+	name    string
+	matcher Matcher
+}
+
+// HaveField matches structs (and pointers to structs), which have the field matching expected.
+// Expected can be the Matcher, other values are compared with Equal. Nested fields are separated by dot:
+//
+//	t.Expect(user).To(matcher.HaveField("ID", 5))
+//	t.Expect(user).To(matcher.HaveField("Address.City", matcher.MatchRegexp("^New")))
+func HaveField(name string, expected interface{}) Matcher {
+	if m, ok := expected.(Matcher); ok {
+		return &fieldMatcher{name: name, matcher: m}
+	}
+	return &fieldMatcher{name: name, matcher: Equal(expected)}
+}
+
+func (m *fieldMatcher) Match(actual interface{}) error {
+	value, err := fieldOf(actual, m.name)
+	if err != nil {
+		return err
+	}
+	if err = m.matcher.Match(value); err != nil {
+		return fmt.Errorf("field %s: %v", m.name, err)
+	}
+	return nil
+}
+
+func (m *fieldMatcher) Describe() string {
+	if eq, ok := m.matcher.(*equalMatcher); ok {
+		return fmt.Sprintf("have field %s equal to %s", m.name, wrapper.FormatValue(eq.expected))
+	}
+	return fmt.Sprintf("have field %s, which should %s", m.name, m.matcher.Describe())
+}
+
+func (m *fieldMatcher) Params(actual interface{}) []*allure.Parameter {
+	value, _ := fieldOf(actual, m.name)
+	return append(allure.NewParameters("Field", m.name), m.matcher.Params(value)...)
+}
+
+type statusMatcher struct {
+	status int
+}
+
+// HaveStatus matches *http.Response, *httptest.ResponseRecorder and int with the status code
+func HaveStatus(status int) Matcher {
+	return &statusMatcher{status: status}
+}
+
+func (m *statusMatcher) Match(actual interface{}) error {
+	status, err := statusOf(actual)
+	if err != nil {
+		return err
+	}
+	if status != m.status {
+		return fmt.Errorf("expected status %s, but got %s", formatStatus(m.status), formatStatus(status))
+	}
+	return nil
+}
+
+func (m *statusMatcher) Describe() string {
+	return fmt.Sprintf("have status %d", m.status)
+}
+
+func (m *statusMatcher) Params(actual interface{}) []*allure.Parameter {
+	params := allure.NewParameters("Expected Status", formatStatus(m.status))
+	if status, err := statusOf(actual); err == nil {
+		params = append(params, allure.NewParameter("Actual Status", formatStatus(status)))
+	}
+	return params
+}
+
+type notMatcher struct {
+	matcher Matcher
+}
+
+// Not matches values, which don't match the matcher
+func Not(matcher Matcher) Matcher {
+	return &notMatcher{matcher: matcher}
+}
+
+func (m *notMatcher) Match(actual interface{}) error {
+	if err := m.matcher.Match(actual); err == nil {
+		return fmt.Errorf("should not %s", m.matcher.Describe())
+	}
+	return nil
+}
+
+func (m *notMatcher) Describe() string {
+	return "not " + m.matcher.Describe()
+}
+
+func (m *notMatcher) Params(actual interface{}) []*allure.Parameter {
+	return m.matcher.Params(actual)
+}
+
+type allOfMatcher struct {
+	matchers []Matcher
+}
+
+// AllOf matches values, which match all the matchers. Matchers are checked in order until the first mismatch
+func AllOf(matchers ...Matcher) Matcher {
+	return &allOfMatcher{matchers: matchers}
+}
+
+func (m *allOfMatcher) Match(actual interface{}) error {
+	for _, matcher := range m.matchers {
+		if err := matcher.Match(actual); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *allOfMatcher) Describe() string {
+	descriptions := make([]string, 0, len(m.matchers))
+	for _, matcher := range m.matchers {
+		descriptions = append(descriptions, matcher.Describe())
+	}
+	return strings.Join(descriptions, " and ")
+}
+
+// Params returns parameters of all matchers, parameters with the same name are added once
+func (m *allOfMatcher) Params(actual interface{}) []*allure.Parameter {
+	var (
+		params []*allure.Parameter
+		names  = make(map[string]struct{})
+	)
+	for _, matcher := range m.matchers {
+		for _, param := range matcher.Params(actual) {
+			if _, ok := names[param.Name]; ok {
+				continue
+			}
+			names[param.Name] = struct{}{}
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// fieldOf returns value of the field of the struct, nested fields are separated by dot
+func fieldOf(actual interface{}, name string) (interface{}, error) {
+	v := reflect.ValueOf(actual)
+	for _, field := range strings.Split(name, ".") {
+		for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+			if v.IsNil() {
+				return nil, fmt.Errorf("can't get field %s of nil", field)
+			}
+			v = v.Elem()
+		}
+		if !v.IsValid() || v.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("can't get field %s of %T: not a struct", field, actual)
+		}
+
+		f, ok := v.Type().FieldByName(field)
+		if !ok {
+			return nil, fmt.Errorf("%s has no field %s", v.Type(), field)
+		}
+		if f.PkgPath != "" {
+			return nil, fmt.Errorf("field %s of %s is unexported", field, v.Type())
+		}
+		v = v.FieldByIndex(f.Index)
+	}
+
+	return v.Interface(), nil
+}
+
+func statusOf(actual interface{}) (int, error) {
+	switch v := actual.(type) {
+	case *http.Response:
+		if v != nil {
+			return v.StatusCode, nil
+		}
+	case *httptest.ResponseRecorder:
+		if v != nil {
+			return v.Code, nil
+		}
+	case int:
+		return v, nil
+	}
+	return 0, fmt.Errorf("can't get status of %T", actual)
+}
+
+func formatStatus(status int) string {
+	if text := http.StatusText(status); text != "" {
+		return fmt.Sprintf("%d %s", status, text)
+	}
+	return fmt.Sprint(status)
+}
+
+func isNil(actual interface{}) bool {
+	if actual == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(actual)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+func isEmpty(actual interface{}) bool {
+	if actual == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(actual)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	case reflect.Ptr:
+		if v.IsNil() {
+			return true
+		}
+		return isEmpty(v.Elem().Interface())
+	default:
+		return v.IsZero()
+	}
+}
+
+func lengthOf(actual interface{}) (int, bool) {
+	v := reflect.ValueOf(actual)
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len(), true
+	}
+	return 0, false
+}
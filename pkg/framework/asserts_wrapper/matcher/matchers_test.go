@@ -0,0 +1,172 @@
+package matcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/wrapper"
+)
+
+type address struct {
+	City string
+}
+
+type user struct {
+	ID      int
+	Name    string
+	Address *address
+	secret  string
+}
+
+func TestEqual(t *testing.T) {
+	m := Equal(5)
+	require.Equal(t, "equal 5", m.Describe())
+	require.NoError(t, m.Match(5))
+	require.EqualError(t, m.Match(int64(5)), "expected 5, but got 5")
+	require.EqualError(t, Equal("a").Match("b"), `expected "a", but got "b"`)
+
+	params := m.Params(6)
+	require.Len(t, params, 2)
+	require.Equal(t, "Expected", params[0].Name)
+	require.Equal(t, "5", params[0].GetValue())
+	require.Equal(t, "6", params[1].GetValue())
+}
+
+func TestBeNil(t *testing.T) {
+	var (
+		ptr *user
+		err error
+	)
+	require.NoError(t, BeNil().Match(nil))
+	require.NoError(t, BeNil().Match(ptr))
+	require.NoError(t, BeNil().Match(err))
+	require.NoError(t, BeNil().Match([]int(nil)))
+	require.Error(t, BeNil().Match(0))
+	require.Error(t, BeNil().Match(&user{}))
+}
+
+func TestBeEmpty(t *testing.T) {
+	empty := ""
+	require.NoError(t, BeEmpty().Match(nil))
+	require.NoError(t, BeEmpty().Match(""))
+	require.NoError(t, BeEmpty().Match(0))
+	require.NoError(t, BeEmpty().Match([]int{}))
+	require.NoError(t, BeEmpty().Match(map[string]int{}))
+	require.NoError(t, BeEmpty().Match(&empty))
+	require.NoError(t, BeEmpty().Match(user{}))
+	require.Error(t, BeEmpty().Match("a"))
+	require.Error(t, BeEmpty().Match([]int{1}))
+	require.Error(t, BeEmpty().Match(user{ID: 1}))
+}
+
+func TestHaveLen(t *testing.T) {
+	require.Equal(t, "have length 2", HaveLen(2).Describe())
+	require.NoError(t, HaveLen(2).Match([]int{1, 2}))
+	require.NoError(t, HaveLen(2).Match("ab"))
+	require.NoError(t, HaveLen(1).Match(map[int]int{1: 1}))
+	require.EqualError(t, HaveLen(3).Match([]int{1, 2}), "[]int{1, 2} should have 3 item(s), but has 2")
+	require.EqualError(t, HaveLen(1).Match(5), "5 has no length")
+}
+
+func TestContainElement(t *testing.T) {
+	require.Equal(t, `contain "b"`, ContainElement("b").Describe())
+	require.NoError(t, ContainElement("b").Match([]string{"a", "b"}))
+	require.NoError(t, ContainElement(2).Match([2]int{1, 2}))
+	require.NoError(t, ContainElement("ell").Match("hello"))
+	require.EqualError(t, ContainElement(3).Match([]int{1, 2}), "[]int{1, 2} does not contain 3")
+	require.EqualError(t, ContainElement(1).Match("hello"), "string can contain only string, but got 1")
+	require.EqualError(t, ContainElement(1).Match(1), "1 is not a slice, an array or a string")
+}
+
+func TestHaveKey(t *testing.T) {
+	m := map[string]int{"a": 1}
+	require.NoError(t, HaveKey("a").Match(m))
+	require.EqualError(t, HaveKey("b").Match(m), `map has no key "b"`)
+	require.EqualError(t, HaveKey("a").Match([]string{"a"}), `[]string{"a"} is not a map`)
+}
+
+func TestMatchRegexp(t *testing.T) {
+	require.Equal(t, `match regexp "^a+$"`, MatchRegexp("^a+$").Describe())
+	require.NoError(t, MatchRegexp("^a+$").Match("aaa"))
+	require.NoError(t, MatchRegexp("^a+$").Match([]byte("aa")))
+	require.EqualError(t, MatchRegexp("^a+$").Match("ab"), `"ab" does not match "^a+$"`)
+	require.EqualError(t, MatchRegexp("(").Match("a"), "invalid regexp \"(\": error parsing regexp: missing closing ): `(`")
+	require.EqualError(t, MatchRegexp("a").Match(1), "1 is not a string or []byte")
+}
+
+func TestHaveField(t *testing.T) {
+	u := &user{ID: 5, Name: "alice", Address: &address{City: "New York"}, secret: "x"}
+
+	m := HaveField("ID", 5)
+	require.Equal(t, "have field ID equal to 5", m.Describe())
+	require.NoError(t, m.Match(u))
+	require.NoError(t, m.Match(*u))
+	require.EqualError(t, HaveField("ID", 6).Match(u), "field ID: expected 6, but got 5")
+
+	params := m.Params(u)
+	require.Len(t, params, 3)
+	require.Equal(t, "Field", params[0].Name)
+	require.Equal(t, "ID", params[0].GetValue())
+	require.Equal(t, "Expected", params[1].Name)
+	require.Equal(t, "5", params[2].GetValue())
+
+	nested := HaveField("Address.City", MatchRegexp("^New"))
+	require.Equal(t, `have field Address.City, which should match regexp "^New"`, nested.Describe())
+	require.NoError(t, nested.Match(u))
+	require.EqualError(t, nested.Match(&user{}), "can't get field City of nil")
+
+	require.EqualError(t, HaveField("Email", "").Match(u), "matcher.user has no field Email")
+	require.EqualError(t, HaveField("secret", "x").Match(u), "field secret of matcher.user is unexported")
+	require.EqualError(t, HaveField("ID", 5).Match(5), "can't get field ID of int: not a struct")
+}
+
+func TestHaveStatus(t *testing.T) {
+	m := HaveStatus(http.StatusOK)
+	require.Equal(t, "have status 200", m.Describe())
+	require.NoError(t, m.Match(&http.Response{StatusCode: http.StatusOK}))
+	require.NoError(t, m.Match(httptest.NewRecorder()))
+	require.NoError(t, m.Match(http.StatusOK))
+	require.EqualError(t, m.Match(&http.Response{StatusCode: http.StatusNotFound}), "expected status 200 OK, but got 404 Not Found")
+	require.EqualError(t, m.Match("200"), "can't get status of string")
+
+	params := m.Params(&http.Response{StatusCode: http.StatusNotFound})
+	require.Len(t, params, 2)
+	require.Equal(t, "200 OK", params[0].GetValue())
+	require.Equal(t, "Actual Status", params[1].Name)
+	require.Equal(t, "404 Not Found", params[1].GetValue())
+	require.Len(t, m.Params(nil), 1)
+}
+
+func TestNot(t *testing.T) {
+	m := Not(Equal(5))
+	require.Equal(t, "not equal 5", m.Describe())
+	require.NoError(t, m.Match(6))
+	require.EqualError(t, m.Match(5), "should not equal 5")
+}
+
+func TestAllOf(t *testing.T) {
+	m := AllOf(HaveField("ID", 5), HaveField("Name", "alice"))
+	require.Equal(t, `have field ID equal to 5 and have field Name equal to "alice"`, m.Describe())
+	require.NoError(t, m.Match(user{ID: 5, Name: "alice"}))
+	require.EqualError(t, m.Match(user{ID: 5, Name: "bob"}), `field Name: expected "alice", but got "bob"`)
+
+	params := m.Params(user{ID: 5, Name: "alice"})
+	require.Len(t, params, 3)
+	require.Equal(t, "5", params[1].GetValue())
+}
+
+func TestExpect_builtInMatchers(t *testing.T) {
+	mockT := newMock()
+	resp := &http.Response{StatusCode: http.StatusInternalServerError}
+	Expect(wrapper.NewAsserts(mockT), mockT, resp).To(HaveStatus(http.StatusOK))
+	Expect(wrapper.NewAsserts(mockT), mockT, user{ID: 5}).To(HaveField("ID", 5))
+
+	require.Len(t, mockT.steps, 2)
+	require.Equal(t, "ASSERT: Expect to have status 200", mockT.steps[0].Name)
+	require.Equal(t, map[string]string{"Expected Status": "200 OK", "Actual Status": "500 Internal Server Error"}, paramsOf(mockT.steps[0]))
+	require.Contains(t, mockT.errors[0], "expected status 200 OK, but got 500 Internal Server Error")
+	require.Equal(t, "ASSERT: Expect to have field ID equal to 5", mockT.steps[1].Name)
+}
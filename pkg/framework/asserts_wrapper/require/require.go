@@ -1,9 +1,12 @@
 package require
 
 import (
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
 	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/wrapper"
 	"github.com/stretchr/testify/assert"
 )
@@ -203,3 +206,103 @@ func Zero(t ProviderT, i interface{}, msgAndArgs ...interface{}) {
 func NotZero(t ProviderT, i interface{}, msgAndArgs ...interface{}) {
 	wrapper.NewRequire(t).NotZero(t, i, msgAndArgs...)
 }
+
+// Panics ...
+func Panics(t ProviderT, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).Panics(t, f, msgAndArgs...)
+}
+
+// NotPanics ...
+func NotPanics(t ProviderT, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).NotPanics(t, f, msgAndArgs...)
+}
+
+// PanicsWithError ...
+func PanicsWithError(t ProviderT, errString string, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).PanicsWithError(t, errString, f, msgAndArgs...)
+}
+
+// ErrorContains ...
+func ErrorContains(t ProviderT, theError error, contains string, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).ErrorContains(t, theError, contains, msgAndArgs...)
+}
+
+// FileExists ...
+func FileExists(t ProviderT, path string, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).FileExists(t, path, msgAndArgs...)
+}
+
+// NoFileExists ...
+func NoFileExists(t ProviderT, path string, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).NoFileExists(t, path, msgAndArgs...)
+}
+
+// NotRegexp ...
+func NotRegexp(t ProviderT, rx interface{}, str interface{}, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).NotRegexp(t, rx, str, msgAndArgs...)
+}
+
+// InEpsilon ...
+func InEpsilon(t ProviderT, expected, actual interface{}, epsilon float64, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).InEpsilon(t, expected, actual, epsilon, msgAndArgs...)
+}
+
+// InDeltaSlice ...
+func InDeltaSlice(t ProviderT, expected, actual interface{}, delta float64, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).InDeltaSlice(t, expected, actual, delta, msgAndArgs...)
+}
+
+// YAMLEq ...
+func YAMLEq(t ProviderT, expected, actual string, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).YAMLEq(t, expected, actual, msgAndArgs...)
+}
+
+// NotElementsMatch ...
+func NotElementsMatch(t ProviderT, listA interface{}, listB interface{}, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).NotElementsMatch(t, listA, listB, msgAndArgs...)
+}
+
+// Never ...
+func Never(t ProviderT, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).Never(t, condition, waitFor, tick, msgAndArgs...)
+}
+
+// EventuallyWithT ...
+func EventuallyWithT(t ProviderT, condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).EventuallyWithT(t, condition, waitFor, tick, msgAndArgs...)
+}
+
+// Positive ...
+func Positive(t ProviderT, e interface{}, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).Positive(t, e, msgAndArgs...)
+}
+
+// Negative ...
+func Negative(t ProviderT, e interface{}, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).Negative(t, e, msgAndArgs...)
+}
+
+// IsIncreasing ...
+func IsIncreasing(t ProviderT, object interface{}, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).IsIncreasing(t, object, msgAndArgs...)
+}
+
+// IsDecreasing ...
+func IsDecreasing(t ProviderT, object interface{}, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).IsDecreasing(t, object, msgAndArgs...)
+}
+
+// HTTPStatusCode ...
+func HTTPStatusCode(t ProviderT, handler http.HandlerFunc, method, url string, values url.Values, statuscode int, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).HTTPStatusCode(t, handler, method, url, values, statuscode, msgAndArgs...)
+}
+
+// HTTPBodyContains ...
+func HTTPBodyContains(t ProviderT, handler http.HandlerFunc, method, url string, values url.Values, str interface{}, msgAndArgs ...interface{}) {
+	wrapper.NewRequire(t).HTTPBodyContains(t, handler, method, url, values, str, msgAndArgs...)
+}
+
+// Expect returns expectation of actual value, which is checked with matchers
+func Expect(t ProviderT, actual interface{}) matcher.Expectation {
+	return matcher.Expect(wrapper.NewRequire(t), t, actual)
+}
@@ -2,14 +2,19 @@ package require
 
 import (
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
 )
 
 type providerTMock struct {
@@ -1740,3 +1745,118 @@ func TestRequireNotZero_Fail(t *testing.T) {
 	require.True(t, mockT.failNow)
 	require.Equal(t, "\n%s", mockT.errorFString)
 }
+
+func TestRequireExtended_Success(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	handler := func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("hello")) }
+
+	mockT := newMock()
+	Panics(mockT, func() { panic("whoops") })
+	NotPanics(mockT, func() {})
+	PanicsWithError(mockT, "whoops", func() { panic(errors.New("whoops")) })
+	ErrorContains(mockT, errors.New("connection refused"), "refused")
+	FileExists(mockT, file)
+	NoFileExists(mockT, file+".missing")
+	NotRegexp(mockT, "^[0-9]+$", "abc")
+	InEpsilon(mockT, 100, 101, 0.05)
+	InDeltaSlice(mockT, []float64{1, 2}, []float64{1.05, 2}, 0.1)
+	YAMLEq(mockT, "a: 1\nb: 2\n", "b: 2\na: 1\n")
+	NotElementsMatch(mockT, []int{1, 2}, []int{2, 3})
+	Never(mockT, func() bool { return false }, 20*time.Millisecond, 5*time.Millisecond)
+	EventuallyWithT(mockT, func(c *assert.CollectT) {}, time.Second, 5*time.Millisecond)
+	Positive(mockT, 1)
+	Negative(mockT, -1)
+	IsIncreasing(mockT, []int{1, 2, 3})
+	IsDecreasing(mockT, []int{3, 2, 1})
+	HTTPStatusCode(mockT, handler, http.MethodGet, "/", nil, http.StatusOK)
+	HTTPBodyContains(mockT, handler, http.MethodGet, "/", nil, "hello")
+
+	names := []string{
+		"REQUIRE: Panics",
+		"REQUIRE: Not Panics",
+		"REQUIRE: Panics With Error",
+		"REQUIRE: Error Contains",
+		"REQUIRE: File Exists",
+		"REQUIRE: No File Exists",
+		"REQUIRE: Not Regexp",
+		"REQUIRE: In Epsilon",
+		"REQUIRE: In Delta Slice",
+		"REQUIRE: YAML Equal",
+		"REQUIRE: Not Elements Match",
+		"REQUIRE: Never",
+		"REQUIRE: Eventually With T",
+		"REQUIRE: Positive",
+		"REQUIRE: Negative",
+		"REQUIRE: Is Increasing",
+		"REQUIRE: Is Decreasing",
+		"REQUIRE: HTTP Status Code",
+		"REQUIRE: HTTP Body Contains",
+	}
+	require.Len(t, mockT.steps, len(names))
+	for i, step := range mockT.steps {
+		require.Equal(t, names[i], step.Name)
+		require.Equal(t, allure.Passed, step.Status, step.Name)
+		require.NotEmpty(t, step.Parameters, step.Name)
+	}
+	require.False(t, mockT.errorF)
+	require.False(t, mockT.failNow)
+}
+
+func TestRequireExtended_Fail(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	handler := func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("hello")) }
+
+	asserts := []func(mockT *providerTMock){
+		func(mockT *providerTMock) { Panics(mockT, func() {}) },
+		func(mockT *providerTMock) { NotPanics(mockT, func() { panic("whoops") }) },
+		func(mockT *providerTMock) { PanicsWithError(mockT, "whoops", func() {}) },
+		func(mockT *providerTMock) { ErrorContains(mockT, errors.New("connection refused"), "timeout") },
+		func(mockT *providerTMock) { FileExists(mockT, file+".missing") },
+		func(mockT *providerTMock) { NoFileExists(mockT, file) },
+		func(mockT *providerTMock) { NotRegexp(mockT, "^[0-9]+$", "123") },
+		func(mockT *providerTMock) { InEpsilon(mockT, 100, 110, 0.05) },
+		func(mockT *providerTMock) { InDeltaSlice(mockT, []float64{1, 2}, []float64{1.5, 2}, 0.1) },
+		func(mockT *providerTMock) { YAMLEq(mockT, "a: 1\n", "a: 2\n") },
+		func(mockT *providerTMock) { NotElementsMatch(mockT, []int{1, 2}, []int{2, 1}) },
+		func(mockT *providerTMock) {
+			Never(mockT, func() bool { return true }, 20*time.Millisecond, 5*time.Millisecond)
+		},
+		func(mockT *providerTMock) {
+			EventuallyWithT(mockT, func(c *assert.CollectT) { assert.Fail(c, "never") }, 20*time.Millisecond, 5*time.Millisecond)
+		},
+		func(mockT *providerTMock) { Positive(mockT, -1) },
+		func(mockT *providerTMock) { Negative(mockT, 1) },
+		func(mockT *providerTMock) { IsIncreasing(mockT, []int{3, 2, 1}) },
+		func(mockT *providerTMock) { IsDecreasing(mockT, []int{1, 2, 3}) },
+		func(mockT *providerTMock) {
+			HTTPStatusCode(mockT, handler, http.MethodGet, "/", nil, http.StatusNotFound)
+		},
+		func(mockT *providerTMock) { HTTPBodyContains(mockT, handler, http.MethodGet, "/", nil, "bye") },
+	}
+	for _, assertFunc := range asserts {
+		mockT := newMock()
+		assertFunc(mockT)
+		require.Len(t, mockT.steps, 1)
+		require.Equal(t, allure.Failed, mockT.steps[0].Status, mockT.steps[0].Name)
+		require.True(t, strings.HasPrefix(mockT.steps[0].Name, "REQUIRE: "))
+		require.True(t, mockT.errorF, mockT.steps[0].Name)
+		require.Equal(t, true, mockT.failNow, mockT.steps[0].Name)
+	}
+}
+
+func TestExpect(t *testing.T) {
+	mockT := newMock()
+	Expect(mockT, map[string]int{"a": 1}).To(matcher.HaveKey("a"))
+	require.Len(t, mockT.steps, 1)
+	require.Equal(t, `REQUIRE: Expect to have key "a"`, mockT.steps[0].Name)
+	require.Equal(t, allure.Passed, mockT.steps[0].Status)
+	require.False(t, mockT.errorF)
+
+	Expect(mockT, map[string]int{"a": 1}).NotTo(matcher.HaveKey("a"))
+	require.Equal(t, `REQUIRE: Expect not to have key "a"`, mockT.steps[1].Name)
+	require.Equal(t, allure.Failed, mockT.steps[1].Status)
+	require.True(t, mockT.errorF)
+	require.Equal(t, true, mockT.failNow)
+}
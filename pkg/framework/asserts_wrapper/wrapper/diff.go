@@ -0,0 +1,191 @@
+package wrapper
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// DiffThresholds control when formatted values of comparison are too big for parameters of the step.
+// Values longer than MaxLength bytes or MaxLines lines are replaced in parameters by reference to attachment.
+// Zero disables the threshold
+type DiffThresholds struct {
+	MaxLength int
+	MaxLines  int
+}
+
+var (
+	diffThresholdsMu sync.RWMutex
+	diffThresholds   = DiffThresholds{MaxLength: 1000, MaxLines: 20}
+)
+
+// SetDiffThresholds sets thresholds of parameters of Equal, EqualValues, JSONEq and ElementsMatch asserts
+func SetDiffThresholds(thresholds DiffThresholds) {
+	diffThresholdsMu.Lock()
+	defer diffThresholdsMu.Unlock()
+
+	diffThresholds = thresholds
+}
+
+// GetDiffThresholds returns current thresholds of parameters (by default 1000 bytes and 20 lines)
+func GetDiffThresholds() DiffThresholds {
+	diffThresholdsMu.RLock()
+	defer diffThresholdsMu.RUnlock()
+
+	return diffThresholds
+}
+
+func (th DiffThresholds) exceeded(value string) bool {
+	return (th.MaxLength > 0 && len(value) > th.MaxLength) ||
+		(th.MaxLines > 0 && strings.Count(value, "\n") >= th.MaxLines)
+}
+
+// spewConfig is the same as testify uses for diffs
+var spewConfig = spew.ConfigState{
+	Indent:                  " ",
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+	SortKeys:                true,
+	DisableMethods:          true,
+	MaxDepth:                10,
+}
+
+// comparison is expected and actual values of equality assert.
+// Failed comparison attaches both values and their unified diff to the step.
+// Full values are formatted only when they are attached, so passed asserts don't dump big values
+type comparison struct {
+	names  [2]string // names of parameters and attachments
+	params [2]string // values for parameters
+	dump   func() ([2]string, allure.MimeType)
+
+	contents [2]string // full values for attachments and diff, set by dump
+	mimeType allure.MimeType
+	dumped   bool
+}
+
+func newComparison(expectedName, actualName string, expected, actual interface{}) *comparison {
+	expString, actString := formatUnequalValues(expected, actual)
+	return &comparison{
+		names:  [2]string{expectedName, actualName},
+		params: [2]string{expString, actString},
+		dump: func() ([2]string, allure.MimeType) {
+			return [2]string{dumpValue(expected), dumpValue(actual)}, allure.Text
+		},
+	}
+}
+
+// newJSONComparison compares JSON documents, which are indented with sorted keys, if they are valid
+func newJSONComparison(expected, actual string) *comparison {
+	return &comparison{
+		names:  [2]string{"Expected", "Actual"},
+		params: [2]string{expected, actual},
+		dump: func() ([2]string, allure.MimeType) {
+			expIndented, expOk := indentJSON(expected)
+			actIndented, actOk := indentJSON(actual)
+			if expOk && actOk {
+				return [2]string{expIndented, actIndented}, allure.JSON
+			}
+
+			return [2]string{expected, actual}, allure.Text
+		},
+	}
+}
+
+// content returns full value for attachment and diff, formatting both values on the first call
+func (c *comparison) content(i int) string {
+	if !c.dumped {
+		c.contents, c.mimeType = c.dump()
+		c.dumped = true
+	}
+
+	return c.contents[i]
+}
+
+// parameters returns parameters of the step: values above thresholds are replaced by reference to attachments
+func (c *comparison) parameters() []*allure.Parameter {
+	thresholds := GetDiffThresholds()
+
+	params := make([]*allure.Parameter, 0, len(c.names))
+	for i, name := range c.names {
+		value := c.params[i]
+		if thresholds.exceeded(value) {
+			value = fmt.Sprintf("see attachment %q", name)
+		}
+		params = append(params, allure.NewParameter(name, value))
+	}
+
+	return params
+}
+
+// attachments returns attachments of the step. Failed comparison gets both values and their diff,
+// otherwise only values replaced in parameters are attached
+func (c *comparison) attachments(failed bool) []*allure.Attachment {
+	thresholds := GetDiffThresholds()
+
+	var attachments []*allure.Attachment
+	for i, name := range c.names {
+		if failed || thresholds.exceeded(c.params[i]) {
+			content := c.content(i)
+			attachments = append(attachments, allure.NewAttachment(name, c.mimeType, []byte(content)))
+		}
+	}
+
+	if failed {
+		if diff := c.diff(); diff != "" {
+			attachments = append(attachments, allure.NewAttachment("Diff", allure.Text, []byte(diff)))
+		}
+	}
+
+	return attachments
+}
+
+// diff returns unified diff of the values
+func (c *comparison) diff() string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(c.content(0)),
+		B:        difflib.SplitLines(c.content(1)),
+		FromFile: c.names[0],
+		ToFile:   c.names[1],
+		Context:  3,
+	})
+
+	return diff
+}
+
+// dumpValue formats the value without truncating: strings as is, other values with spew
+func dumpValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time, time.Duration:
+		return fmt.Sprintf("%v", v)
+	}
+
+	if v := reflect.ValueOf(value); v.IsValid() && v.Kind() == reflect.String {
+		return v.String()
+	}
+
+	return spewConfig.Sdump(value)
+}
+
+func indentJSON(value string) (string, bool) {
+	var obj interface{}
+	if err := sonic.Unmarshal([]byte(value), &obj); err != nil {
+		return value, false
+	}
+
+	indented, err := sonic.ConfigStd.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return value, false
+	}
+
+	return string(indented), true
+}
@@ -0,0 +1,156 @@
+package wrapper
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+type diffUser struct {
+	Name string
+	Age  int
+}
+
+func withDiffThresholds(t *testing.T, thresholds DiffThresholds) {
+	prev := GetDiffThresholds()
+	SetDiffThresholds(thresholds)
+	t.Cleanup(func() { SetDiffThresholds(prev) })
+}
+
+func attachmentsByName(step *allure.Step) map[string]*allure.Attachment {
+	attachments := make(map[string]*allure.Attachment)
+	for _, attachment := range step.Attachments {
+		attachments[attachment.Name] = attachment
+	}
+	return attachments
+}
+
+func TestDiffThresholds_exceeded(t *testing.T) {
+	th := DiffThresholds{MaxLength: 10, MaxLines: 2}
+	require.False(t, th.exceeded("short"))
+	require.True(t, th.exceeded("longer than ten"))
+	require.True(t, th.exceeded("a\nb\nc"))
+	require.False(t, DiffThresholds{}.exceeded(strings.Repeat("a", 100000)))
+}
+
+func TestFormatValue(t *testing.T) {
+	require.Equal(t, `"text"`, FormatValue("text"))
+	require.Equal(t, `"boom"`, FormatValue(errors.New("boom")))
+	require.Equal(t, "1s", FormatValue(time.Second))
+	require.Equal(t, `[]int{1, 2}`, FormatValue([]int{1, 2}))
+
+	withDiffThresholds(t, DiffThresholds{MaxLength: 10})
+	require.Equal(t, `"aaaaaaaaa<... truncated>`, FormatValue(strings.Repeat("a", 20)))
+
+	withDiffThresholds(t, DiffThresholds{})
+	require.Len(t, FormatValue(strings.Repeat("a", 100000)), bufio.MaxScanTokenSize-100+len("<... truncated>"))
+}
+
+func TestAssertEqual_Success_noAttachments(t *testing.T) {
+	mockT := newMock()
+	NewAsserts(mockT).Equal(mockT, diffUser{Name: "alice"}, diffUser{Name: "alice"})
+	require.Len(t, mockT.steps, 1)
+	require.Empty(t, mockT.steps[0].Attachments)
+}
+
+func TestComparison_dumpOnlyAttached(t *testing.T) {
+	cmp := newComparison("Expected", "Actual", diffUser{Name: "alice"}, diffUser{Name: "alice"})
+	require.Empty(t, cmp.attachments(false))
+	require.False(t, cmp.dumped)
+
+	require.Len(t, cmp.attachments(true), 2)
+	require.True(t, cmp.dumped)
+}
+
+func TestAssertEqual_Fail_attachments(t *testing.T) {
+	mockT := newMock()
+	NewAsserts(mockT).Equal(mockT, diffUser{Name: "alice", Age: 30}, diffUser{Name: "bob", Age: 30})
+	require.Len(t, mockT.steps, 1)
+	step := mockT.steps[0]
+	require.Equal(t, allure.Failed, step.Status)
+
+	require.Len(t, step.Parameters, 2)
+	require.Contains(t, step.Parameters[0].GetValue(), "alice")
+
+	attachments := attachmentsByName(step)
+	require.Len(t, attachments, 3)
+	require.Equal(t, allure.Text, attachments["Expected"].Type)
+	require.Contains(t, string(attachments["Expected"].GetContent()), `Name: (string) (len=5) "alice"`)
+	require.Contains(t, string(attachments["Actual"].GetContent()), `Name: (string) (len=3) "bob"`)
+
+	diff := string(attachments["Diff"].GetContent())
+	require.Contains(t, diff, "--- Expected\n+++ Actual\n")
+	require.Contains(t, diff, `- Name: (string) (len=5) "alice",`)
+	require.Contains(t, diff, `+ Name: (string) (len=3) "bob",`)
+	require.Contains(t, diff, "  Age: (int) 30")
+}
+
+func TestAssertEqualValues_Fail_attachments(t *testing.T) {
+	mockT := newMock()
+	NewRequire(mockT).EqualValues(mockT, "line 1\nline 2\n", "line 1\nline 3\n")
+	require.True(t, mockT.failNow)
+
+	attachments := attachmentsByName(mockT.steps[0])
+	require.Equal(t, "line 1\nline 2\n", string(attachments["Expected"].GetContent()))
+	require.Equal(t, "line 1\nline 3\n", string(attachments["Actual"].GetContent()))
+	require.Contains(t, string(attachments["Diff"].GetContent()), " line 1\n-line 2\n+line 3\n")
+}
+
+func TestAssertEqual_thresholds(t *testing.T) {
+	withDiffThresholds(t, DiffThresholds{MaxLength: 10})
+
+	long := strings.Repeat("a", 20)
+	mockT := newMock()
+	NewAsserts(mockT).Equal(mockT, long, long)
+	step := mockT.steps[0]
+	require.Equal(t, allure.Passed, step.Status)
+
+	require.Equal(t, "Expected", step.Parameters[0].Name)
+	require.Equal(t, `see attachment "Expected"`, step.Parameters[0].GetValue())
+	require.Equal(t, `see attachment "Actual"`, step.Parameters[1].GetValue())
+
+	attachments := attachmentsByName(step)
+	require.Len(t, attachments, 2)
+	require.Equal(t, long, string(attachments["Expected"].GetContent()))
+	require.Equal(t, long, string(attachments["Actual"].GetContent()))
+}
+
+func TestAssertJSONEq_Fail_attachments(t *testing.T) {
+	mockT := newMock()
+	NewAsserts(mockT).JSONEq(mockT, `{"b": 1, "a": "x"}`, `{"a": "y", "b": 1}`)
+	step := mockT.steps[0]
+	require.Equal(t, allure.Failed, step.Status)
+
+	attachments := attachmentsByName(step)
+	require.Len(t, attachments, 3)
+	require.Equal(t, allure.JSON, attachments["Expected"].Type)
+	require.Equal(t, "{\n  \"a\": \"x\",\n  \"b\": 1\n}", string(attachments["Expected"].GetContent()))
+	require.Equal(t, "{\n  \"a\": \"y\",\n  \"b\": 1\n}", string(attachments["Actual"].GetContent()))
+	require.Contains(t, string(attachments["Diff"].GetContent()), "-  \"a\": \"x\",\n+  \"a\": \"y\",\n")
+}
+
+func TestAssertJSONEq_Fail_invalidJSON(t *testing.T) {
+	mockT := newMock()
+	NewAsserts(mockT).JSONEq(mockT, `{"a": 1}`, `not json`)
+
+	attachments := attachmentsByName(mockT.steps[0])
+	require.Equal(t, allure.Text, attachments["Actual"].Type)
+	require.Equal(t, "not json", string(attachments["Actual"].GetContent()))
+}
+
+func TestAssertElementsMatch_Fail_attachments(t *testing.T) {
+	mockT := newMock()
+	NewAsserts(mockT).ElementsMatch(mockT, []int{1, 2}, []int{2, 3})
+
+	attachments := attachmentsByName(mockT.steps[0])
+	require.Len(t, attachments, 3)
+	require.Contains(t, attachments, "ListA")
+	require.Contains(t, attachments, "ListB")
+	require.Contains(t, string(attachments["Diff"].GetContent()), "--- ListA\n+++ ListB\n")
+}
@@ -35,6 +35,22 @@ func (h *assertHelper) WithNewStep(t TestingT, provider Provider, assertName str
 	return result
 }
 
+// WithNewComparisonStep works as WithNewStep, but adds values of comparison and their diff as attachments to the step, if assert failed
+func (h *assertHelper) WithNewComparisonStep(t TestingT, provider Provider, assertName string, assert func(t TestingT) bool, cmp *comparison, msgAndArgs ...interface{}) bool {
+	var (
+		step   = allure.NewSimpleStep(h.getStepName(assertName, msgAndArgs...), cmp.parameters()...)
+		result = assert(t)
+	)
+
+	provider.Step(step)
+	if !result {
+		step.Failed()
+	}
+	step.WithAttachments(cmp.attachments(!result)...)
+
+	return result
+}
+
 func messageFromMsgAndArgs(msgAndArgs ...interface{}) string {
 	if len(msgAndArgs) == 0 || msgAndArgs == nil {
 		return ""
@@ -1,13 +1,25 @@
 package wrapper
 
 import (
+	"net/http"
+	"net/url"
 	"time"
 
+	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/stretchr/testify/assert"
 )
 
+// Decorator runs custom asserts as steps with the same semantic as built-in asserts
+type Decorator interface {
+	// Decorate runs assertFunc as the step named "ASSERT: name" (or "REQUIRE: name") with the parameters.
+	// Failed assert fails the step, and stops the test in require mode
+	Decorate(provider Provider, name string, assertFunc func(t TestingT) bool, params []*allure.Parameter, msgAndArgs ...interface{})
+}
+
 // AssertsWrapper ...
 type AssertsWrapper interface {
+	Decorator
+
 	Exactly(provider Provider, expected interface{}, actual interface{}, msgAndArgs ...interface{})
 	Same(provider Provider, expected interface{}, actual interface{}, msgAndArgs ...interface{})
 	NotSame(provider Provider, expected interface{}, actual interface{}, msgAndArgs ...interface{})
@@ -48,4 +60,23 @@ type AssertsWrapper interface {
 	NotZero(provider Provider, i interface{}, msgAndArgs ...interface{})
 	InDelta(provider Provider, expected, actual interface{}, delta float64, msgAndArgs ...interface{})
 	Eventually(provider Provider, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	Panics(provider Provider, f assert.PanicTestFunc, msgAndArgs ...interface{})
+	NotPanics(provider Provider, f assert.PanicTestFunc, msgAndArgs ...interface{})
+	PanicsWithError(provider Provider, errString string, f assert.PanicTestFunc, msgAndArgs ...interface{})
+	ErrorContains(provider Provider, theError error, contains string, msgAndArgs ...interface{})
+	FileExists(provider Provider, path string, msgAndArgs ...interface{})
+	NoFileExists(provider Provider, path string, msgAndArgs ...interface{})
+	NotRegexp(provider Provider, rx interface{}, str interface{}, msgAndArgs ...interface{})
+	InEpsilon(provider Provider, expected, actual interface{}, epsilon float64, msgAndArgs ...interface{})
+	InDeltaSlice(provider Provider, expected, actual interface{}, delta float64, msgAndArgs ...interface{})
+	YAMLEq(provider Provider, expected, actual string, msgAndArgs ...interface{})
+	NotElementsMatch(provider Provider, listA interface{}, listB interface{}, msgAndArgs ...interface{})
+	Never(provider Provider, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	EventuallyWithT(provider Provider, condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	Positive(provider Provider, e interface{}, msgAndArgs ...interface{})
+	Negative(provider Provider, e interface{}, msgAndArgs ...interface{})
+	IsIncreasing(provider Provider, object interface{}, msgAndArgs ...interface{})
+	IsDecreasing(provider Provider, object interface{}, msgAndArgs ...interface{})
+	HTTPStatusCode(provider Provider, handler http.HandlerFunc, method, url string, values url.Values, statuscode int, msgAndArgs ...interface{})
+	HTTPBodyContains(provider Provider, handler http.HandlerFunc, method, url string, values url.Values, str interface{}, msgAndArgs ...interface{})
 }
@@ -3,6 +3,8 @@ package wrapper
 import (
 	"bufio"
 	"fmt"
+	"net/http"
+	"net/url"
 	"reflect"
 	"time"
 
@@ -41,6 +43,7 @@ func NewRequire(t TestingT) AssertsWrapper {
 	}
 }
 
+// Decorate runs custom assert as the step, see Decorator
 func (a *asserts) Decorate(provider Provider, name string, assertFunc func(t TestingT) bool, params []*allure.Parameter, msgAndArgs ...interface{}) {
 	success := a.resultHelper.WithNewStep(
 		a.t,
@@ -113,13 +116,12 @@ func (a *asserts) NotSame(provider Provider, expected interface{}, actual interf
 // Equal ...
 func (a *asserts) Equal(provider Provider, expected interface{}, actual interface{}, msgAndArgs ...interface{}) {
 	assertName := "Equal"
-	expString, actString := formatUnequalValues(expected, actual)
-	success := a.resultHelper.WithNewStep(
+	success := a.resultHelper.WithNewComparisonStep(
 		a.t,
 		provider,
 		assertName,
 		func(t TestingT) bool { return assert.Equal(a.t, expected, actual, msgAndArgs...) },
-		allure.NewParameters("Expected", expString, "Actual", actString),
+		newComparison("Expected", "Actual", expected, actual),
 		msgAndArgs...,
 	)
 	if !success && a.required {
@@ -147,13 +149,12 @@ func (a *asserts) NotEqual(provider Provider, expected interface{}, actual inter
 // EqualValues ...
 func (a *asserts) EqualValues(provider Provider, expected interface{}, actual interface{}, msgAndArgs ...interface{}) {
 	assertName := "Equal Values"
-	expString, actString := formatUnequalValues(expected, actual)
-	success := a.resultHelper.WithNewStep(
+	success := a.resultHelper.WithNewComparisonStep(
 		a.t,
 		provider,
 		assertName,
 		func(t TestingT) bool { return assert.EqualValues(a.t, expected, actual, msgAndArgs...) },
-		allure.NewParameters("Expected", expString, "Actual", actString),
+		newComparison("Expected", "Actual", expected, actual),
 		msgAndArgs...,
 	)
 	if !success && a.required {
@@ -521,12 +522,12 @@ func (a *asserts) WithinDuration(provider Provider, expected, actual time.Time,
 // JSONEq ...
 func (a *asserts) JSONEq(provider Provider, expected, actual string, msgAndArgs ...interface{}) {
 	assertName := "JSON Equal"
-	success := a.resultHelper.WithNewStep(
+	success := a.resultHelper.WithNewComparisonStep(
 		a.t,
 		provider,
 		assertName,
 		func(t TestingT) bool { return assert.JSONEq(t, expected, actual, msgAndArgs...) },
-		allure.NewParameters("Expected", expected, "Actual", actual),
+		newJSONComparison(expected, actual),
 		msgAndArgs...,
 	)
 	if !success && a.required {
@@ -655,13 +656,12 @@ func (a *asserts) Regexp(provider Provider, rx interface{}, str interface{}, msg
 // ElementsMatch ...
 func (a *asserts) ElementsMatch(provider Provider, listA interface{}, listB interface{}, msgAndArgs ...interface{}) {
 	assertName := "Elements Match"
-	listAString, listBString := formatUnequalValues(listA, listB)
-	success := a.resultHelper.WithNewStep(
+	success := a.resultHelper.WithNewComparisonStep(
 		a.t,
 		provider,
 		assertName,
 		func(t TestingT) bool { return assert.ElementsMatch(a.t, listA, listB, msgAndArgs...) },
-		allure.NewParameters("ListA", listAString, "ListB", listBString),
+		newComparison("ListA", "ListB", listA, listB),
 		msgAndArgs...,
 	)
 	if !success && a.required {
@@ -766,6 +766,346 @@ func (a *asserts) Eventually(provider Provider, condition func() bool, waitFor t
 	}
 }
 
+// Panics ...
+func (a *asserts) Panics(provider Provider, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	assertName := "Panics"
+	panicValue := allure.NewParameter("Panic Value", noPanic)
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.Panics(t, recordPanic(f, panicValue), msgAndArgs...) },
+		[]*allure.Parameter{panicValue},
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// NotPanics ...
+func (a *asserts) NotPanics(provider Provider, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	assertName := "Not Panics"
+	panicValue := allure.NewParameter("Panic Value", noPanic)
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.NotPanics(t, recordPanic(f, panicValue), msgAndArgs...) },
+		[]*allure.Parameter{panicValue},
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// PanicsWithError ...
+func (a *asserts) PanicsWithError(provider Provider, errString string, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	assertName := "Panics With Error"
+	panicValue := allure.NewParameter("Panic Value", noPanic)
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool {
+			return assert.PanicsWithError(t, errString, recordPanic(f, panicValue), msgAndArgs...)
+		},
+		[]*allure.Parameter{allure.NewParameter("Expected Error", errString), panicValue},
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// ErrorContains ...
+func (a *asserts) ErrorContains(provider Provider, theError error, contains string, msgAndArgs ...interface{}) {
+	var (
+		actualString string
+
+		assertName = "Error Contains"
+	)
+
+	if theError != nil {
+		actualString = theError.Error()
+	}
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.ErrorContains(t, theError, contains, msgAndArgs...) },
+		allure.NewParameters("Actual", actualString, "Should Contain", contains),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// FileExists ...
+func (a *asserts) FileExists(provider Provider, path string, msgAndArgs ...interface{}) {
+	assertName := "File Exists"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.FileExists(t, path, msgAndArgs...) },
+		allure.NewParameters("Path", path),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// NoFileExists ...
+func (a *asserts) NoFileExists(provider Provider, path string, msgAndArgs ...interface{}) {
+	assertName := "No File Exists"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.NoFileExists(t, path, msgAndArgs...) },
+		allure.NewParameters("Path", path),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// NotRegexp ...
+func (a *asserts) NotRegexp(provider Provider, rx interface{}, str interface{}, msgAndArgs ...interface{}) {
+	assertName := "Not Regexp"
+	expString, actString := formatUnequalValues(rx, str)
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.NotRegexp(t, rx, str, msgAndArgs...) },
+		allure.NewParameters("Expected", expString, "Actual", actString),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// InEpsilon ...
+func (a *asserts) InEpsilon(provider Provider, expected, actual interface{}, epsilon float64, msgAndArgs ...interface{}) {
+	assertName := "In Epsilon"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.InEpsilon(t, expected, actual, epsilon, msgAndArgs...) },
+		allure.NewParameters("Expected", expected, "Actual", actual, "Epsilon", epsilon),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// InDeltaSlice ...
+func (a *asserts) InDeltaSlice(provider Provider, expected, actual interface{}, delta float64, msgAndArgs ...interface{}) {
+	assertName := "In Delta Slice"
+	expString, actString := formatUnequalValues(expected, actual)
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.InDeltaSlice(t, expected, actual, delta, msgAndArgs...) },
+		allure.NewParameters("Expected", expString, "Actual", actString, "Delta", delta),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// YAMLEq ...
+func (a *asserts) YAMLEq(provider Provider, expected, actual string, msgAndArgs ...interface{}) {
+	assertName := "YAML Equal"
+	success := a.resultHelper.WithNewComparisonStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.YAMLEq(t, expected, actual, msgAndArgs...) },
+		newComparison("Expected", "Actual", expected, actual),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// NotElementsMatch ...
+func (a *asserts) NotElementsMatch(provider Provider, listA interface{}, listB interface{}, msgAndArgs ...interface{}) {
+	assertName := "Not Elements Match"
+	listAString, listBString := formatUnequalValues(listA, listB)
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.NotElementsMatch(t, listA, listB, msgAndArgs...) },
+		allure.NewParameters("ListA", listAString, "ListB", listBString),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// Never ...
+func (a *asserts) Never(provider Provider, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	assertName := "Never"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.Never(t, condition, waitFor, tick, msgAndArgs...) },
+		allure.NewParameters("WaitFor", waitFor, "Tick", tick),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// EventuallyWithT ...
+func (a *asserts) EventuallyWithT(provider Provider, condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	assertName := "Eventually With T"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.EventuallyWithT(t, condition, waitFor, tick, msgAndArgs...) },
+		allure.NewParameters("WaitFor", waitFor, "Tick", tick),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// Positive ...
+func (a *asserts) Positive(provider Provider, e interface{}, msgAndArgs ...interface{}) {
+	assertName := "Positive"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.Positive(t, e, msgAndArgs...) },
+		allure.NewParameters("Actual", e),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// Negative ...
+func (a *asserts) Negative(provider Provider, e interface{}, msgAndArgs ...interface{}) {
+	assertName := "Negative"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.Negative(t, e, msgAndArgs...) },
+		allure.NewParameters("Actual", e),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// IsIncreasing ...
+func (a *asserts) IsIncreasing(provider Provider, object interface{}, msgAndArgs ...interface{}) {
+	assertName := "Is Increasing"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.IsIncreasing(t, object, msgAndArgs...) },
+		allure.NewParameters("Actual", truncatingFormat(object)),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// IsDecreasing ...
+func (a *asserts) IsDecreasing(provider Provider, object interface{}, msgAndArgs ...interface{}) {
+	assertName := "Is Decreasing"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool { return assert.IsDecreasing(t, object, msgAndArgs...) },
+		allure.NewParameters("Actual", truncatingFormat(object)),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// HTTPStatusCode ...
+func (a *asserts) HTTPStatusCode(provider Provider, handler http.HandlerFunc, method, url string, values url.Values, statuscode int, msgAndArgs ...interface{}) {
+	assertName := "HTTP Status Code"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool {
+			return assert.HTTPStatusCode(t, handler, method, url, values, statuscode, msgAndArgs...)
+		},
+		allure.NewParameters("Method", method, "URL", url, "Values", values.Encode(), "Expected Status Code", statuscode),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// HTTPBodyContains ...
+func (a *asserts) HTTPBodyContains(provider Provider, handler http.HandlerFunc, method, url string, values url.Values, str interface{}, msgAndArgs ...interface{}) {
+	assertName := "HTTP Body Contains"
+	success := a.resultHelper.WithNewStep(
+		a.t,
+		provider,
+		assertName,
+		func(t TestingT) bool {
+			return assert.HTTPBodyContains(t, handler, method, url, values, str, msgAndArgs...)
+		},
+		allure.NewParameters("Method", method, "URL", url, "Values", values.Encode(), "Should Contain", fmt.Sprint(str)),
+		msgAndArgs...,
+	)
+	if !success && a.required {
+		a.t.FailNow()
+	}
+}
+
+// noPanic is the value of "Panic Value" parameter, if the function didn't panic
+const noPanic = "none"
+
+// recordPanic wraps f to set value of its panic to the parameter
+func recordPanic(f assert.PanicTestFunc, param *allure.Parameter) assert.PanicTestFunc {
+	return func() {
+		defer func() {
+			if r := recover(); r != nil {
+				param.Value = fmt.Sprintf("%v", r)
+				panic(r)
+			}
+		}()
+		f()
+	}
+}
+
 // formatUnequalValues takes two values of arbitrary types and returns string
 // representations appropriate to be presented to the user.
 //
@@ -789,8 +1129,32 @@ func formatUnequalValues(expected, actual interface{}) (e string, a string) {
 // This helps keep formatted error messages lines from exceeding the
 // bufio.MaxScanTokenSize max line length that the go testing framework imposes.
 func truncatingFormat(data interface{}) string {
-	value := fmt.Sprintf("%#v", data)
-	max := bufio.MaxScanTokenSize - 100 // Give us some space the type info too if needed.
+	return truncate(fmt.Sprintf("%#v", data), 0)
+}
+
+// FormatValue formats value for parameters and messages of asserts: as go syntax (strings and errors are quoted),
+// durations and times as they are printed. Values longer than MaxLength of DiffThresholds are truncated
+func FormatValue(value interface{}) string {
+	var formatted string
+	switch v := value.(type) {
+	case error:
+		formatted = fmt.Sprintf("%q", v.Error())
+	case time.Duration, time.Time:
+		formatted = fmt.Sprintf("%v", v)
+	default:
+		formatted = fmt.Sprintf("%#v", v)
+	}
+
+	return truncate(formatted, GetDiffThresholds().MaxLength)
+}
+
+// truncate cuts value to max bytes. Zero or too big max is replaced by the limit of go testing line length
+func truncate(value string, max int) string {
+	limit := bufio.MaxScanTokenSize - 100 // Give us some space the type info too if needed.
+	if max <= 0 || max > limit {
+		max = limit
+	}
+
 	if len(value) > max {
 		value = value[0:max] + "<... truncated>"
 	}
@@ -2,12 +2,15 @@ package wrapper
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ozontech/allure-go/pkg/allure"
@@ -3812,3 +3815,329 @@ func TestRequireEventually_Fail(t *testing.T) {
 	require.True(t, mockT.failNow)
 	require.Equal(t, "\n%s", mockT.errorFString)
 }
+
+func TestAssertPanics_Success(t *testing.T) {
+	mockT := newMock()
+	NewAsserts(mockT).Panics(mockT, func() { panic("whoops") })
+
+	steps := mockT.steps
+	require.Len(t, steps, 1)
+	require.Equal(t, "ASSERT: Panics", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+
+	params := steps[0].Parameters
+	require.Len(t, params, 1)
+	require.Equal(t, "Panic Value", params[0].Name)
+	require.Equal(t, "whoops", params[0].GetValue())
+
+	require.False(t, mockT.errorF)
+	require.False(t, mockT.failNow)
+}
+
+func TestAssertPanics_Fail(t *testing.T) {
+	mockT := newMock()
+	NewAsserts(mockT).Panics(mockT, func() {})
+
+	steps := mockT.steps
+	require.Len(t, steps, 1)
+	require.Equal(t, allure.Failed, steps[0].Status)
+	require.Equal(t, "none", steps[0].Parameters[0].GetValue())
+
+	require.True(t, mockT.errorF)
+	require.False(t, mockT.failNow)
+}
+
+func TestRequireNotPanics_Fail(t *testing.T) {
+	mockT := newMock()
+	NewRequire(mockT).NotPanics(mockT, func() { panic("whoops") })
+
+	steps := mockT.steps
+	require.Len(t, steps, 1)
+	require.Equal(t, "REQUIRE: Not Panics", steps[0].Name)
+	require.Equal(t, allure.Failed, steps[0].Status)
+	require.Equal(t, "Panic Value", steps[0].Parameters[0].Name)
+	require.Equal(t, "whoops", steps[0].Parameters[0].GetValue())
+
+	require.True(t, mockT.errorF)
+	require.True(t, mockT.failNow)
+}
+
+func TestAssertPanicsWithError(t *testing.T) {
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.PanicsWithError(mockT, "whoops", func() { panic(errors.New("whoops")) })
+	a.PanicsWithError(mockT, "whoops", func() { panic(errors.New("oops")) })
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: Panics With Error", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, allure.Failed, steps[1].Status)
+
+	params := steps[1].Parameters
+	require.Len(t, params, 2)
+	require.Equal(t, "Expected Error", params[0].Name)
+	require.Equal(t, "whoops", params[0].GetValue())
+	require.Equal(t, "Panic Value", params[1].Name)
+	require.Equal(t, "oops", params[1].GetValue())
+}
+
+func TestAssertErrorContains(t *testing.T) {
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.ErrorContains(mockT, errors.New("connection refused"), "refused")
+	a.ErrorContains(mockT, errors.New("connection refused"), "timeout")
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: Error Contains", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, allure.Failed, steps[1].Status)
+
+	params := steps[1].Parameters
+	require.Len(t, params, 2)
+	require.Equal(t, "Actual", params[0].Name)
+	require.Equal(t, "connection refused", params[0].GetValue())
+	require.Equal(t, "Should Contain", params[1].Name)
+	require.Equal(t, "timeout", params[1].GetValue())
+	require.True(t, mockT.errorF)
+}
+
+func TestAssertFileExists(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "file")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.FileExists(mockT, file.Name())
+	a.NoFileExists(mockT, file.Name())
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: File Exists", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, "Path", steps[0].Parameters[0].Name)
+	require.Equal(t, file.Name(), steps[0].Parameters[0].GetValue())
+
+	require.Equal(t, "ASSERT: No File Exists", steps[1].Name)
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.True(t, mockT.errorF)
+}
+
+func TestAssertNotRegexp(t *testing.T) {
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.NotRegexp(mockT, "^[0-9]+$", "abc")
+	a.NotRegexp(mockT, "^[0-9]+$", "123")
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: Not Regexp", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.Equal(t, "Expected", steps[1].Parameters[0].Name)
+	require.Equal(t, "Actual", steps[1].Parameters[1].Name)
+}
+
+func TestAssertInEpsilon(t *testing.T) {
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.InEpsilon(mockT, 100, 101, 0.05)
+	a.InEpsilon(mockT, 100, 110, 0.05)
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: In Epsilon", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, allure.Failed, steps[1].Status)
+
+	params := steps[1].Parameters
+	require.Len(t, params, 3)
+	require.Equal(t, "Expected", params[0].Name)
+	require.Equal(t, "100", params[0].GetValue())
+	require.Equal(t, "Actual", params[1].Name)
+	require.Equal(t, "110", params[1].GetValue())
+	require.Equal(t, "Epsilon", params[2].Name)
+	require.Equal(t, "0.05", params[2].GetValue())
+}
+
+func TestAssertInDeltaSlice(t *testing.T) {
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.InDeltaSlice(mockT, []float64{1, 2}, []float64{1.05, 2}, 0.1)
+	a.InDeltaSlice(mockT, []float64{1, 2}, []float64{1.5, 2}, 0.1)
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: In Delta Slice", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.Len(t, steps[1].Parameters, 3)
+	require.Equal(t, "Delta", steps[1].Parameters[2].Name)
+	require.Equal(t, "0.1", steps[1].Parameters[2].GetValue())
+}
+
+func TestAssertYAMLEq(t *testing.T) {
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.YAMLEq(mockT, "a: 1\nb: 2\n", "b: 2\na: 1\n")
+	a.YAMLEq(mockT, "a: 1\nb: 2\n", "a: 1\nb: 3\n")
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: YAML Equal", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Empty(t, steps[0].Attachments)
+
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.Equal(t, "Expected", steps[1].Parameters[0].Name)
+	require.Equal(t, "a: 1\nb: 2\n", steps[1].Parameters[0].GetValue())
+	require.Len(t, steps[1].Attachments, 3)
+}
+
+func TestAssertNotElementsMatch(t *testing.T) {
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.NotElementsMatch(mockT, []int{1, 2}, []int{2, 3})
+	a.NotElementsMatch(mockT, []int{1, 2}, []int{2, 1})
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: Not Elements Match", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.Equal(t, "ListA", steps[1].Parameters[0].Name)
+	require.Equal(t, fmt.Sprintf("%#v", []int{1, 2}), steps[1].Parameters[0].GetValue())
+	require.Equal(t, "ListB", steps[1].Parameters[1].Name)
+}
+
+func TestAssertNever(t *testing.T) {
+	var (
+		mockT   = newMock()
+		a       = NewAsserts(mockT)
+		waitFor = 30 * time.Millisecond
+		tick    = 5 * time.Millisecond
+	)
+	a.Never(mockT, func() bool { return false }, waitFor, tick)
+	a.Never(mockT, func() bool { return true }, waitFor, tick)
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: Never", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, allure.Failed, steps[1].Status)
+
+	params := steps[1].Parameters
+	require.Len(t, params, 2)
+	require.Equal(t, "WaitFor", params[0].Name)
+	require.Equal(t, fmt.Sprintf("%v", waitFor), params[0].GetValue())
+	require.Equal(t, "Tick", params[1].Name)
+	require.Equal(t, fmt.Sprintf("%v", tick), params[1].GetValue())
+}
+
+func TestAssertEventuallyWithT(t *testing.T) {
+	var (
+		mockT   = newMock()
+		a       = NewAsserts(mockT)
+		counter int32
+		waitFor = time.Second
+		tick    = 5 * time.Millisecond
+	)
+	a.EventuallyWithT(mockT, func(c *assert.CollectT) {
+		assert.GreaterOrEqual(c, atomic.AddInt32(&counter, 1), int32(3))
+	}, waitFor, tick)
+	a.EventuallyWithT(mockT, func(c *assert.CollectT) {
+		assert.Fail(c, "never")
+	}, 30*time.Millisecond, tick)
+
+	steps := mockT.steps
+	require.Len(t, steps, 2)
+	require.Equal(t, "ASSERT: Eventually With T", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, "WaitFor", steps[0].Parameters[0].Name)
+	require.Equal(t, fmt.Sprintf("%v", waitFor), steps[0].Parameters[0].GetValue())
+	require.Equal(t, allure.Failed, steps[1].Status)
+	require.True(t, mockT.errorF)
+}
+
+func TestAssertPositiveNegative(t *testing.T) {
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.Positive(mockT, 1)
+	a.Positive(mockT, -1)
+	a.Negative(mockT, -1.5)
+	a.Negative(mockT, 0)
+
+	steps := mockT.steps
+	require.Len(t, steps, 4)
+	require.Equal(t, "ASSERT: Positive", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, "Actual", steps[0].Parameters[0].Name)
+	require.Equal(t, "1", steps[0].Parameters[0].GetValue())
+	require.Equal(t, allure.Failed, steps[1].Status)
+
+	require.Equal(t, "ASSERT: Negative", steps[2].Name)
+	require.Equal(t, allure.Passed, steps[2].Status)
+	require.Equal(t, "-1.5", steps[2].Parameters[0].GetValue())
+	require.Equal(t, allure.Failed, steps[3].Status)
+}
+
+func TestAssertIsIncreasingDecreasing(t *testing.T) {
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.IsIncreasing(mockT, []int{1, 2, 3})
+	a.IsIncreasing(mockT, []int{1, 3, 2})
+	a.IsDecreasing(mockT, []int{3, 2, 1})
+	a.IsDecreasing(mockT, []int{1, 2})
+
+	steps := mockT.steps
+	require.Len(t, steps, 4)
+	require.Equal(t, "ASSERT: Is Increasing", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, "Actual", steps[0].Parameters[0].Name)
+	require.Equal(t, "[]int{1, 2, 3}", steps[0].Parameters[0].GetValue())
+	require.Equal(t, allure.Failed, steps[1].Status)
+
+	require.Equal(t, "ASSERT: Is Decreasing", steps[2].Name)
+	require.Equal(t, allure.Passed, steps[2].Status)
+	require.Equal(t, allure.Failed, steps[3].Status)
+}
+
+func TestAssertHTTP(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, "hello, %s", r.URL.Query().Get("name"))
+	}
+	values := url.Values{"name": []string{"alice"}}
+
+	mockT := newMock()
+	a := NewAsserts(mockT)
+	a.HTTPStatusCode(mockT, handler, http.MethodGet, "/hello", values, http.StatusCreated)
+	a.HTTPStatusCode(mockT, handler, http.MethodGet, "/hello", values, http.StatusOK)
+	a.HTTPBodyContains(mockT, handler, http.MethodGet, "/hello", values, "hello, alice")
+	a.HTTPBodyContains(mockT, handler, http.MethodGet, "/hello", values, "hello, bob")
+
+	steps := mockT.steps
+	require.Len(t, steps, 4)
+	require.Equal(t, "ASSERT: HTTP Status Code", steps[0].Name)
+	require.Equal(t, allure.Passed, steps[0].Status)
+	require.Equal(t, allure.Failed, steps[1].Status)
+
+	params := steps[1].Parameters
+	require.Len(t, params, 4)
+	require.Equal(t, "Method", params[0].Name)
+	require.Equal(t, "GET", params[0].GetValue())
+	require.Equal(t, "URL", params[1].Name)
+	require.Equal(t, "/hello", params[1].GetValue())
+	require.Equal(t, "Values", params[2].Name)
+	require.Equal(t, "name=alice", params[2].GetValue())
+	require.Equal(t, "Expected Status Code", params[3].Name)
+	require.Equal(t, "200", params[3].GetValue())
+
+	require.Equal(t, "ASSERT: HTTP Body Contains", steps[2].Name)
+	require.Equal(t, allure.Passed, steps[2].Status)
+	require.Equal(t, allure.Failed, steps[3].Status)
+	require.Equal(t, "Should Contain", steps[3].Parameters[3].Name)
+	require.Equal(t, "hello, bob", steps[3].Parameters[3].GetValue())
+}
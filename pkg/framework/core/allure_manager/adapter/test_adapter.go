@@ -52,6 +52,14 @@ func NewTestMeta(suiteFullName, suiteName, testName, packageName string, tags ..
 	return &TestAdapter{result: result, container: container}
 }
 
+// NewTestMetaWithResult returns pointer to instance of TestAdapter with passed result and new container
+func NewTestMetaWithResult(result *allure.Result) *TestAdapter {
+	container := allure.NewContainer()
+	container.AddChild(result.UUID)
+
+	return &TestAdapter{result: result, container: container}
+}
+
 // GetResult returns allure.Result pointer
 func (ctx *TestAdapter) GetResult() *allure.Result {
 	return ctx.result
@@ -38,6 +38,16 @@ func TestNewTestMeta(t *testing.T) {
 
 }
 
+func TestNewTestMetaWithResult(t *testing.T) {
+	result := allure.NewResult("testName", "fullName")
+
+	adapter := NewTestMetaWithResult(result)
+	require.Equal(t, result, adapter.GetResult())
+	require.NotNil(t, adapter.GetContainer())
+	require.Len(t, adapter.GetContainer().Children, 1)
+	require.Equal(t, result.UUID, adapter.GetContainer().Children[0])
+}
+
 func TestTestAdapter_GetResult(t *testing.T) {
 	test := &allure.Result{}
 	adapter := TestAdapter{result: test}
@@ -15,3 +15,10 @@ func (a *allureManager) WithNewParameters(kv ...interface{}) {
 		r.Parameters = append(r.Parameters, allure.NewParameters(kv...)...)
 	})
 }
+
+// WithNewMaskedParameters adds masked parameters to report in case of current execution context
+func (a *allureManager) WithNewMaskedParameters(kv ...interface{}) {
+	a.withResult(func(r *allure.Result) {
+		r.Parameters = append(r.Parameters, allure.NewMaskedParameters(kv...)...)
+	})
+}
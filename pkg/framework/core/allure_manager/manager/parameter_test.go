@@ -60,3 +60,12 @@ func TestAllureManager_NewParameter(t *testing.T) {
 	require.Equal(t, "os", manager.GetResult().Parameters[1].Name)
 	require.Equal(t, "linux", manager.GetResult().Parameters[1].GetValue())
 }
+
+func TestAllureManager_NewMaskedParameter(t *testing.T) {
+	manager := allureManager{testMeta: &testMetaMockParameter{result: &allure.Result{}}}
+	manager.WithNewMaskedParameters("token", "secret")
+	require.Len(t, manager.GetResult().Parameters, 1)
+	require.Equal(t, "token", manager.GetResult().Parameters[0].Name)
+	require.Equal(t, "secret", manager.GetResult().Parameters[0].GetValue())
+	require.Equal(t, allure.ParameterModeMasked, manager.GetResult().Parameters[0].Mode)
+}
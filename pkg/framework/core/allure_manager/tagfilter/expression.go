@@ -0,0 +1,326 @@
+package tagfilter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// Expression is a parsed boolean expression over test labels.
+//
+// Grammar:
+//
+//	expr    = and { "||" and }
+//	and     = unary { "&&" unary }
+//	unary   = "!" unary | primary
+//	primary = "(" expr ")" | term
+//	term    = value [ ("=" | "!=") value ]
+//
+// Bare value matches `tag` label (e.g. `smoke`), `name=value` matches label with the name
+// (e.g. `owner=alice`, `severity=critical`, `ALLURE_ID=42`). Label names are case-insensitive.
+// Values containing spaces or operators can be quoted: `feature="Sign in"`.
+type Expression struct {
+	raw  string
+	root node
+}
+
+// Parse parses tag expression
+func Parse(expr string) (*Expression, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.peek().value, p.peek().pos)
+	}
+
+	return &Expression{raw: expr, root: root}, nil
+}
+
+// MustParse parses tag expression and panics on error
+func MustParse(expr string) *Expression {
+	e, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns source of the expression
+func (e *Expression) String() string {
+	return e.raw
+}
+
+// Match returns true if labels satisfy the expression
+func (e *Expression) Match(labels []*allure.Label) bool {
+	return e.root.eval(labels)
+}
+
+// IsSelected returns true if labels of the result satisfy the expression
+func (e *Expression) IsSelected(result *allure.Result) bool {
+	if result == nil {
+		return false
+	}
+
+	return e.Match(result.Labels)
+}
+
+type node interface {
+	eval(labels []*allure.Label) bool
+}
+
+type orNode struct{ left, right node }
+
+func (n *orNode) eval(labels []*allure.Label) bool { return n.left.eval(labels) || n.right.eval(labels) }
+
+type andNode struct{ left, right node }
+
+func (n *andNode) eval(labels []*allure.Label) bool { return n.left.eval(labels) && n.right.eval(labels) }
+
+type notNode struct{ operand node }
+
+func (n *notNode) eval(labels []*allure.Label) bool { return !n.operand.eval(labels) }
+
+type labelNode struct {
+	name  string
+	value string
+}
+
+func (n *labelNode) eval(labels []*allure.Label) bool {
+	for _, label := range labels {
+		if label != nil && strings.EqualFold(label.Name, n.name) && label.GetValue() == n.value {
+			return true
+		}
+	}
+
+	return false
+}
+
+type tokenKind int
+
+const (
+	tokenValue tokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenEq
+	tokenNotEq
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func tokenize(expr string) ([]token, error) {
+	var (
+		tokens []token
+		runes  = []rune(expr)
+	)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: i})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: i})
+			i++
+
+		case r == '&' || r == '|':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, fmt.Errorf("unexpected %q at position %d, did you mean %q", r, i, string([]rune{r, r}))
+			}
+
+			kind := tokenAnd
+			if r == '|' {
+				kind = tokenOr
+			}
+			tokens = append(tokens, token{kind: kind, value: string([]rune{r, r}), pos: i})
+			i += 2
+
+		case r == '!':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{kind: tokenNotEq, value: "!=", pos: i})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{kind: tokenNot, value: "!", pos: i})
+			i++
+
+		case r == '=':
+			tokens = append(tokens, token{kind: tokenEq, value: "=", pos: i})
+			i++
+
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated quoted value at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenValue, value: string(runes[i+1 : end]), pos: i})
+			i = end + 1
+
+		default:
+			start := i
+			for i < len(runes) && !isDelimiter(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenValue, value: string(runes[start:i]), pos: start})
+		}
+	}
+
+	return tokens, nil
+}
+
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("()&|!=\"'", r)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) accept(kind tokenKind) bool {
+	if !p.done() && p.peek().kind == kind {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokenOr) {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokenAnd) {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept(tokenNot) {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	if p.accept(tokenLParen) {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.accept(tokenRParen) {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+
+		return expr, nil
+	}
+
+	tok := p.peek()
+	if tok.kind != tokenValue {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.value, tok.pos)
+	}
+	p.pos++
+
+	switch {
+	case p.accept(tokenEq):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		return &labelNode{name: tok.value, value: value}, nil
+
+	case p.accept(tokenNotEq):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{operand: &labelNode{name: tok.value, value: value}}, nil
+	}
+
+	return &labelNode{name: string(allure.Tag), value: tok.value}, nil
+}
+
+func (p *parser) parseValue() (string, error) {
+	if p.done() {
+		return "", fmt.Errorf("unexpected end of expression, label value expected")
+	}
+
+	tok := p.peek()
+	if tok.kind != tokenValue {
+		return "", fmt.Errorf("unexpected %q at position %d, label value expected", tok.value, tok.pos)
+	}
+	p.pos++
+
+	return tok.value, nil
+}
@@ -0,0 +1,90 @@
+package tagfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+func TestExpression_Match(t *testing.T) {
+	labels := []*allure.Label{
+		allure.TagLabel("smoke"),
+		allure.TagLabel("api"),
+		allure.OwnerLabel("alice"),
+		allure.SeverityLabel(allure.CRITICAL),
+		allure.EpicLabel("Payments"),
+		allure.FeatureLabel("Sign in"),
+		allure.IDAllureLabel("42"),
+		allure.NewLabel("layer", "e2e"),
+	}
+
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{"smoke", true},
+		{"slow", false},
+		{"!slow", true},
+		{"smoke && !slow", true},
+		{"smoke && slow", false},
+		{"slow || smoke", true},
+		{"smoke && slow || owner=alice", true},
+		{"smoke && slow || owner=bob", false},
+		{"smoke && (slow || owner=alice)", true},
+		{"!(smoke && api)", false},
+		{"!!smoke", true},
+		{"tag=api", true},
+		{"owner!=alice", false},
+		{"owner != bob", true},
+		{"severity=critical", true},
+		{"epic=Payments && feature=\"Sign in\"", true},
+		{"feature='Sign in'", true},
+		{"ALLURE_ID=42", true},
+		{"allure_id=42", true},
+		{"layer=e2e && !layer=unit", true},
+	}
+
+	for _, test := range tests {
+		expr, err := Parse(test.expr)
+		require.NoError(t, err, test.expr)
+		require.Equal(t, test.expected, expr.Match(labels), test.expr)
+		require.Equal(t, test.expr, expr.String())
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"smoke &",
+		"smoke |",
+		"smoke &&",
+		"(smoke",
+		"smoke)",
+		"owner=",
+		"owner=&&",
+		"feature=\"Sign in",
+		"smoke slow",
+		"&& smoke",
+	} {
+		_, err := Parse(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	require.NotNil(t, MustParse("smoke"))
+	require.Panics(t, func() { MustParse("(smoke") })
+}
+
+func TestExpression_IsSelected(t *testing.T) {
+	expr := MustParse("smoke")
+
+	result := allure.NewResult("test", "fullName")
+	require.False(t, expr.IsSelected(result))
+	require.False(t, expr.IsSelected(nil))
+
+	result.AddLabel(allure.TagLabel("smoke"))
+	require.True(t, expr.IsSelected(result))
+}
@@ -0,0 +1,74 @@
+package tagfilter
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// Tag expression to filter tests by labels
+const tagsEnvKey = "ALLURE_TAGS"
+
+var tagsFlag = flag.String("allure-go.tags", "", "tag expression to select tests by labels, e.g. `smoke && !slow || owner=alice` (overrides "+tagsEnvKey+")")
+
+var (
+	once      sync.Once
+	mu        sync.RWMutex
+	tagFilter *Expression
+)
+
+// GetTagFilter returns tag expression set by -allure-go.tags flag or ALLURE_TAGS environment variable.
+// Returns nil if nothing was set. Exits the test binary if the expression is invalid.
+func GetTagFilter() *Expression {
+	once.Do(func() {
+		raw := os.Getenv(tagsEnvKey)
+		if tagsFlag != nil && *tagsFlag != "" {
+			raw = *tagsFlag
+		}
+
+		if raw == "" {
+			return
+		}
+
+		expr, err := Parse(raw)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "allure-go: invalid tag expression %q: %s\n", raw, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Tag expression found: %s. It will be used for test filters\n", raw)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		tagFilter = expr
+	})
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return tagFilter
+}
+
+// SetTagFilter overrides tag expression set by -allure-go.tags flag or ALLURE_TAGS environment variable.
+// Passing nil disables tag filtering.
+func SetTagFilter(expr *Expression) {
+	once.Do(func() {})
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	tagFilter = expr
+}
+
+// IsSelected returns true if the result satisfies tag expression or tag expression was not set
+func IsSelected(result *allure.Result) bool {
+	if filter := GetTagFilter(); filter != nil {
+		return filter.IsSelected(result)
+	}
+
+	return true
+}
@@ -0,0 +1,26 @@
+package tagfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+func TestSetTagFilter(t *testing.T) {
+	defer SetTagFilter(nil)
+
+	result := allure.NewResult("test", "fullName")
+
+	SetTagFilter(nil)
+	require.Nil(t, GetTagFilter())
+	require.True(t, IsSelected(result))
+
+	SetTagFilter(MustParse("smoke"))
+	require.Equal(t, "smoke", GetTagFilter().String())
+	require.False(t, IsSelected(result))
+
+	result.AddLabel(allure.TagLabel("smoke"))
+	require.True(t, IsSelected(result))
+}
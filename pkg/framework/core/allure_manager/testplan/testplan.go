@@ -1,13 +1,17 @@
 package testplan
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 
 	"github.com/bytedance/sonic"
+
+	"github.com/ozontech/allure-go/pkg/allure"
 )
 
 var (
@@ -18,9 +22,31 @@ var (
 // Path to testplan.json
 const testPlanPath = "ALLURE_TESTPLAN_PATH"
 
+// ID is an Allure ID of the test case. TestOps writes it either as a number or as a string
+type ID string
+
+// UnmarshalJSON accepts both numeric and string IDs
+func (id *ID) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := sonic.Unmarshal(data, &str); err == nil {
+		*id = ID(str)
+		return nil
+	}
+
+	var num json.Number
+	if err := sonic.Unmarshal(data, &num); err != nil {
+		return fmt.Errorf("wrong test case id %s: %w", data, err)
+	}
+	*id = ID(num.String())
+
+	return nil
+}
+
 type TestCase struct {
-	ID       int    `json:"id"`
+	ID       ID     `json:"id"`
 	Selector string `json:"selector"`
+
+	matcher func(selector string) bool
 }
 
 type TestPlan struct {
@@ -54,14 +80,73 @@ func newTestPlan() (*TestPlan, error) {
 		return nil, fmt.Errorf("no any tests found in %s", filePath)
 	}
 
+	if err = plan.compile(); err != nil {
+		return nil, fmt.Errorf("wrong selector in %s: %w", filePath, err)
+	}
+
 	return &plan, nil
 }
 
-// IsSelected returns true if selector matches with testplan selector
-// TODO: ID parsing from TestOps
+// compile prepares glob and regex selectors of the plan
+func (p *TestPlan) compile() error {
+	for _, t := range p.Tests {
+		if err := t.compile(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compile prepares selector matcher:
+//   - `/pattern/` is a regular expression;
+//   - selector with `*` or `?` is a glob (`*` matches any sequence of characters, including `/`);
+//   - any other selector is matched as is.
+func (c *TestCase) compile() error {
+	selector := c.Selector
+
+	switch {
+	case len(selector) > 2 && strings.HasPrefix(selector, "/") && strings.HasSuffix(selector, "/"):
+		re, err := regexp.Compile(selector[1 : len(selector)-1])
+		if err != nil {
+			return err
+		}
+		c.matcher = re.MatchString
+
+	case strings.ContainsAny(selector, "*?"):
+		re := regexp.MustCompile(globToRegexp(selector))
+		c.matcher = re.MatchString
+
+	default:
+		c.matcher = func(s string) bool { return s == selector }
+	}
+
+	return nil
+}
+
+// IsSelected returns true if the test case matches id (ALLURE_ID label value) or selector (test full name)
+func (c *TestCase) IsSelected(id, selector string) bool {
+	if id != "" && string(c.ID) == id {
+		return true
+	}
+
+	if c.Selector == "" {
+		return false
+	}
+
+	if c.matcher == nil {
+		if err := c.compile(); err != nil {
+			return false
+		}
+	}
+
+	return c.matcher(selector)
+}
+
+// IsSelected returns true if any test of the plan matches id (ALLURE_ID label value) or selector (test full name)
 func (p *TestPlan) IsSelected(id, selector string) bool {
 	for _, t := range p.Tests {
-		if t.Selector == selector {
+		if t.IsSelected(id, selector) {
 			return true
 		}
 	}
@@ -69,6 +154,39 @@ func (p *TestPlan) IsSelected(id, selector string) bool {
 	return false
 }
 
+// IsSelectedResult returns true if the plan selects the result by its ALLURE_ID label or full name
+func (p *TestPlan) IsSelectedResult(result *allure.Result) bool {
+	if result == nil {
+		return false
+	}
+
+	var id string
+	if label, ok := result.GetFirstLabel(allure.AllureID); ok {
+		id = label.GetValue()
+	}
+
+	return p.IsSelected(id, result.FullName)
+}
+
+func globToRegexp(glob string) string {
+	var b strings.Builder
+
+	b.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+
+	return b.String()
+}
+
 func initTestPlan() *TestPlan {
 	var (
 		err   error
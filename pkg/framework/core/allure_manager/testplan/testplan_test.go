@@ -0,0 +1,95 @@
+package testplan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+func TestID_UnmarshalJSON(t *testing.T) {
+	var plan TestPlan
+	err := sonic.Unmarshal([]byte(`{"version":"1.0","tests":[{"id":42},{"id":"43","selector":"TestA"}]}`), &plan)
+	require.NoError(t, err)
+	require.Len(t, plan.Tests, 2)
+	require.Equal(t, ID("42"), plan.Tests[0].ID)
+	require.Equal(t, ID("43"), plan.Tests[1].ID)
+	require.Equal(t, "TestA", plan.Tests[1].Selector)
+
+	err = sonic.Unmarshal([]byte(`{"tests":[{"id":{}}]}`), &plan)
+	require.Error(t, err)
+}
+
+func TestTestPlan_IsSelected(t *testing.T) {
+	plan := &TestPlan{Tests: []*TestCase{
+		{ID: "42"},
+		{Selector: "TestRunner/Suite/TestExact"},
+		{Selector: "TestRunner/Glob/*"},
+		{Selector: "TestRunner/Param/TableTestCity_?"},
+		{Selector: "/^TestRegexp/.*Login$/"},
+	}}
+	require.NoError(t, plan.compile())
+
+	tests := []struct {
+		id       string
+		selector string
+		expected bool
+	}{
+		{"42", "TestRunner/Other", true},
+		{"43", "TestRunner/Other", false},
+		{"", "TestRunner/Other", false},
+		{"", "TestRunner/Suite/TestExact", true},
+		{"", "TestRunner/Suite/TestExact2", false},
+		{"", "TestRunner/Glob/Suite/TestAny", true},
+		{"", "TestRunner/Glober/TestAny", false},
+		{"", "TestRunner/Param/TableTestCity_1", true},
+		{"", "TestRunner/Param/TableTestCity_10", false},
+		{"", "TestRegexp/Suite/TestLogin", true},
+		{"", "TestRegexp/Suite/TestLogout", false},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.expected, plan.IsSelected(test.id, test.selector), "%s %s", test.id, test.selector)
+	}
+}
+
+func TestTestPlan_compile_wrongRegexp(t *testing.T) {
+	plan := &TestPlan{Tests: []*TestCase{{Selector: "/(/"}}}
+	require.Error(t, plan.compile())
+}
+
+func TestTestPlan_IsSelectedResult(t *testing.T) {
+	plan := &TestPlan{Tests: []*TestCase{{ID: "42"}, {Selector: "TestRunner/Suite/TestA"}}}
+
+	byID := allure.NewResult("TestB", "TestRunner/Suite/TestB")
+	require.False(t, plan.IsSelectedResult(byID))
+	byID.AddLabel(allure.IDAllureLabel("42"))
+	require.True(t, plan.IsSelectedResult(byID))
+
+	// test case id hash is not an allure id
+	byHash := allure.NewResult("TestC", "TestRunner/Suite/TestC")
+	byHash.TestCaseID = "42"
+	require.False(t, plan.IsSelectedResult(byHash))
+
+	require.True(t, plan.IsSelectedResult(allure.NewResult("TestA", "TestRunner/Suite/TestA")))
+	require.False(t, plan.IsSelectedResult(nil))
+}
+
+func TestNewTestPlan(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "testplan.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":"1.0","tests":[{"id":1,"selector":"TestA/*"}]}`), 0o600))
+	t.Setenv(testPlanPath, path)
+
+	plan, err := newTestPlan()
+	require.NoError(t, err)
+	require.True(t, plan.IsSelected("", "TestA/TestB"))
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":"1.0","tests":[{"selector":"/(/"}]}`), 0o600))
+	_, err = newTestPlan()
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"runtime/debug"
@@ -10,9 +11,12 @@ import (
 
 	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/helper"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
 	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/manager"
+	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/tagfilter"
 	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/testplan"
 	"github.com/ozontech/allure-go/pkg/framework/core/constants"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 )
 
@@ -33,6 +37,14 @@ type Common struct {
 	tempDir    string
 	tempDirErr error
 	tempDirSeq int32
+
+	fixtureSuite *allure.Container
+
+	ctxMu  sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	listeners listener.Set
 }
 
 // NewT returns Common instance that implementing provider.T interface
@@ -58,15 +70,42 @@ func (c *Common) registerError(fullMessage string) {
 	if res != nil {
 		res.StatusDetails.Message = extractErrorMessages(fullMessage)
 		res.StatusDetails.Trace = fmt.Sprintf("%s\n%s", res.StatusDetails.Trace, fullMessage)
+		listener.NotifyStatus(c.Listener(), res)
 	}
 }
 
 func (c *Common) withResult(f func(result *allure.Result)) {
 	if r := c.GetResult(); r != nil {
 		f(r)
+		listener.NotifyStatus(c.Listener(), r)
 	}
 }
 
+// SetListeners sets listeners of the runner, which the test belongs to
+func (c *Common) SetListeners(listeners listener.Set) {
+	c.listeners = listeners
+}
+
+// Listener returns listeners of the test's events: global listeners and listeners of the runner
+func (c *Common) Listener() listener.Set {
+	return c.listeners
+}
+
+// WithAttachments adds attachments to the test
+func (c *Common) WithAttachments(attachments ...*allure.Attachment) {
+	c.Provider.WithAttachments(attachments...)
+	if l := c.Listener(); l.Active() {
+		for _, attachment := range attachments {
+			l.AttachmentAdded(listener.AttachmentEvent{Result: c.GetResult(), Attachment: attachment})
+		}
+	}
+}
+
+// WithNewAttachment creates new attachment and adds it to the test
+func (c *Common) WithNewAttachment(name string, mimeType allure.MimeType, content []byte) {
+	c.WithAttachments(allure.NewAttachment(name, mimeType, content))
+}
+
 func (c *Common) SetProvider(provider provider.Provider) {
 	c.Provider = provider
 }
@@ -91,6 +130,12 @@ func (c *Common) Require() provider.Asserts {
 	return c.require
 }
 
+// Expect returns expectation of actual value, which is asserted with matchers, see matcher.Matcher.
+// Use Require().Expect to stop the test, if the value doesn't match
+func (c *Common) Expect(actual interface{}) matcher.Expectation {
+	return c.assert.Expect(actual)
+}
+
 // XSkip marks current test as XSkip that means that in case of assert fail this test will be marked skipped
 func (c *Common) XSkip() {
 	c.xSkip = true
@@ -158,6 +203,7 @@ func (c *Common) Fatal(args ...interface{}) {
 
 	fullMessage := fmt.Sprintf("%s", args...)
 	c.registerError(fullMessage)
+	c.CancelContext()
 	c.TestingT.Fatal(args...)
 }
 
@@ -167,6 +213,7 @@ func (c *Common) Fatalf(format string, args ...interface{}) {
 
 	fullMessage := fmt.Sprintf(format, args...)
 	c.registerError(fullMessage)
+	c.CancelContext()
 	c.TestingT.Fatalf(format, args...)
 }
 
@@ -218,6 +265,7 @@ func (c *Common) FailNow() {
 			r.Status = allure.Failed
 		}
 	})
+	c.CancelContext()
 	c.TestingT.FailNow()
 }
 
@@ -235,6 +283,7 @@ func (c *Common) BrokenNow() {
 		r.Status = allure.Broken
 	})
 
+	c.CancelContext()
 	c.TestingT.FailNow()
 }
 
@@ -351,19 +400,33 @@ func (c *Common) Run(testName string, testBody func(provider.T), tags ...string)
 		newProvider := manager.NewProvider(providerCfg)
 
 		newProvider.NewTest(testName, packageName, tags...)
+		if !tagfilter.IsSelected(newProvider.GetTestMeta().GetResult()) {
+			realT.Skip("Test is not selected by tag expression")
+		}
 		if testPlan := testplan.GetTestPlan(); testPlan != nil {
-			if !testPlan.IsSelected(newProvider.GetTestMeta().GetResult().TestCaseID, newProvider.GetResult().FullName) {
+			if !testPlan.IsSelectedResult(newProvider.GetTestMeta().GetResult()) {
 				realT.Skip("Test is not Selected in Test Plan")
 			}
 		}
 		newProvider.TestContext()
 
 		testT.SetProvider(newProvider)
+		testT.SetFixtureSuite(c.fixtureSuiteContainer())
+		testT.SetListeners(c.listeners)
+		testT.SetContext(c.Context())
+		defer testT.CancelContext()
 
 		defer func() {
 			res = testT.GetResult()
 		}()
 
+		l := testT.Listener()
+		if l.Active() {
+			l.TestScheduled(listener.TestEvent{Result: testT.GetResult()})
+			l.TestStarted(listener.TestEvent{Result: testT.GetResult()})
+		}
+		defer listener.NotifyTestFinished(l, testT.GetResult())
+
 		// print test result
 		defer func() {
 			err := testT.FinishTest()
@@ -372,6 +435,8 @@ func (c *Common) Run(testName string, testBody func(provider.T), tags ...string)
 			}
 		}()
 
+		defer testT.TeardownFixtures(TestScope)
+
 		defer func() {
 			rec := recover()
 			// wait for all tests async steps over
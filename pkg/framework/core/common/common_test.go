@@ -12,6 +12,7 @@ import (
 
 	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/helper"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
 	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/manager"
 	"github.com/ozontech/allure-go/pkg/framework/core/constants"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
@@ -242,6 +243,16 @@ func TestCommon_Require(t *testing.T) {
 	require.Equal(t, asserts, comm.assert)
 }
 
+func TestCommon_Expect(t *testing.T) {
+	mockT := newCommonTMock()
+	comm := Common{assert: helper.NewAssertsHelper(mockT)}
+	comm.Expect(5).To(matcher.Equal(5))
+	require.Len(t, mockT.steps, 1)
+	require.Equal(t, "ASSERT: Expect to equal 5", mockT.steps[0].Name)
+	require.Equal(t, allure.Passed, mockT.steps[0].Status)
+	require.False(t, mockT.errorfFlag)
+}
+
 func TestCommon_Error(t *testing.T) {
 	mock := newCommonTMock()
 	comm := Common{TestingT: mock, Provider: newProviderMockCommon("name", "fullName")}
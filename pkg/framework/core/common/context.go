@@ -0,0 +1,114 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+var (
+	rootCtx, cancelRootCtx = context.WithCancel(context.Background())
+	interruptOnce          sync.Once
+)
+
+// rootContext returns context, which tests without parent context are derived from.
+// It is canceled on the first interrupt, if HandleInterrupt is called
+func rootContext() context.Context {
+	return rootCtx
+}
+
+// HandleInterrupt makes the first interrupt of the process cancel contexts of running tests,
+// so they can stop and write their results. The second interrupt stops the test binary as usual.
+// It's opt-in, because the handler replaces the default one for the whole process. Next calls do nothing
+func HandleInterrupt() {
+	interruptOnce.Do(func() {
+		interrupted := make(chan os.Signal, 1)
+		signal.Notify(interrupted, os.Interrupt)
+		go func() {
+			<-interrupted
+			signal.Stop(interrupted)
+			fmt.Fprintln(os.Stderr, "allure-go: interrupted, contexts of running tests are canceled (interrupt again to stop immediately)")
+			cancelRootCtx()
+		}()
+	})
+}
+
+// Context returns context of the test. Unless it is set with SetContext, it is derived from the root context,
+// which is canceled on interrupt (see HandleInterrupt). The context carries the test (see provider.StepsFromContext)
+func (c *Common) Context() context.Context {
+	c.ctxMu.Lock()
+	defer c.ctxMu.Unlock()
+
+	if c.ctx == nil {
+		c.setContext(rootContext())
+	}
+
+	return c.ctx
+}
+
+// SetContext sets new context of the test derived from parent. Previous context is canceled
+func (c *Common) SetContext(parent context.Context) {
+	c.ctxMu.Lock()
+	defer c.ctxMu.Unlock()
+
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.setContext(parent)
+}
+
+func (c *Common) setContext(parent context.Context) {
+	ctx, cancel := context.WithCancel(parent)
+	c.ctx, c.cancel = provider.ContextWithSteps(ctx, c), cancel
+}
+
+// CancelContext cancels context of the test
+func (c *Common) CancelContext() {
+	c.ctxMu.Lock()
+	defer c.ctxMu.Unlock()
+
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// Context returns context of the test, which carries the step (see provider.StepsFromContext)
+func (ctx *stepCtx) Context() context.Context {
+	ctx.ctxMu.Lock()
+	base := ctx.base
+	ctx.ctxMu.Unlock()
+
+	if base == nil {
+		base = ctx.parentContext()
+	}
+
+	return provider.ContextWithSteps(base, ctx)
+}
+
+func (ctx *stepCtx) parentContext() context.Context {
+	if ctx.parentStep != nil {
+		return ctx.parentStep.Context()
+	}
+
+	return ctx.t.Context()
+}
+
+// cancelableStep makes context of the step cancelable. Returns function that cancels it, when the step ends
+func cancelableStep(stCtx InternalStepCtx) context.CancelFunc {
+	sc, ok := stCtx.(*stepCtx)
+	if !ok {
+		return func() {}
+	}
+
+	base, cancel := context.WithCancel(sc.parentContext())
+
+	sc.ctxMu.Lock()
+	sc.base = base
+	sc.ctxMu.Unlock()
+
+	return cancel
+}
@@ -0,0 +1,148 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/constants"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+func newContextCommon() (*Common, *stepsStepsCommTMock) {
+	mockT := newStepsCommonTMock()
+	p := &providerMockstepsCommon{
+		testMetaMock:  &testMetaMockstepsCommon{result: &allure.Result{}},
+		suiteMetaMock: &suiteMetaMockstepsCommon{},
+		executionMock: newExecContextstepsCommMock(constants.TestContextName),
+	}
+
+	return &Common{TestingT: mockT, Provider: p}, mockT
+}
+
+func TestCommon_Context(t *testing.T) {
+	comm, _ := newContextCommon()
+
+	ctx := comm.Context()
+	require.NoError(t, ctx.Err())
+	require.Same(t, ctx, comm.Context())
+
+	steps, ok := provider.StepsFromContext(ctx)
+	require.True(t, ok)
+	require.Same(t, comm, steps)
+
+	comm.CancelContext()
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestCommon_SetContext(t *testing.T) {
+	type key struct{}
+
+	comm, _ := newContextCommon()
+	old := comm.Context()
+
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	comm.SetContext(parent)
+	require.ErrorIs(t, old.Err(), context.Canceled)
+
+	ctx := comm.Context()
+	require.Equal(t, "value", ctx.Value(key{}))
+	require.NoError(t, ctx.Err())
+
+	cancel()
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestCommon_Context_failNow(t *testing.T) {
+	comm, mockT := newContextCommon()
+	ctx := comm.Context()
+
+	comm.FailNow()
+	require.True(t, mockT.failNow)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestCommon_Context_brokenNow(t *testing.T) {
+	comm, mockT := newContextCommon()
+	ctx := comm.Context()
+
+	comm.BrokenNow()
+	require.True(t, mockT.failNow)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestStepCtx_Context(t *testing.T) {
+	comm, _ := newContextCommon()
+	testCtx := comm.Context()
+
+	var stepCtx, childCtx context.Context
+	comm.WithNewStep("step", func(sCtx provider.StepCtx) {
+		stepCtx = sCtx.Context()
+		steps, ok := provider.StepsFromContext(stepCtx)
+		require.True(t, ok)
+		require.Same(t, sCtx, steps)
+
+		sCtx.WithNewStep("child", func(sCtx provider.StepCtx) {
+			childCtx = sCtx.Context()
+			steps, ok := provider.StepsFromContext(childCtx)
+			require.True(t, ok)
+			require.Same(t, sCtx, steps)
+		})
+	})
+
+	// contexts of steps are canceled, when steps end
+	require.ErrorIs(t, stepCtx.Err(), context.Canceled)
+	require.ErrorIs(t, childCtx.Err(), context.Canceled)
+	require.NoError(t, testCtx.Err())
+
+	comm.CancelContext()
+	require.ErrorIs(t, testCtx.Err(), context.Canceled)
+}
+
+func TestStepCtx_Context_canceledWithTest(t *testing.T) {
+	comm, _ := newContextCommon()
+
+	comm.WithNewStep("step", func(sCtx provider.StepCtx) {
+		sCtx.WithNewStep("child", func(sCtx provider.StepCtx) {
+			ctx := sCtx.Context()
+			require.NoError(t, ctx.Err())
+			comm.CancelContext()
+			require.ErrorIs(t, ctx.Err(), context.Canceled)
+		})
+	})
+}
+
+func TestRootContext(t *testing.T) {
+	// without HandleInterrupt the root context is never canceled
+	require.NoError(t, rootContext().Err())
+}
+
+func TestCommon_WithNewStepTimeout_context(t *testing.T) {
+	comm, _ := newContextCommon()
+
+	canceled := make(chan error, 1)
+	comm.WithNewStepTimeout("step", 10*time.Millisecond, func(sCtx provider.StepCtx) {
+		<-sCtx.Context().Done()
+		canceled <- sCtx.Context().Err()
+	})
+
+	select {
+	case err := <-canceled:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		require.Fail(t, "context of the step is not canceled on timeout")
+	}
+	require.NoError(t, comm.Context().Err())
+}
+
+func TestStepsFromContext(t *testing.T) {
+	_, ok := provider.StepsFromContext(context.Background())
+	require.False(t, ok)
+
+	//nolint:staticcheck // nil context is checked explicitly
+	_, ok = provider.StepsFromContext(nil)
+	require.False(t, ok)
+}
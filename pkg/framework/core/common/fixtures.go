@@ -0,0 +1,532 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/ctx"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// FixtureScope is the lifetime of the fixture's value
+type FixtureScope int
+
+const (
+	// TestScope fixture is created for every test and torn down after its AfterEach hook
+	TestScope FixtureScope = iota
+	// SuiteScope fixture is shared by tests of the suite and torn down after its AfterAll hook
+	SuiteScope
+	// PackageScope fixture is shared by all tests of the package and torn down by TeardownPackageFixtures
+	PackageScope
+)
+
+func (s FixtureScope) String() string {
+	switch s {
+	case TestScope:
+		return "test"
+	case SuiteScope:
+		return "suite"
+	case PackageScope:
+		return "package"
+	}
+
+	return fmt.Sprintf("FixtureScope(%d)", int(s))
+}
+
+// FixtureDef describes the fixture.
+// Setup gets the test, which requested the fixture (use it to get fixtures the fixture depends on),
+// and context of the setup step. Teardown is optional.
+type FixtureDef struct {
+	Name     string
+	Scope    FixtureScope
+	Setup    func(t provider.T, ctx provider.StepCtx) (interface{}, error)
+	Teardown func(ctx provider.StepCtx, value interface{})
+}
+
+// FixtureT is implemented by tests, which can get fixtures
+type FixtureT interface {
+	GetFixture(def *FixtureDef) interface{}
+}
+
+type fixtureInstance struct {
+	def   *FixtureDef
+	ready chan struct{}
+	value interface{}
+	err   error
+}
+
+type fixtureScope struct {
+	container *allure.Container
+	instances map[*FixtureDef]*fixtureInstance
+	created   []*fixtureInstance
+	children  map[string]bool
+}
+
+type packageScopeKey struct{}
+
+// fixtures keeps fixture scopes: result of the test for TestScope,
+// container of the suite for SuiteScope and packageScopeKey for PackageScope.
+// The mutex also guards Befores, Afters and Children of the scopes' containers.
+var fixtures = struct {
+	sync.Mutex
+	scopes map[interface{}]*fixtureScope
+}{scopes: make(map[interface{}]*fixtureScope)}
+
+// SetFixtureSuite sets container of the suite, which suite scoped fixtures of the test belong to.
+// By default, it is the container of the test's own suite meta
+func (c *Common) SetFixtureSuite(container *allure.Container) {
+	c.fixtureSuite = container
+}
+
+func (c *Common) fixtureSuiteContainer() *allure.Container {
+	if c.fixtureSuite != nil {
+		return c.fixtureSuite
+	}
+
+	return c.Provider.GetSuiteMeta().GetContainer()
+}
+
+// fixtureScopeKey returns key of the scope and its container. Container of the package scope is created with the scope
+func (c *Common) fixtureScopeKey(scope FixtureScope) (interface{}, *allure.Container) {
+	switch scope {
+	case TestScope:
+		return c.GetResult(), c.Provider.GetTestMeta().GetContainer()
+	case SuiteScope:
+		container := c.fixtureSuiteContainer()
+		return container, container
+	}
+
+	return packageScopeKey{}, nil
+}
+
+// GetFixture returns value of the fixture, creating it on the first request in its scope.
+// Setup is reported as a step of the scope container's Befores. The test is stopped as broken if setup fails.
+func (c *Common) GetFixture(def *FixtureDef) interface{} {
+	return c.getFixture(def, nil)
+}
+
+func (c *Common) getFixture(def *FixtureDef, chain []*FixtureDef) interface{} {
+	c.Helper()
+
+	inst, created := c.acquireFixture(def)
+	if created {
+		c.setupFixture(inst, chain)
+	}
+
+	<-inst.ready
+	if inst.err != nil {
+		c.Breakf("fixture %q: %v", def.Name, inst.err)
+	}
+
+	return inst.value
+}
+
+// acquireFixture returns instance of the fixture in the test's scope. Returns true if the instance is new and should be set up
+func (c *Common) acquireFixture(def *FixtureDef) (*fixtureInstance, bool) {
+	key, container := c.fixtureScopeKey(def.Scope)
+
+	fixtures.Lock()
+	defer fixtures.Unlock()
+
+	scope, ok := fixtures.scopes[key]
+	if !ok {
+		if container == nil {
+			container = allure.NewContainer()
+			container.Begin()
+		}
+		scope = &fixtureScope{
+			container: container,
+			instances: make(map[*FixtureDef]*fixtureInstance),
+			children:  make(map[string]bool),
+		}
+		fixtures.scopes[key] = scope
+	}
+
+	if def.Scope == PackageScope {
+		if result := c.GetResult(); result != nil && !scope.children[result.UUID.String()] {
+			scope.children[result.UUID.String()] = true
+			scope.container.AddChild(result.UUID)
+		}
+	}
+
+	if inst, ok := scope.instances[def]; ok {
+		return inst, false
+	}
+
+	inst := &fixtureInstance{def: def, ready: make(chan struct{})}
+	scope.instances[def] = inst
+
+	return inst, true
+}
+
+func (c *Common) setupFixture(inst *fixtureInstance, chain []*FixtureDef) {
+	defer close(inst.ready)
+
+	var (
+		def   = inst.def
+		stCtx = NewStepCtx(c, c.Provider, fmt.Sprintf("Setup fixture %s", def.Name), allure.NewParameter("scope", def.Scope.String()))
+		ft    = &fixtureT{Common: c, chain: append(chain[:len(chain):len(chain)], def)}
+	)
+
+	outcome := runIsolated(func() {
+		inst.value, inst.err = def.Setup(ft, stCtx)
+	})
+	stCtx.WG().Wait()
+
+	switch {
+	case outcome.panicValue != nil:
+		inst.err = fmt.Errorf("setup panicked: %v\n%s", outcome.panicValue, outcome.panicStack)
+	case !outcome.returned:
+		inst.err = errors.New("setup was stopped")
+	}
+
+	if inst.err != nil {
+		msg := fmt.Sprintf("fixture %q: %v", def.Name, inst.err)
+		stCtx.CurrentStep().Broken().WithStatusDetails(msg, msg)
+	}
+	stCtx.CurrentStep().Finish()
+
+	key, _ := c.fixtureScopeKey(def.Scope)
+
+	fixtures.Lock()
+	defer fixtures.Unlock()
+
+	if scope, ok := fixtures.scopes[key]; ok {
+		scope.container.Befores = append(scope.container.Befores, stCtx.CurrentStep())
+		if inst.err == nil {
+			scope.created = append(scope.created, inst)
+		}
+	}
+}
+
+// TeardownFixtures tears down fixtures of the scope created for the test (for its suite with SuiteScope)
+// in reverse order of creation. Teardown is reported as a step of the scope container's Afters
+func (c *Common) TeardownFixtures(scope FixtureScope) {
+	key, _ := c.fixtureScopeKey(scope)
+	teardownFixtureScope(key, c, c.Provider)
+}
+
+// TeardownPackageFixtures tears down package scoped fixtures in reverse order of creation
+// and prints their container. Failures of teardown are printed to stderr
+func TeardownPackageFixtures() {
+	fixtures.Lock()
+	scope, ok := fixtures.scopes[packageScopeKey{}]
+	fixtures.Unlock()
+
+	if !ok {
+		return
+	}
+
+	pt := &packageT{container: scope.container}
+	teardownFixtureScope(packageScopeKey{}, pt, pt)
+	pt.cleanup()
+	if err := scope.container.Done(); err != nil {
+		fmt.Fprintf(os.Stderr, "allure-go: cannot print container of package fixtures: %v\n", err)
+	}
+}
+
+// teardownFixtureScope tears down fixtures of the scope and forgets the scope
+func teardownFixtureScope(key interface{}, t StepT, p StepProvider) {
+	fixtures.Lock()
+	scope, ok := fixtures.scopes[key]
+	delete(fixtures.scopes, key)
+	fixtures.Unlock()
+
+	if !ok {
+		return
+	}
+
+	for i := len(scope.created) - 1; i >= 0; i-- {
+		inst := scope.created[i]
+		if inst.def.Teardown == nil {
+			continue
+		}
+
+		stCtx := NewStepCtx(t, p, fmt.Sprintf("Teardown fixture %s", inst.def.Name), allure.NewParameter("scope", inst.def.Scope.String()))
+		outcome := runIsolated(func() {
+			inst.def.Teardown(stCtx, inst.value)
+		})
+		stCtx.WG().Wait()
+
+		if outcome.panicValue != nil {
+			msg := fmt.Sprintf("fixture %q: teardown panicked: %v\n%s", inst.def.Name, outcome.panicValue, outcome.panicStack)
+			stCtx.CurrentStep().Broken().WithStatusDetails(fmt.Sprintf("fixture %q: teardown panicked", inst.def.Name), msg)
+			t.Error(msg)
+		}
+		stCtx.CurrentStep().Finish()
+
+		fixtures.Lock()
+		scope.container.Afters = append(scope.container.Afters, stCtx.CurrentStep())
+		fixtures.Unlock()
+	}
+}
+
+// fixtureT is passed to setup of the fixture. It checks scopes and cycles of the fixture's dependencies
+type fixtureT struct {
+	*Common
+	chain []*FixtureDef
+}
+
+// GetFixture returns value of the fixture the set up fixture depends on
+func (t *fixtureT) GetFixture(def *FixtureDef) interface{} {
+	t.Helper()
+
+	current := t.chain[len(t.chain)-1]
+	if def.Scope < current.Scope {
+		t.Breakf("fixture %q with %s scope can't depend on fixture %q with %s scope", current.Name, current.Scope, def.Name, def.Scope)
+		return nil
+	}
+
+	for _, d := range t.chain {
+		if d == def {
+			t.Breakf("fixture %q depends on itself", def.Name)
+			return nil
+		}
+	}
+
+	return t.Common.getFixture(def, t.chain)
+}
+
+// packageT runs teardown of package fixtures, which happens out of any test (e.g. in TestMain).
+// Failures are printed to stderr and reported in the container of package fixtures only
+type packageT struct {
+	container *allure.Container
+
+	mu       sync.Mutex
+	failed   bool
+	skipped  bool
+	cleanups []func()
+}
+
+func (t *packageT) Fail() {}
+
+func (t *packageT) FailNow() {
+	runtime.Goexit()
+}
+
+func (t *packageT) Error(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+}
+
+func (t *packageT) Errorf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func (t *packageT) Log(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+}
+
+func (t *packageT) Logf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func (t *packageT) Break(args ...interface{}) {
+	t.Error(args...)
+	t.FailNow()
+}
+
+func (t *packageT) Breakf(format string, args ...interface{}) {
+	t.Errorf(format, args...)
+	t.FailNow()
+}
+
+func (t *packageT) Broken() {}
+
+func (t *packageT) BrokenNow() {
+	t.FailNow()
+}
+
+func (t *packageT) Name() string {
+	return "package fixtures"
+}
+
+func (t *packageT) GetRealT() provider.TestingT {
+	return packageRealT{t: t}
+}
+
+func (t *packageT) StopResult(allure.Status) {}
+
+func (t *packageT) UpdateResultStatus(string, string) {}
+
+func (t *packageT) Listener() listener.Set {
+	return listener.Set(nil)
+}
+
+func (t *packageT) Context() context.Context {
+	return rootContext()
+}
+
+func (t *packageT) ExecutionContext() provider.ExecutionContext {
+	return ctx.NewAfterAllCtx(t.container)
+}
+
+// cleanup runs functions registered with Cleanup of packageRealT in reverse order
+func (t *packageT) cleanup() {
+	t.mu.Lock()
+	cleanups := t.cleanups
+	t.cleanups = nil
+	t.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		if outcome := runIsolated(cleanups[i]); outcome.panicValue != nil {
+			t.Errorf("cleanup of package fixtures panicked: %v\n%s", outcome.panicValue, outcome.panicStack)
+		}
+	}
+}
+
+// packageRealT is returned by packageT.GetRealT. Methods of testing.TB are implemented on top of packageT,
+// embedded testing.TB is nil and only makes the type implement the interface
+type packageRealT struct {
+	testing.TB
+	t *packageT
+}
+
+func (rt packageRealT) Cleanup(f func()) {
+	rt.t.mu.Lock()
+	defer rt.t.mu.Unlock()
+
+	rt.t.cleanups = append(rt.t.cleanups, f)
+}
+
+func (rt packageRealT) Error(args ...interface{}) {
+	rt.t.Error(args...)
+	rt.Fail()
+}
+
+func (rt packageRealT) Errorf(format string, args ...interface{}) {
+	rt.t.Errorf(format, args...)
+	rt.Fail()
+}
+
+func (rt packageRealT) Fail() {
+	rt.t.mu.Lock()
+	defer rt.t.mu.Unlock()
+
+	rt.t.failed = true
+}
+
+func (rt packageRealT) FailNow() {
+	rt.Fail()
+	rt.t.FailNow()
+}
+
+func (rt packageRealT) Failed() bool {
+	rt.t.mu.Lock()
+	defer rt.t.mu.Unlock()
+
+	return rt.t.failed
+}
+
+func (rt packageRealT) Fatal(args ...interface{}) {
+	rt.Error(args...)
+	rt.t.FailNow()
+}
+
+func (rt packageRealT) Fatalf(format string, args ...interface{}) {
+	rt.Errorf(format, args...)
+	rt.t.FailNow()
+}
+
+func (packageRealT) Helper() {}
+
+func (rt packageRealT) Log(args ...interface{}) {
+	rt.t.Log(args...)
+}
+
+func (rt packageRealT) Logf(format string, args ...interface{}) {
+	rt.t.Logf(format, args...)
+}
+
+func (rt packageRealT) Name() string {
+	return rt.t.Name()
+}
+
+func (rt packageRealT) Setenv(key, value string) {
+	prev, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		rt.Fatalf("cannot set environment variable %s: %v", key, err)
+	}
+
+	rt.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, prev)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+func (rt packageRealT) Skip(args ...interface{}) {
+	rt.Log(args...)
+	rt.SkipNow()
+}
+
+func (rt packageRealT) Skipf(format string, args ...interface{}) {
+	rt.Logf(format, args...)
+	rt.SkipNow()
+}
+
+func (rt packageRealT) SkipNow() {
+	rt.t.mu.Lock()
+	rt.t.skipped = true
+	rt.t.mu.Unlock()
+
+	runtime.Goexit()
+}
+
+func (rt packageRealT) Skipped() bool {
+	rt.t.mu.Lock()
+	defer rt.t.mu.Unlock()
+
+	return rt.t.skipped
+}
+
+func (rt packageRealT) TempDir() string {
+	dir, err := os.MkdirTemp("", "allure-go-package-fixtures")
+	if err != nil {
+		rt.Fatalf("cannot create temporary directory: %v", err)
+	}
+
+	rt.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return dir
+}
+
+func (rt packageRealT) Chdir(dir string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		rt.Fatalf("cannot get working directory: %v", err)
+	}
+
+	if err = os.Chdir(dir); err != nil {
+		rt.Fatalf("cannot change working directory: %v", err)
+	}
+
+	rt.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func (rt packageRealT) Context() context.Context {
+	return rt.t.Context()
+}
+
+func (rt packageRealT) Attr(key, value string) {
+	rt.Logf("%s: %s", key, value)
+}
+
+func (packageRealT) Output() io.Writer {
+	return os.Stderr
+}
+
+func (packageRealT) Parallel() {}
+
+func (packageRealT) Run(string, func(t *testing.T)) bool {
+	return false
+}
@@ -0,0 +1,292 @@
+package common
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/constants"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+type fixturesTMock struct {
+	*stepsStepsCommTMock
+}
+
+func (m *fixturesTMock) Helper() {}
+
+func (m *fixturesTMock) Name() string {
+	return "fixtures"
+}
+
+func newFixturesCommon(suite *allure.Container) (*Common, *fixturesTMock) {
+	mockT := &fixturesTMock{stepsStepsCommTMock: newStepsCommonTMock()}
+	p := &providerMockstepsCommon{
+		testMetaMock:  &testMetaMockstepsCommon{result: allure.NewResult("test", "test"), container: allure.NewContainer()},
+		suiteMetaMock: &suiteMetaMockstepsCommon{container: suite},
+		executionMock: newExecContextstepsCommMock(constants.TestContextName),
+	}
+
+	return &Common{TestingT: mockT, Provider: p}, mockT
+}
+
+func TestFixtureScope_String(t *testing.T) {
+	require.Equal(t, "test", TestScope.String())
+	require.Equal(t, "suite", SuiteScope.String())
+	require.Equal(t, "package", PackageScope.String())
+	require.Equal(t, "FixtureScope(5)", FixtureScope(5).String())
+}
+
+func TestCommon_GetFixture_testScope(t *testing.T) {
+	var setups, teardowns int
+	def := &FixtureDef{
+		Name:  "db",
+		Scope: TestScope,
+		Setup: func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+			setups++
+			ctx.WithNewStep("connect", func(ctx provider.StepCtx) {})
+			return "conn", nil
+		},
+		Teardown: func(ctx provider.StepCtx, value interface{}) {
+			require.Equal(t, "conn", value)
+			teardowns++
+		},
+	}
+
+	comm, mockT := newFixturesCommon(allure.NewContainer())
+	require.Equal(t, "conn", comm.GetFixture(def))
+	require.Equal(t, "conn", comm.GetFixture(def))
+	require.Equal(t, 1, setups)
+
+	container := comm.Provider.GetTestMeta().GetContainer()
+	require.Len(t, container.Befores, 1)
+	require.Equal(t, "Setup fixture db", container.Befores[0].Name)
+	require.Equal(t, allure.Passed, container.Befores[0].Status)
+	require.Equal(t, []*allure.Parameter{allure.NewParameter("scope", "test")}, container.Befores[0].Parameters)
+	require.Len(t, container.Befores[0].Steps, 1)
+
+	comm.TeardownFixtures(TestScope)
+	require.Equal(t, 1, teardowns)
+	require.Len(t, container.Afters, 1)
+	require.Equal(t, "Teardown fixture db", container.Afters[0].Name)
+	require.False(t, mockT.failNow)
+
+	// the next test gets new value
+	other, _ := newFixturesCommon(allure.NewContainer())
+	require.Equal(t, "conn", other.GetFixture(def))
+	require.Equal(t, 2, setups)
+	other.TeardownFixtures(TestScope)
+}
+
+func TestCommon_GetFixture_suiteScope(t *testing.T) {
+	var (
+		setups int
+		order  []string
+		suite  = allure.NewContainer()
+	)
+
+	newDef := func(name string, deps ...*FixtureDef) *FixtureDef {
+		return &FixtureDef{
+			Name:  name,
+			Scope: SuiteScope,
+			Setup: func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+				setups++
+				for _, dep := range deps {
+					t.(FixtureT).GetFixture(dep)
+				}
+				return name, nil
+			},
+			Teardown: func(ctx provider.StepCtx, value interface{}) {
+				order = append(order, value.(string))
+			},
+		}
+	}
+	first := newDef("first")
+	second := newDef("second", first)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			comm, _ := newFixturesCommon(suite)
+			require.Equal(t, "second", comm.GetFixture(second))
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 2, setups)
+	require.Len(t, suite.Befores, 2)
+	require.Equal(t, "Setup fixture first", suite.Befores[0].Name)
+	require.Equal(t, "Setup fixture second", suite.Befores[1].Name)
+
+	comm, _ := newFixturesCommon(suite)
+	comm.TeardownFixtures(SuiteScope)
+	require.Equal(t, []string{"second", "first"}, order)
+	require.Len(t, suite.Afters, 2)
+}
+
+func TestCommon_GetFixture_setupError(t *testing.T) {
+	def := &FixtureDef{
+		Name:  "db",
+		Scope: TestScope,
+		Setup: func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+			return nil, errors.New("connection refused")
+		},
+		Teardown: func(ctx provider.StepCtx, value interface{}) {
+			require.Fail(t, "teardown of failed fixture")
+		},
+	}
+
+	comm, mockT := newFixturesCommon(allure.NewContainer())
+	comm.GetFixture(def)
+	require.True(t, mockT.failNow)
+	require.Equal(t, allure.Broken, comm.GetResult().Status)
+	require.Contains(t, comm.GetResult().StatusDetails.Message, `fixture "db": connection refused`)
+
+	container := comm.Provider.GetTestMeta().GetContainer()
+	require.Len(t, container.Befores, 1)
+	require.Equal(t, allure.Broken, container.Befores[0].Status)
+
+	comm.TeardownFixtures(TestScope)
+	require.Empty(t, container.Afters)
+}
+
+func TestCommon_GetFixture_setupPanic(t *testing.T) {
+	def := &FixtureDef{
+		Name:  "db",
+		Scope: TestScope,
+		Setup: func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+			panic("boom")
+		},
+	}
+
+	comm, mockT := newFixturesCommon(allure.NewContainer())
+	comm.GetFixture(def)
+	require.True(t, mockT.failNow)
+	require.Contains(t, comm.GetResult().StatusDetails.Message, "setup panicked: boom")
+	comm.TeardownFixtures(TestScope)
+}
+
+func TestCommon_GetFixture_wrongScope(t *testing.T) {
+	testDef := &FixtureDef{
+		Name:  "request",
+		Scope: TestScope,
+		Setup: func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+			return "request", nil
+		},
+	}
+	suiteDef := &FixtureDef{
+		Name:  "client",
+		Scope: SuiteScope,
+		Setup: func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+			return t.(FixtureT).GetFixture(testDef), nil
+		},
+	}
+
+	suite := allure.NewContainer()
+	comm, mockT := newFixturesCommon(suite)
+	comm.GetFixture(suiteDef)
+	require.True(t, mockT.failNow)
+	require.Equal(t, allure.Broken, comm.GetResult().Status)
+	require.Contains(t, comm.GetResult().StatusDetails.Message, `fixture "client" with suite scope can't depend on fixture "request" with test scope`)
+	comm.TeardownFixtures(SuiteScope)
+	comm.TeardownFixtures(TestScope)
+}
+
+func TestCommon_GetFixture_cycle(t *testing.T) {
+	def := &FixtureDef{Name: "self", Scope: TestScope}
+	def.Setup = func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+		return t.(FixtureT).GetFixture(def), nil
+	}
+
+	comm, mockT := newFixturesCommon(allure.NewContainer())
+	comm.GetFixture(def)
+	require.True(t, mockT.failNow)
+	require.Contains(t, comm.GetResult().StatusDetails.Message, `fixture "self" depends on itself`)
+	comm.TeardownFixtures(TestScope)
+}
+
+func TestTeardownPackageFixtures(t *testing.T) {
+	t.Setenv("ALLURE_OUTPUT_PATH", t.TempDir())
+
+	var teardowns int
+	def := &FixtureDef{
+		Name:  "server",
+		Scope: PackageScope,
+		Setup: func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+			return "server", nil
+		},
+		Teardown: func(ctx provider.StepCtx, value interface{}) {
+			teardowns++
+			panic("boom")
+		},
+	}
+
+	first, _ := newFixturesCommon(allure.NewContainer())
+	second, _ := newFixturesCommon(allure.NewContainer())
+	require.Equal(t, "server", first.GetFixture(def))
+	require.Equal(t, "server", second.GetFixture(def))
+	require.Equal(t, "server", second.GetFixture(def))
+
+	fixtures.Lock()
+	container := fixtures.scopes[packageScopeKey{}].container
+	fixtures.Unlock()
+	require.Len(t, container.Children, 2)
+	require.Equal(t, first.GetResult().UUID, container.Children[0])
+	require.Equal(t, second.GetResult().UUID, container.Children[1])
+	require.Len(t, container.Befores, 1)
+
+	TeardownPackageFixtures()
+	require.Equal(t, 1, teardowns)
+	require.Len(t, container.Afters, 1)
+	require.Equal(t, allure.Broken, container.Afters[0].Status)
+	require.NotZero(t, container.Stop)
+
+	// scope is forgotten after teardown
+	TeardownPackageFixtures()
+	require.Equal(t, 1, teardowns)
+}
+
+func TestTeardownPackageFixtures_realT(t *testing.T) {
+	t.Setenv("ALLURE_OUTPUT_PATH", t.TempDir())
+
+	var (
+		name, dir string
+		failed    bool
+		cleaned   []string
+	)
+	def := &FixtureDef{
+		Name:  "workdir",
+		Scope: PackageScope,
+		Setup: func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+			return "workdir", nil
+		},
+		Teardown: func(ctx provider.StepCtx, value interface{}) {
+			realT := ctx.(*stepCtx).GetRealT()
+			name = realT.Name()
+			dir = realT.TempDir()
+			realT.Setenv("ALLURE_GO_PACKAGE_FIXTURE", "set")
+			realT.Cleanup(func() { cleaned = append(cleaned, os.Getenv("ALLURE_GO_PACKAGE_FIXTURE")) })
+			realT.Errorf("teardown failed")
+			failed = realT.Failed()
+			realT.Skip("skipped")
+			cleaned = append(cleaned, "not reached")
+		},
+	}
+
+	common, _ := newFixturesCommon(allure.NewContainer())
+	require.Equal(t, "workdir", common.GetFixture(def))
+
+	require.NotPanics(t, TeardownPackageFixtures)
+	require.Equal(t, "package fixtures", name)
+	require.True(t, failed)
+	require.Equal(t, []string{"set"}, cleaned)
+	require.NoDirExists(t, dir)
+	_, ok := os.LookupEnv("ALLURE_GO_PACKAGE_FIXTURE")
+	require.False(t, ok)
+}
@@ -5,6 +5,7 @@ import (
 	"runtime/debug"
 	"testing"
 
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 )
 
@@ -35,6 +36,21 @@ func CarriedHook(hook HookType, getHookBody func() func(t provider.T)) HookFunc
 			oldT := t.RealT()
 			defer t.SetRealT(oldT)
 
+			if l := t.Listener(); l.Active() {
+				event := listener.HookEvent{Hook: string(hook), Suite: provider.GetSuiteMeta().GetSuiteName()}
+				if hook == BeforeEach || hook == AfterEach {
+					event.Result = provider.GetTestMeta().GetResult()
+				}
+				l.HookStarted(event)
+				defer func() {
+					event.Err = err
+					if event.Err == nil && !result {
+						event.Err = fmt.Errorf("%s hook failed", hook)
+					}
+					l.HookFinished(event)
+				}()
+			}
+
 			// HACK: allows testing library control routines to avoid deadlocks and appropriate waiting
 			result = t.RealT().Run(string(hook), func(realT *testing.T) {
 				defer t.WG().Done()
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 )
 
@@ -136,6 +137,10 @@ func (m *hookTMock) WG() *sync.WaitGroup {
 	return m.wg
 }
 
+func (m *hookTMock) Listener() listener.Set {
+	return listener.Set(nil)
+}
+
 func (m *hookTMock) FailNow() {
 	m.failNow = true
 }
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 )
 
@@ -27,4 +28,5 @@ type InternalT interface {
 
 	SetRealT(realT provider.TestingT)
 	WG() *sync.WaitGroup
+	Listener() listener.Set
 }
@@ -0,0 +1,147 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/helper"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// softFailure is the failed assert of the soft asserts scope
+type softFailure struct {
+	step    *allure.Step
+	message string
+}
+
+// softAsserts collects asserts of the scope: their steps are added to the scope's step
+// and failures are reported once, when the scope is closed
+type softAsserts struct {
+	mu       sync.Mutex
+	scope    *allure.Step
+	total    int
+	pending  []string
+	failures []softFailure
+}
+
+func (s *softAsserts) Step(step *allure.Step) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.total++
+	s.scope.WithChild(step)
+
+	// failed assert calls Errorf before its step is created
+	if len(s.pending) > 0 {
+		message := strings.Join(s.pending, "\n")
+		s.pending = nil
+		step.WithStatusDetails(shortMessage(message), message)
+		s.failures = append(s.failures, softFailure{step: step, message: message})
+	}
+}
+
+func (s *softAsserts) Errorf(format string, args ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
+
+// FailNow is never called: asserts of the scope don't stop it, require mode fails when the scope is closed
+func (s *softAsserts) FailNow() {}
+
+// summary returns short message and consolidated report of all failed asserts
+func (s *softAsserts) summary() (string, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	short := fmt.Sprintf("%d of %d soft asserts failed", len(s.failures), s.total)
+
+	var report strings.Builder
+	report.WriteString(short + ":")
+	for i, failure := range s.failures {
+		_, _ = fmt.Fprintf(&report, "\n%d. %s: %s", i+1, failure.step.Name, failure.step.StatusDetails.Message)
+		for _, param := range failure.step.Parameters {
+			switch param.Mode {
+			case allure.ParameterModeHidden:
+				continue
+			case allure.ParameterModeMasked:
+				_, _ = fmt.Fprintf(&report, "\n\t%s: %s", param.Name, allure.MaskedParameterValue)
+			default:
+				_, _ = fmt.Fprintf(&report, "\n\t%s: %s", param.Name, param.GetValue())
+			}
+		}
+	}
+
+	return short, report.String()
+}
+
+func (s *softAsserts) failed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.failures) > 0
+}
+
+// runSoftAsserts runs body of the soft asserts scope with the step of sCtx.
+// If any assert failed, the step and the test are failed with consolidated report, required scope also stops the test
+func runSoftAsserts(sCtx provider.StepCtx, body func(a provider.Asserts), required bool) {
+	soft := &softAsserts{scope: sCtx.CurrentStep()}
+	defer func() {
+		if !soft.failed() {
+			return
+		}
+
+		short, report := soft.summary()
+		sCtx.Errorf("%s", report)
+		sCtx.WithStatusDetails(short, report)
+		if required {
+			sCtx.FailNow()
+		}
+	}()
+
+	body(helper.NewAssertsHelper(soft))
+}
+
+// SoftAssert runs body in the new step. Asserts of body don't fail the test immediately, they are added as substeps,
+// and when body is completed the test is failed once with the list of all failed asserts.
+func (c *Common) SoftAssert(stepName string, body func(a provider.Asserts)) {
+	c.WithNewStep(stepName, func(sCtx provider.StepCtx) {
+		runSoftAsserts(sCtx, body, false)
+	})
+}
+
+// SoftRequire is the same as SoftAssert, but stops the test after body if any of asserts failed.
+func (c *Common) SoftRequire(stepName string, body func(a provider.Asserts)) {
+	c.WithNewStep(stepName, func(sCtx provider.StepCtx) {
+		runSoftAsserts(sCtx, body, true)
+	})
+}
+
+// SoftAssert runs body in the new substep, see Common.SoftAssert
+func (ctx *stepCtx) SoftAssert(stepName string, body func(a provider.Asserts)) {
+	ctx.WithNewStep(stepName, func(sCtx provider.StepCtx) {
+		runSoftAsserts(sCtx, body, false)
+	})
+}
+
+// SoftRequire runs body in the new substep, see Common.SoftRequire
+func (ctx *stepCtx) SoftRequire(stepName string, body func(a provider.Asserts)) {
+	ctx.WithNewStep(stepName, func(sCtx provider.StepCtx) {
+		runSoftAsserts(sCtx, body, true)
+	})
+}
+
+// shortMessage returns `Error:` line of testify message, or the first line of other messages
+func shortMessage(message string) string {
+	lines := strings.Split(message, "\n")
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "Error:") {
+			return strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(line, "Error:")), ":")
+		}
+	}
+
+	return strings.TrimSpace(lines[0])
+}
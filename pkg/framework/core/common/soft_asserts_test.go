@@ -0,0 +1,90 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+func TestStepCtx_SoftAssert_passed(t *testing.T) {
+	mockT := newStepProviderMock()
+	mockT.SetRealT(t)
+	step := allure.NewSimpleStep("testStep")
+	ctx := stepCtx{t: mockT, p: &providerMockStep{}, currentStep: step}
+
+	ctx.SoftAssert("soft", func(a provider.Asserts) {
+		a.Equal(1, 1)
+		a.True(true)
+	})
+
+	require.False(t, mockT.failed)
+	require.Len(t, step.Steps, 1)
+	scope := step.Steps[0]
+	require.Equal(t, "soft", scope.Name)
+	require.NotEqual(t, allure.Failed, scope.Status)
+	require.Len(t, scope.Steps, 2)
+	require.Equal(t, "ASSERT: Equal", scope.Steps[0].Name)
+	require.Equal(t, "ASSERT: True", scope.Steps[1].Name)
+}
+
+func TestStepCtx_SoftAssert_failed(t *testing.T) {
+	mockT := newStepProviderMock()
+	mockT.SetRealT(t)
+	step := allure.NewSimpleStep("testStep")
+	ctx := stepCtx{t: mockT, p: &providerMockStep{}, currentStep: step}
+
+	after := false
+	ctx.SoftAssert("soft", func(a provider.Asserts) {
+		a.Equal(1, 2)
+		a.NotEmpty("value")
+		a.True(false, "flag is set")
+		after = true
+	})
+
+	require.True(t, after)
+	require.True(t, mockT.errorF)
+	require.False(t, mockT.failNow)
+	require.Equal(t, allure.Failed, step.Status)
+
+	scope := step.Steps[0]
+	require.Equal(t, allure.Failed, scope.Status)
+	require.Len(t, scope.Steps, 3)
+	require.Equal(t, allure.Failed, scope.Steps[0].Status)
+	require.Contains(t, scope.Steps[0].StatusDetails.Message, "Not equal")
+	require.NotEqual(t, allure.Failed, scope.Steps[1].Status)
+	require.Equal(t, allure.Failed, scope.Steps[2].Status)
+
+	require.Equal(t, "2 of 3 soft asserts failed", scope.StatusDetails.Message)
+	trace := scope.StatusDetails.Trace
+	require.Contains(t, trace, "1. ASSERT: Equal: Not equal\n\tExpected: 1\n\tActual: 2")
+	require.Contains(t, trace, "2. ASSERT: flag is set: Should be true\n\tActual Value: bool(false)")
+}
+
+func TestStepCtx_SoftRequire(t *testing.T) {
+	mockT := newStepProviderMock()
+	mockT.SetRealT(t)
+	step := allure.NewSimpleStep("testStep")
+	ctx := stepCtx{t: mockT, p: &providerMockStep{}, currentStep: step}
+
+	ctx.SoftRequire("soft", func(a provider.Asserts) {
+		a.Equal(1, 1)
+	})
+	require.False(t, mockT.failNow)
+
+	ctx.SoftRequire("soft", func(a provider.Asserts) {
+		a.Equal(1, 2)
+		a.Equal(3, 4)
+	})
+	require.True(t, mockT.failNow)
+	require.Equal(t, allure.Failed, step.Steps[1].Status)
+	require.Equal(t, "2 of 2 soft asserts failed", step.Steps[1].StatusDetails.Message)
+}
+
+func TestShortMessage(t *testing.T) {
+	require.Equal(t, "Should be true", shortMessage("Error Trace:\tfile.go:1\n\tError:      \tShould be true\n\tTest:\tTest"))
+	require.Equal(t, "Not equal", shortMessage("\tError:      \tNot equal: \n\t            \texpected: 1"))
+	require.Equal(t, "first", shortMessage("first\nsecond"))
+}
@@ -1,12 +1,15 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"runtime/debug"
 	"sync"
 
 	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/helper"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 )
 
@@ -29,6 +32,8 @@ type StepT interface {
 	BrokenNow()
 	Name() string
 	GetRealT() provider.TestingT
+	Context() context.Context
+	Listener() listener.Set
 }
 
 type InternalStepCtx interface {
@@ -49,6 +54,9 @@ type stepCtx struct {
 	require provider.Asserts
 
 	wg sync.WaitGroup
+
+	ctxMu sync.Mutex
+	base  context.Context // context of the step with timeout, otherwise context of the parent is used
 }
 
 func NewStepCtx(t StepT, p StepProvider, stepName string, params ...*allure.Parameter) InternalStepCtx {
@@ -79,6 +87,11 @@ func (ctx *stepCtx) Require() provider.Asserts {
 	return ctx.require
 }
 
+// Expect returns expectation of actual value, which is asserted with matchers, see matcher.Matcher
+func (ctx *stepCtx) Expect(actual interface{}) matcher.Expectation {
+	return ctx.asserts.Expect(actual)
+}
+
 func (ctx *stepCtx) WG() *sync.WaitGroup {
 	return &ctx.wg
 }
@@ -138,12 +151,41 @@ func (ctx *stepCtx) WithNewParameters(kv ...interface{}) {
 	ctx.currentStep.WithNewParameters(kv...)
 }
 
+func (ctx *stepCtx) WithNewMaskedParameters(kv ...interface{}) {
+	ctx.currentStep.WithNewMaskedParameters(kv...)
+}
+
 func (ctx *stepCtx) WithAttachments(attachments ...*allure.Attachment) {
 	ctx.currentStep.WithAttachments(attachments...)
+	if l := ctx.t.Listener(); l.Active() {
+		for _, attachment := range attachments {
+			l.AttachmentAdded(listener.AttachmentEvent{Result: ctx.testResult(), Step: ctx.currentStep, Attachment: attachment})
+		}
+	}
 }
 
 func (ctx *stepCtx) WithNewAttachment(name string, mimeType allure.MimeType, content []byte) {
-	ctx.currentStep.WithAttachments(allure.NewAttachment(name, mimeType, content))
+	ctx.WithAttachments(allure.NewAttachment(name, mimeType, content))
+}
+
+// childStarted emits StepStarted event of the child step
+func (ctx *stepCtx) childStarted(child *allure.Step) {
+	if l := ctx.t.Listener(); l.Active() {
+		l.StepStarted(listener.StepEvent{Result: ctx.testResult(), Step: child, Parent: ctx.currentStep})
+	}
+}
+
+// childFinished emits StepFinished event of the child step and observed status change of the test
+func (ctx *stepCtx) childFinished(child *allure.Step) {
+	if l := ctx.t.Listener(); l.Active() {
+		l.StepFinished(listener.StepEvent{Result: ctx.testResult(), Step: child, Parent: ctx.currentStep})
+		listener.NotifyStatus(l, ctx.testResult())
+	}
+}
+
+// testResult returns result of the test the step belongs to, nil for steps of all hooks
+func (ctx *stepCtx) testResult() *allure.Result {
+	return ctx.p.ExecutionContext().GetTestResult()
 }
 
 func (ctx *stepCtx) LogStep(args ...interface{}) {
@@ -169,7 +211,12 @@ func (ctx *stepCtx) NewStep(stepName string, parameters ...*allure.Parameter) {
 
 func (ctx *stepCtx) WithNewStep(stepName string, step func(ctx provider.StepCtx), params ...*allure.Parameter) {
 	newCtx := ctx.NewChildCtx(stepName, params...)
+	cancel := cancelableStep(newCtx)
+	defer cancel()
+
+	ctx.childStarted(newCtx.CurrentStep())
 	defer ctx.currentStep.WithChild(newCtx.CurrentStep())
+	defer ctx.childFinished(newCtx.CurrentStep())
 	defer func() {
 		r := recover()
 		newCtx.WG().Wait()
@@ -1,13 +1,16 @@
 package common
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/helper"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
 	"github.com/ozontech/allure-go/pkg/framework/core/constants"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 
 	"github.com/stretchr/testify/require"
@@ -89,6 +92,14 @@ func (m *providerTMockStep) GetRealT() provider.TestingT {
 	return m.testingT
 }
 
+func (m *providerTMockStep) Context() context.Context {
+	return context.Background()
+}
+
+func (m *providerTMockStep) Listener() listener.Set {
+	return listener.Set(nil)
+}
+
 func (m *providerTMockStep) SetRealT(realT provider.TestingT) {
 	m.testingT = realT
 }
@@ -311,6 +322,17 @@ func TestStepCtx_Require(t *testing.T) {
 	require.Equal(t, test, ctx.Require())
 }
 
+func TestStepCtx_Expect(t *testing.T) {
+	step := allure.NewSimpleStep("testStep")
+	ctx := &stepCtx{t: newStepProviderMock(), currentStep: step}
+	ctx.asserts = helper.NewAssertsHelper(ctx)
+
+	ctx.Expect([]int{1, 2}).To(matcher.HaveLen(2))
+	require.Len(t, step.Steps, 1)
+	require.Equal(t, "ASSERT: Expect to have length 2", step.Steps[0].Name)
+	require.Equal(t, allure.Passed, step.Steps[0].Status)
+}
+
 func TestStepCtx_WG(t *testing.T) {
 	test := sync.WaitGroup{}
 	ctx := stepCtx{wg: test}
@@ -347,6 +369,19 @@ func TestStepCtx_WithNewParameters(t *testing.T) {
 	require.Equal(t, "v2", step.Parameters[1].GetValue())
 }
 
+func TestStepCtx_WithNewMaskedParameters(t *testing.T) {
+	mockT := new(providerTMockStep)
+	step := allure.NewSimpleStep("testStep")
+
+	ctx := stepCtx{t: mockT, currentStep: step}
+	ctx.WithNewMaskedParameters("token", "secret")
+
+	require.Len(t, step.Parameters, 1)
+	require.Equal(t, "token", step.Parameters[0].Name)
+	require.Equal(t, "secret", step.Parameters[0].GetValue())
+	require.True(t, step.Parameters[0].IsMasked())
+}
+
 func TestStepCtx_WithAttachments(t *testing.T) {
 	mockT := new(providerTMockStep)
 	step := allure.NewSimpleStep("testStep")
@@ -5,6 +5,7 @@ import (
 	"runtime/debug"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 )
 
@@ -12,7 +13,12 @@ import (
 // Any other struct.Step that will be added to struct.AllureResult object will be added as child step
 func (c *Common) WithNewStep(stepName string, step func(ctx provider.StepCtx), params ...*allure.Parameter) {
 	stCtx := NewStepCtx(c, c.Provider, stepName, params...)
+	cancel := cancelableStep(stCtx)
+	defer cancel()
+
+	c.stepStarted(stCtx.CurrentStep())
 	defer c.Step(stCtx.CurrentStep())
+	defer c.stepFinished(stCtx.CurrentStep())
 	defer func() {
 		r := recover()
 		stCtx.WG().Wait()
@@ -36,3 +42,18 @@ func (c *Common) WithNewAsyncStep(stepName string, step func(ctx provider.StepCt
 		c.WithNewStep(stepName, step, params...)
 	}()
 }
+
+// stepStarted emits StepStarted event of the test's step
+func (c *Common) stepStarted(step *allure.Step) {
+	if l := c.Listener(); l.Active() {
+		l.StepStarted(listener.StepEvent{Result: c.GetResult(), Step: step})
+	}
+}
+
+// stepFinished emits StepFinished event of the test's step and observed status change
+func (c *Common) stepFinished(step *allure.Step) {
+	if l := c.Listener(); l.Active() {
+		l.StepFinished(listener.StepEvent{Result: c.GetResult(), Step: step})
+		listener.NotifyStatus(l, c.GetResult())
+	}
+}
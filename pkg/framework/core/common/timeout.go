@@ -0,0 +1,263 @@
+package common
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// GoroutineDumpName is the name of the attachment with goroutine dump that is created on timeout
+const GoroutineDumpName = "Goroutine dump"
+
+// GoroutineDump returns stack traces of all running goroutines
+func GoroutineDump() []byte {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// NewGoroutineDumpAttachment returns attachment with stack traces of all running goroutines
+func NewGoroutineDumpAttachment() *allure.Attachment {
+	return allure.NewAttachment(GoroutineDumpName, allure.Text, GoroutineDump())
+}
+
+// stepOutcome describes how the step's goroutine finished
+type stepOutcome struct {
+	returned   bool
+	panicValue interface{}
+	panicStack []byte
+}
+
+// goStep runs step in separate goroutine, so FailNow (runtime.Goexit) in the step stops the step only.
+// Returns channel with the outcome of the step
+func goStep(step func()) <-chan stepOutcome {
+	done := make(chan stepOutcome, 1)
+	go func() {
+		var res stepOutcome
+		defer func() {
+			if r := recover(); r != nil {
+				res.panicValue = r
+				res.panicStack = debug.Stack()
+			}
+			done <- res
+		}()
+		step()
+		res.returned = true
+	}()
+
+	return done
+}
+
+// runIsolated runs step in separate goroutine and waits for it
+func runIsolated(step func()) stepOutcome {
+	return <-goStep(step)
+}
+
+// runWithTimeout runs step in separate goroutine and waits for it at most timeout.
+// Returns false if the timeout expired. The goroutine of expired step is abandoned.
+func runWithTimeout(timeout time.Duration, step func()) (outcome stepOutcome, ok bool) {
+	done := goStep(step)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case outcome = <-done:
+		return outcome, true
+	case <-timer.C:
+		return outcome, false
+	}
+}
+
+// expiredStep returns the step reported instead of the expired one: broken, with timeout message and goroutine dump.
+// Step of the body is left to its goroutine, so its later changes don't get to the report. Returns the message
+func expiredStep(stepName string, start int64, params []*allure.Parameter, timeout time.Duration) (*allure.Step, string) {
+	errMsg := fmt.Sprintf("step %q timed out after %s", stepName, timeout)
+	step := allure.NewStep(stepName, allure.Broken, start, allure.GetNow(), params)
+	step.WithStatusDetails(errMsg, errMsg)
+	step.WithAttachments(NewGoroutineDumpAttachment())
+
+	return step, errMsg
+}
+
+// stepGuard detaches the body of expired step from the test and the parent step,
+// so failures and logs of the abandoned goroutine are dropped
+type stepGuard struct {
+	mu       sync.RWMutex
+	detached bool
+}
+
+// do calls f, if the body is not detached. Detaching waits for f to return
+func (g *stepGuard) do(f func()) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.detached {
+		f()
+	}
+}
+
+func (g *stepGuard) detach() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.detached = true
+}
+
+// guardedT is the test of the step with timeout, which ignores calls of detached body.
+// FailNow and BrokenNow of detached body stop its goroutine only
+type guardedT struct {
+	StepT
+	guard *stepGuard
+}
+
+func (t *guardedT) Fail()                     { t.guard.do(t.StepT.Fail) }
+func (t *guardedT) Broken()                   { t.guard.do(t.StepT.Broken) }
+func (t *guardedT) Error(args ...interface{}) { t.guard.do(func() { t.StepT.Error(args...) }) }
+func (t *guardedT) Errorf(format string, args ...interface{}) {
+	t.guard.do(func() { t.StepT.Errorf(format, args...) })
+}
+func (t *guardedT) Log(args ...interface{}) { t.guard.do(func() { t.StepT.Log(args...) }) }
+func (t *guardedT) Logf(format string, args ...interface{}) {
+	t.guard.do(func() { t.StepT.Logf(format, args...) })
+}
+func (t *guardedT) Break(args ...interface{}) { t.guard.do(func() { t.StepT.Break(args...) }) }
+func (t *guardedT) Breakf(format string, args ...interface{}) {
+	t.guard.do(func() { t.StepT.Breakf(format, args...) })
+}
+
+func (t *guardedT) FailNow() {
+	t.guard.do(t.StepT.FailNow)
+	runtime.Goexit()
+}
+
+func (t *guardedT) BrokenNow() {
+	t.guard.do(t.StepT.BrokenNow)
+	runtime.Goexit()
+}
+
+// guardedParent is the parent of the step with timeout, which ignores status changes by detached body
+type guardedParent struct {
+	InternalStepCtx
+	guard *stepGuard
+}
+
+func (p *guardedParent) Fail()   { p.guard.do(p.InternalStepCtx.Fail) }
+func (p *guardedParent) Broken() { p.guard.do(p.InternalStepCtx.Broken) }
+
+// newTimeoutStepCtx returns context of the step with timeout, which is detached from t and parent by guard
+func newTimeoutStepCtx(t StepT, p StepProvider, parent InternalStepCtx, guard *stepGuard, stepName string, params ...*allure.Parameter) *stepCtx {
+	newCtx := NewStepCtx(&guardedT{StepT: t, guard: guard}, p, stepName, params...).(*stepCtx)
+	if parent != nil {
+		newCtx.parentStep = &guardedParent{InternalStepCtx: parent, guard: guard}
+	}
+
+	return newCtx
+}
+
+// WithNewStepTimeout works like WithNewStep, but stops waiting for the step when timeout expires.
+// Expired step is marked broken with goroutine dump attached and the error is handled by TestError:
+// the test body and BeforeEach hook mark the test broken and stop it, other hooks are just stopped.
+// The body keeps running in its goroutine, so it must return, when context of the step is done (sCtx.Context().Done()).
+// Changes of the body after the timeout are not reported
+func (c *Common) WithNewStepTimeout(stepName string, timeout time.Duration, step func(ctx provider.StepCtx), params ...*allure.Parameter) {
+	var (
+		guard    = &stepGuard{}
+		stCtx    = newTimeoutStepCtx(c, c.Provider, nil, guard, stepName, params...)
+		ctxName  = c.ExecutionContext().GetName()
+		start    = stCtx.CurrentStep().Start
+		stParams = append([]*allure.Parameter(nil), params...)
+	)
+
+	cancel := cancelableStep(stCtx)
+	defer cancel()
+
+	c.stepStarted(stCtx.CurrentStep())
+
+	outcome, ok := runWithTimeout(timeout, func() { step(stCtx) })
+	if !ok {
+		guard.detach()
+		expired, errMsg := expiredStep(stepName, start, stParams, timeout)
+		c.Step(expired)
+		c.stepFinished(expired)
+		TestError(c.TestingT, c.Provider, ctxName, errMsg)
+		return
+	}
+	defer c.stepFinished(stCtx.CurrentStep())
+
+	stCtx.WG().Wait()
+	stCtx.CurrentStep().Finish()
+	c.Step(stCtx.CurrentStep())
+
+	if outcome.panicValue != nil {
+		errMsg := fmt.Sprintf("%s panicked: %v\n%s", ctxName, outcome.panicValue, outcome.panicStack)
+		stCtx.Broken()
+		stCtx.WithStatusDetails(fmt.Sprintf("%s panicked", ctxName), errMsg)
+		TestError(c.TestingT, c.Provider, ctxName, errMsg)
+		return
+	}
+
+	// step was stopped by FailNow
+	if !outcome.returned {
+		c.TestingT.FailNow()
+	}
+}
+
+// WithNewStepTimeout works like WithNewStep, but stops waiting for the step when timeout expires.
+// Expired step is marked broken with goroutine dump attached and the error is handled by TestError:
+// the test body and BeforeEach hook mark the test broken and stop it, other hooks are just stopped.
+// The body keeps running in its goroutine, so it must return, when context of the step is done (sCtx.Context().Done()).
+// Changes of the body after the timeout are not reported
+func (ctx *stepCtx) WithNewStepTimeout(stepName string, timeout time.Duration, step func(ctx provider.StepCtx), params ...*allure.Parameter) {
+	var (
+		guard    = &stepGuard{}
+		newCtx   = newTimeoutStepCtx(ctx.t, ctx.p, ctx, guard, stepName, params...)
+		ctxName  = newCtx.ExecutionContextName()
+		start    = newCtx.CurrentStep().Start
+		stParams = append([]*allure.Parameter(nil), params...)
+	)
+
+	cancel := cancelableStep(newCtx)
+	defer cancel()
+
+	ctx.childStarted(newCtx.CurrentStep())
+
+	outcome, ok := runWithTimeout(timeout, func() { step(newCtx) })
+	if !ok {
+		guard.detach()
+		expired, errMsg := expiredStep(stepName, start, stParams, timeout)
+		ctx.currentStep.WithChild(expired)
+		ctx.childFinished(expired)
+		ctx.Broken()
+		TestError(ctx.t, ctx.p, ctxName, errMsg)
+		return
+	}
+	defer ctx.childFinished(newCtx.CurrentStep())
+
+	newCtx.WG().Wait()
+	newCtx.CurrentStep().Finish()
+	ctx.currentStep.WithChild(newCtx.CurrentStep())
+
+	if outcome.panicValue != nil {
+		errMsg := fmt.Sprintf("%s panicked: %v\n%s", ctxName, outcome.panicValue, outcome.panicStack)
+		newCtx.Broken()
+		newCtx.WithStatusDetails(fmt.Sprintf("%s panicked", ctxName), errMsg)
+		TestError(ctx.t, ctx.p, ctxName, errMsg)
+		return
+	}
+
+	// step was stopped by FailNow
+	if !outcome.returned {
+		ctx.t.FailNow()
+	}
+}
@@ -0,0 +1,138 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/constants"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+func TestGoroutineDump(t *testing.T) {
+	dump := string(GoroutineDump())
+	require.True(t, strings.HasPrefix(dump, "goroutine "))
+	require.Contains(t, dump, "TestGoroutineDump")
+
+	attachment := NewGoroutineDumpAttachment()
+	require.Equal(t, GoroutineDumpName, attachment.Name)
+	require.Equal(t, allure.Text, attachment.Type)
+}
+
+func TestCommon_WithNewStepTimeout(t *testing.T) {
+	mockT := newStepsCommonTMock()
+	p := &providerMockstepsCommon{
+		testMetaMock:  &testMetaMockstepsCommon{result: &allure.Result{}},
+		suiteMetaMock: &suiteMetaMockstepsCommon{},
+		executionMock: newExecContextstepsCommMock(constants.TestContextName),
+	}
+	comm := Common{TestingT: mockT, Provider: p}
+	params := allure.NewParameters("p1", "v1")
+	comm.WithNewStepTimeout("step", time.Second, func(ctx provider.StepCtx) {}, params...)
+	require.Len(t, p.steps, 1)
+	require.Equal(t, "step", p.steps[0].Name)
+	require.Equal(t, params, p.steps[0].Parameters)
+	require.Empty(t, p.steps[0].Attachments)
+	require.False(t, mockT.failNow)
+}
+
+func TestCommon_WithNewStepTimeout_expired(t *testing.T) {
+	mockT := newStepsCommonTMock()
+	p := &providerMockstepsCommon{
+		testMetaMock:  &testMetaMockstepsCommon{result: &allure.Result{}},
+		suiteMetaMock: &suiteMetaMockstepsCommon{},
+		executionMock: newExecContextstepsCommMock(constants.TestContextName),
+	}
+	comm := Common{TestingT: mockT, Provider: p}
+
+	release := make(chan struct{})
+	defer close(release)
+	comm.WithNewStepTimeout("step", 10*time.Millisecond, func(ctx provider.StepCtx) { <-release })
+	require.Len(t, p.steps, 1)
+	require.Equal(t, allure.Broken, p.steps[0].Status)
+	require.Contains(t, p.steps[0].StatusDetails.Message, "timed out after 10ms")
+	require.Len(t, p.steps[0].Attachments, 1)
+	require.Equal(t, GoroutineDumpName, p.steps[0].Attachments[0].Name)
+	require.True(t, mockT.errorfFlag)
+	require.True(t, mockT.failNow)
+}
+
+func TestCommon_WithNewStepTimeout_detached(t *testing.T) {
+	mockT := newStepsCommonTMock()
+	p := &providerMockstepsCommon{
+		testMetaMock:  &testMetaMockstepsCommon{result: &allure.Result{}},
+		suiteMetaMock: &suiteMetaMockstepsCommon{},
+		executionMock: newExecContextstepsCommMock(constants.TestContextName),
+	}
+	comm := Common{TestingT: mockT, Provider: p}
+
+	var (
+		release = make(chan struct{})
+		done    = make(chan struct{})
+	)
+	comm.WithNewStep("parent", func(sCtx provider.StepCtx) {
+		sCtx.WithNewStepTimeout("child", 10*time.Millisecond, func(ctx provider.StepCtx) {
+			defer close(done)
+			<-ctx.Context().Done()
+			<-release
+			ctx.WithNewAttachment("late", allure.Text, []byte("late"))
+			ctx.WithNewParameters("late", 1)
+			ctx.Errorf("late failure")
+			ctx.FailNow()
+		}, allure.NewParameter("p1", "v1"))
+	})
+	close(release)
+	<-done
+
+	child := p.steps[0].Steps[0]
+	require.Equal(t, allure.Broken, child.Status)
+	require.Contains(t, child.StatusDetails.Message, "timed out after 10ms")
+	require.Equal(t, []*allure.Parameter{allure.NewParameter("p1", "v1")}, child.Parameters)
+	require.Len(t, child.Attachments, 1)
+	require.Equal(t, GoroutineDumpName, child.Attachments[0].Name)
+	require.Equal(t, allure.Broken, p.steps[0].Status)
+}
+
+func TestCommon_WithNewStepTimeout_panic(t *testing.T) {
+	mockT := newStepsCommonTMock()
+	p := &providerMockstepsCommon{
+		testMetaMock:  &testMetaMockstepsCommon{result: &allure.Result{}},
+		suiteMetaMock: &suiteMetaMockstepsCommon{},
+		executionMock: newExecContextstepsCommMock(constants.TestContextName),
+	}
+	comm := Common{TestingT: mockT, Provider: p}
+	comm.WithNewStepTimeout("step", time.Second, func(ctx provider.StepCtx) { panic("whoops") })
+	require.Len(t, p.steps, 1)
+	require.Equal(t, allure.Broken, p.steps[0].Status)
+	require.Equal(t, "test panicked", p.steps[0].StatusDetails.Message)
+	require.Contains(t, p.steps[0].StatusDetails.Trace, "whoops")
+	require.True(t, mockT.failNow)
+}
+
+func TestStepCtx_WithNewStepTimeout_expired(t *testing.T) {
+	mockT := newStepsCommonTMock()
+	p := &providerMockstepsCommon{
+		testMetaMock:  &testMetaMockstepsCommon{result: &allure.Result{}},
+		suiteMetaMock: &suiteMetaMockstepsCommon{},
+		executionMock: newExecContextstepsCommMock(constants.TestContextName),
+	}
+	comm := &Common{TestingT: mockT, Provider: p}
+
+	release := make(chan struct{})
+	defer close(release)
+	comm.WithNewStep("parent", func(sCtx provider.StepCtx) {
+		sCtx.WithNewStepTimeout("child", 10*time.Millisecond, func(ctx provider.StepCtx) { <-release })
+	})
+	require.Len(t, p.steps, 1)
+	require.Equal(t, allure.Broken, p.steps[0].Status)
+	require.Len(t, p.steps[0].Steps, 1)
+
+	child := p.steps[0].Steps[0]
+	require.Equal(t, "child", child.Name)
+	require.Equal(t, allure.Broken, child.Status)
+	require.Len(t, child.Attachments, 1)
+	require.True(t, mockT.failNow)
+}
@@ -0,0 +1,190 @@
+package dataprovider
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CSVTag is the struct tag with name of the CSV column of the field. `csv:"-"` skips the field
+const CSVTag = "csv"
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// decodeCSV decodes CSV file with header row. Columns are matched with exported fields of struct
+// by CSVTag or case-insensitive field name. Non-struct types are decoded from the single column.
+func decodeCSV(data []byte, typ reflect.Type) ([]Row, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read CSV header")
+	}
+
+	fields, err := csvFields(header, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for index := 1; ; index++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+
+		row := Row{Index: index}
+
+		// FieldPos panics after failed Read, the line of malformed record is taken from the error
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			row.Line = parseErr.StartLine
+			if row.Line == 0 {
+				row.Line = parseErr.Line
+			}
+			row.Err = err
+		case err != nil:
+			return nil, err
+		default:
+			row.Line, _ = reader.FieldPos(0)
+			row.Value, row.Err = decodeCSVRecord(record, header, fields, typ)
+		}
+
+		rows = append(rows, row)
+	}
+}
+
+// csvFields returns index of the field for every column of the header. Nil means the type itself
+func csvFields(header []string, typ reflect.Type) ([][]int, error) {
+	fields := make([][]int, len(header))
+
+	if typ.Kind() != reflect.Struct || typ.Implements(textUnmarshalerType) || reflect.PtrTo(typ).Implements(textUnmarshalerType) {
+		if len(header) != 1 {
+			return nil, errors.Errorf("single CSV column expected for %s, got %d", typ, len(header))
+		}
+
+		return fields, nil
+	}
+
+	for i, column := range header {
+		field, ok := findCSVField(typ, strings.TrimSpace(column))
+		if !ok {
+			return nil, errors.Errorf("unknown CSV column %q for %s", column, typ)
+		}
+		fields[i] = field.Index
+	}
+
+	return fields, nil
+}
+
+func findCSVField(typ reflect.Type, column string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Tag.Get(CSVTag)
+		if name == "-" {
+			continue
+		}
+		if name == "" && strings.EqualFold(field.Name, column) || name == column {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func decodeCSVRecord(record, header []string, fields [][]int, typ reflect.Type) (interface{}, error) {
+	value := reflect.New(typ).Elem()
+
+	for i, cell := range record {
+		target := value
+		if fields[i] != nil {
+			target = value.FieldByIndex(fields[i])
+		}
+
+		if err := setCSVValue(target, cell); err != nil {
+			return nil, errors.Wrapf(err, "column %q", header[i])
+		}
+	}
+
+	return value.Interface(), nil
+}
+
+// setCSVValue parses the cell into the value. Empty cell keeps zero value
+func setCSVValue(v reflect.Value, cell string) error {
+	if cell == "" {
+		return nil
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(cell))
+		}
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(cell)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return errors.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
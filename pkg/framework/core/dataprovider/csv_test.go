@@ -0,0 +1,87 @@
+package dataprovider
+
+import (
+	"encoding/csv"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type csvCase struct {
+	Name    string
+	Enabled bool
+	Count   uint8
+	Ratio   float64
+	Timeout time.Duration
+	Limit   *int
+	IP      net.IP
+	Skipped string `csv:"-"`
+	Renamed string `csv:"other name"`
+}
+
+func TestDecodeCSV_types(t *testing.T) {
+	data := []byte("name,enabled,count,ratio,timeout,limit,ip,other name\nfirst,true,7,0.5,1m,10,127.0.0.1,renamed\n")
+
+	rows, err := decodeCSV(data, reflect.TypeOf(csvCase{}))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.NoError(t, rows[0].Err)
+
+	limit := 10
+	require.Equal(t, csvCase{
+		Name:    "first",
+		Enabled: true,
+		Count:   7,
+		Ratio:   0.5,
+		Timeout: time.Minute,
+		Limit:   &limit,
+		IP:      net.ParseIP("127.0.0.1"),
+		Renamed: "renamed",
+	}, rows[0].Value)
+}
+
+func TestDecodeCSV_errors(t *testing.T) {
+	typ := reflect.TypeOf(csvCase{})
+
+	_, err := decodeCSV([]byte("skipped\nvalue\n"), typ)
+	require.Error(t, err)
+
+	rows, err := decodeCSV([]byte("count\n256\n-1\n"), typ)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Error(t, rows[0].Err)
+	require.Error(t, rows[1].Err)
+}
+
+func TestDecodeCSV_malformedQuote(t *testing.T) {
+	rows, err := decodeCSV([]byte("name,count\nfirst,1\nsec\"ond,2\nthird,3\n"), reflect.TypeOf(csvCase{}))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.NoError(t, rows[0].Err)
+
+	var parseErr *csv.ParseError
+	require.ErrorAs(t, rows[1].Err, &parseErr)
+	require.Equal(t, 3, rows[1].Line)
+	require.NoError(t, rows[2].Err)
+	require.Equal(t, 4, rows[2].Line)
+
+	rows, err = decodeCSV([]byte("name\n\"unterminated\n"), reflect.TypeOf(csvCase{}))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Error(t, rows[0].Err)
+	require.Equal(t, 2, rows[0].Line)
+}
+
+func TestDecodeCSV_singleColumn(t *testing.T) {
+	rows, err := decodeCSV([]byte("city\nMoscow\nParis\n"), reflect.TypeOf(""))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "Paris", rows[1].Value)
+	require.Equal(t, 3, rows[1].Line)
+
+	_, err = decodeCSV([]byte("a,b\n1,2\n"), reflect.TypeOf(""))
+	require.Error(t, err)
+}
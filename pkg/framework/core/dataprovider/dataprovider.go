@@ -0,0 +1,89 @@
+package dataprovider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+const (
+	// SourceParameterName is the name of the parameter with path to the data file
+	SourceParameterName = "Data source"
+	// RowParameterName is the name of the parameter with number of the case in the data file
+	RowParameterName = "Data row"
+)
+
+// Row is the case loaded from the data file
+type Row struct {
+	Source string      // path to the data file
+	Index  int         // number of the case in the file, starting with 1
+	Line   int         // line of the case in the file, 0 if unknown
+	Value  interface{} // decoded case. Zero value, if the case is malformed
+	Err    error       // error of the case decoding
+}
+
+// Name returns short description of the row for names and messages, e.g. `cases.csv:3`
+func (r Row) Name() string {
+	return fmt.Sprintf("%s:%d", filepath.Base(r.Source), r.Index)
+}
+
+// Parameters returns source file and row as parameters.
+// They are excluded from historyId, so history isn't lost when rows are moved
+func (r Row) Parameters() []*allure.Parameter {
+	row := strconv.Itoa(r.Index)
+	if r.Line > 0 {
+		row = fmt.Sprintf("%d (line %d)", r.Index, r.Line)
+	}
+
+	return []*allure.Parameter{
+		allure.NewExcludedParameter(SourceParameterName, r.Source),
+		allure.NewExcludedParameter(RowParameterName, row),
+	}
+}
+
+type decoder func(data []byte, typ reflect.Type) ([]Row, error)
+
+var decoders = map[string]decoder{
+	".csv":  decodeCSV,
+	".json": decodeJSON,
+	".yaml": decodeYAML,
+	".yml":  decodeYAML,
+}
+
+// Load reads cases from the data file and decodes them into values of typ.
+// Format is chosen by extension: `.csv` (header row names the fields), `.json` (array) or `.yaml`/`.yml` (sequence).
+// Relative path is resolved from the working directory, which is the package directory under `go test`.
+// Error is returned if the file can't be read or parsed at all, malformed cases are returned with Row.Err.
+func Load(path string, typ reflect.Type) ([]Row, error) {
+	decode, ok := decoders[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return nil, errors.Errorf("%s: unsupported data file format, expected one of .csv, .json, .yaml, .yml", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read data file")
+	}
+
+	rows, err := decode(data, typ)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", path)
+	}
+
+	for i := range rows {
+		rows[i].Source = path
+		if rows[i].Err != nil {
+			rows[i].Value = reflect.Zero(typ).Interface()
+			rows[i].Err = errors.Wrapf(rows[i].Err, "%s: malformed row %d", path, rows[i].Index)
+		}
+	}
+
+	return rows, nil
+}
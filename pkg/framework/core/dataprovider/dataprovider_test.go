@@ -0,0 +1,114 @@
+package dataprovider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+type cityCase struct {
+	Name       string `json:"name" yaml:"name"`
+	Population int    `json:"population" yaml:"population" csv:"population"`
+}
+
+var cityType = reflect.TypeOf(cityCase{})
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	return path
+}
+
+func TestLoad_JSON(t *testing.T) {
+	path := writeFile(t, "cities.json", `[
+		{"name": "Moscow", "population": 12},
+		{"name": "Paris", "population": "many"},
+		{"name": "Rome"}
+	]`)
+
+	rows, err := Load(path, cityType)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	require.Equal(t, Row{Source: path, Index: 1, Value: cityCase{Name: "Moscow", Population: 12}}, rows[0])
+	require.Equal(t, cityCase{Name: "Rome"}, rows[2].Value)
+
+	require.Error(t, rows[1].Err)
+	require.Contains(t, rows[1].Err.Error(), "malformed row 2")
+	require.Equal(t, cityCase{}, rows[1].Value)
+}
+
+func TestLoad_YAML(t *testing.T) {
+	path := writeFile(t, "cities.yml", `
+- name: Moscow
+  population: 12
+- name: Paris
+  population: many
+`)
+
+	rows, err := Load(path, cityType)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.Equal(t, Row{Source: path, Index: 1, Line: 2, Value: cityCase{Name: "Moscow", Population: 12}}, rows[0])
+	require.Error(t, rows[1].Err)
+	require.Equal(t, 4, rows[1].Line)
+}
+
+func TestLoad_CSV(t *testing.T) {
+	path := writeFile(t, "cities.csv", "Name,population\nMoscow,12\nParis,many\nRome\nBerlin,\n")
+
+	rows, err := Load(path, cityType)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	require.Equal(t, Row{Source: path, Index: 1, Line: 2, Value: cityCase{Name: "Moscow", Population: 12}}, rows[0])
+	require.Error(t, rows[1].Err)
+	require.Contains(t, rows[1].Err.Error(), `column "population"`)
+	require.Error(t, rows[2].Err)
+	require.Equal(t, cityCase{Name: "Berlin"}, rows[3].Value)
+}
+
+func TestLoad_errors(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"), cityType)
+	require.Error(t, err)
+
+	_, err = Load(writeFile(t, "cities.txt", ""), cityType)
+	require.Error(t, err)
+
+	_, err = Load(writeFile(t, "cities.json", `{"name": "Moscow"}`), cityType)
+	require.Error(t, err)
+
+	_, err = Load(writeFile(t, "cities.yaml", `name: Moscow`), cityType)
+	require.Error(t, err)
+
+	_, err = Load(writeFile(t, "cities.csv", "Name,Country\nMoscow,Russia\n"), cityType)
+	require.Error(t, err)
+}
+
+func TestLoad_empty(t *testing.T) {
+	for _, name := range []string{"cities.csv", "cities.yaml"} {
+		rows, err := Load(writeFile(t, name, ""), cityType)
+		require.NoError(t, err)
+		require.Empty(t, rows)
+	}
+}
+
+func TestRow_Parameters(t *testing.T) {
+	row := Row{Source: "testdata/cities.csv", Index: 3, Line: 4}
+
+	require.Equal(t, "cities.csv:3", row.Name())
+	require.Equal(t, []*allure.Parameter{
+		allure.NewExcludedParameter(SourceParameterName, "testdata/cities.csv"),
+		allure.NewExcludedParameter(RowParameterName, "3 (line 4)"),
+	}, row.Parameters())
+
+	row.Line = 0
+	require.Equal(t, "3", row.Parameters()[1].GetValue())
+}
@@ -0,0 +1,32 @@
+package dataprovider
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/bytedance/sonic"
+	"github.com/pkg/errors"
+)
+
+// decodeJSON decodes JSON array, every element is a case
+func decodeJSON(data []byte, typ reflect.Type) ([]Row, error) {
+	var elements []json.RawMessage
+	if err := sonic.Unmarshal(data, &elements); err != nil {
+		return nil, errors.Wrap(err, "JSON array of cases expected")
+	}
+
+	rows := make([]Row, 0, len(elements))
+	for i, element := range elements {
+		value := reflect.New(typ)
+		row := Row{Index: i + 1}
+		if err := sonic.Unmarshal(element, value.Interface()); err != nil {
+			row.Err = err
+		} else {
+			row.Value = value.Elem().Interface()
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,41 @@
+package dataprovider
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// decodeYAML decodes YAML sequence, every element is a case
+func decodeYAML(data []byte, typ reflect.Type) ([]Row, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	// empty file
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	seq := doc.Content[0]
+	if seq.Kind != yaml.SequenceNode {
+		return nil, errors.Errorf("YAML sequence of cases expected at line %d", seq.Line)
+	}
+
+	rows := make([]Row, 0, len(seq.Content))
+	for i, node := range seq.Content {
+		value := reflect.New(typ)
+		row := Row{Index: i + 1, Line: node.Line}
+		if err := node.Decode(value.Interface()); err != nil {
+			row.Err = err
+		} else {
+			row.Value = value.Elem().Interface()
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,179 @@
+package listener
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// Listener observes the run. Listeners are called synchronously from the goroutines of the tests,
+// so they must be safe for concurrent use and shouldn't block. Panics of listeners are printed to stderr.
+// Embed Base to implement only needed methods.
+type Listener interface {
+	SuiteStarted(e SuiteEvent)
+	SuiteFinished(e SuiteEvent)
+
+	TestScheduled(e TestEvent)
+	TestStarted(e TestEvent)
+	TestFinished(e TestEvent)
+
+	HookStarted(e HookEvent)
+	HookFinished(e HookEvent)
+
+	StepStarted(e StepEvent)
+	StepFinished(e StepEvent)
+
+	AttachmentAdded(e AttachmentEvent)
+	StatusChanged(e StatusEvent)
+}
+
+// SuiteEvent is emitted when the suite (runner) starts and finishes
+type SuiteEvent struct {
+	Name      string            // name of the suite
+	FullName  string            // full name of the suite
+	Container *allure.Container // container of the suite. Holds before all and after all steps when the suite is finished
+}
+
+// TestEvent is emitted when the test is scheduled (before waiting for t.Parallel), started and finished.
+// Every attempt of retried test is started and finished with its own result
+type TestEvent struct {
+	Result *allure.Result
+}
+
+// HookEvent is emitted when before/after all or before/after each hook starts and finishes
+type HookEvent struct {
+	Hook   string         // BeforeAll, AfterAll, BeforeEach or AfterEach
+	Suite  string         // name of the suite
+	Result *allure.Result // result of the test for each hooks, nil for all hooks
+	Err    error          // error of the finished hook, if it failed or panicked
+}
+
+// StepEvent is emitted when the step with body (WithNewStep and its variants) starts and finishes
+type StepEvent struct {
+	Result *allure.Result // result of the test, nil for steps of all hooks
+	Step   *allure.Step   // the step. Status and Stop are set when the step is finished
+	Parent *allure.Step   // parent step, nil for steps of the test
+}
+
+// AttachmentEvent is emitted when the attachment is added to the test or the step
+type AttachmentEvent struct {
+	Result     *allure.Result // result of the test, nil for attachments of all hooks
+	Step       *allure.Step   // step the attachment is added to, nil for attachments of the test
+	Attachment *allure.Attachment
+}
+
+// StatusEvent is emitted when the change of the test's status is observed:
+// by status methods of provider.T, after steps and hooks and before TestFinished
+type StatusEvent struct {
+	Result   *allure.Result // the result with new status
+	Previous allure.Status  // previous status, empty if it wasn't set
+}
+
+// Base implements Listener with methods doing nothing
+type Base struct{}
+
+func (Base) SuiteStarted(SuiteEvent)         {}
+func (Base) SuiteFinished(SuiteEvent)        {}
+func (Base) TestScheduled(TestEvent)         {}
+func (Base) TestStarted(TestEvent)           {}
+func (Base) TestFinished(TestEvent)          {}
+func (Base) HookStarted(HookEvent)           {}
+func (Base) HookFinished(HookEvent)          {}
+func (Base) StepStarted(StepEvent)           {}
+func (Base) StepFinished(StepEvent)          {}
+func (Base) AttachmentAdded(AttachmentEvent) {}
+func (Base) StatusChanged(StatusEvent)       {}
+
+type registration struct {
+	listener Listener
+}
+
+var global struct {
+	sync.RWMutex
+	registrations []*registration
+}
+
+// Register registers listener of all runs of the test binary. Returns function that unregisters it
+func Register(l Listener) (unregister func()) {
+	reg := &registration{listener: l}
+
+	global.Lock()
+	global.registrations = append(global.registrations, reg)
+	global.Unlock()
+
+	return func() {
+		global.Lock()
+		defer global.Unlock()
+
+		for i, r := range global.registrations {
+			if r == reg {
+				global.registrations = append(global.registrations[:i:i], global.registrations[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Set is the list of listeners of the runner. It implements Listener by calling
+// globally registered listeners and then listeners of the set
+type Set []Listener
+
+// With returns new set with listeners added
+func (s Set) With(listeners ...Listener) Set {
+	return append(s[:len(s):len(s)], listeners...)
+}
+
+// Active returns true if there are listeners to call: in the set or registered globally
+func (s Set) Active() bool {
+	if len(s) > 0 {
+		return true
+	}
+
+	global.RLock()
+	defer global.RUnlock()
+
+	return len(global.registrations) > 0
+}
+
+func (s Set) each(event func(l Listener)) {
+	global.RLock()
+	all := make([]Listener, 0, len(global.registrations)+len(s))
+	for _, r := range global.registrations {
+		all = append(all, r.listener)
+	}
+	global.RUnlock()
+
+	for _, l := range append(all, s...) {
+		call(l, event)
+	}
+}
+
+func call(l Listener, event func(l Listener)) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "allure-go: listener %T panicked: %v\n", l, r)
+		}
+	}()
+
+	event(l)
+}
+
+func (s Set) SuiteStarted(e SuiteEvent)  { s.each(func(l Listener) { l.SuiteStarted(e) }) }
+func (s Set) SuiteFinished(e SuiteEvent) { s.each(func(l Listener) { l.SuiteFinished(e) }) }
+func (s Set) TestScheduled(e TestEvent)  { s.each(func(l Listener) { l.TestScheduled(e) }) }
+func (s Set) TestStarted(e TestEvent)    { s.each(func(l Listener) { l.TestStarted(e) }) }
+func (s Set) TestFinished(e TestEvent)   { s.each(func(l Listener) { l.TestFinished(e) }) }
+func (s Set) HookStarted(e HookEvent)    { s.each(func(l Listener) { l.HookStarted(e) }) }
+func (s Set) HookFinished(e HookEvent)   { s.each(func(l Listener) { l.HookFinished(e) }) }
+func (s Set) StepStarted(e StepEvent)    { s.each(func(l Listener) { l.StepStarted(e) }) }
+func (s Set) StepFinished(e StepEvent)   { s.each(func(l Listener) { l.StepFinished(e) }) }
+
+func (s Set) AttachmentAdded(e AttachmentEvent) {
+	s.each(func(l Listener) { l.AttachmentAdded(e) })
+}
+
+func (s Set) StatusChanged(e StatusEvent) {
+	s.each(func(l Listener) { l.StatusChanged(e) })
+}
@@ -0,0 +1,133 @@
+package listener
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+type recorder struct {
+	Base
+
+	mu     sync.Mutex
+	name   string
+	events *[]string
+}
+
+func (r *recorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	*r.events = append(*r.events, r.name+":"+event)
+}
+
+func (r *recorder) SuiteStarted(e SuiteEvent) { r.record("suite started " + e.Name) }
+
+func (r *recorder) StatusChanged(e StatusEvent) {
+	r.record("status " + string(e.Previous) + "->" + string(e.Result.Status))
+}
+
+func (r *recorder) TestFinished(e TestEvent) { r.record("test finished " + e.Result.Name) }
+
+type panicking struct {
+	Base
+}
+
+func (panicking) SuiteStarted(SuiteEvent) { panic("boom") }
+
+func TestSet_Active(t *testing.T) {
+	require.False(t, Set(nil).Active())
+	require.True(t, Set(nil).With(Base{}).Active())
+
+	unregister := Register(Base{})
+	require.True(t, Set(nil).Active())
+	unregister()
+	require.False(t, Set(nil).Active())
+}
+
+func TestSet_With(t *testing.T) {
+	first := Set{Base{}}
+	second := first.With(Base{})
+	third := first.With(&panicking{})
+
+	require.Len(t, first, 1)
+	require.Len(t, second, 2)
+	require.Len(t, third, 2)
+	require.Equal(t, Base{}, second[1])
+}
+
+func TestSet_SuiteStarted(t *testing.T) {
+	var events []string
+
+	unregister := Register(&recorder{name: "global", events: &events})
+	defer unregister()
+
+	s := Set{&panicking{}, &recorder{name: "runner", events: &events}}
+	require.NotPanics(t, func() { s.SuiteStarted(SuiteEvent{Name: "suite"}) })
+	require.Equal(t, []string{"global:suite started suite", "runner:suite started suite"}, events)
+
+	unregister()
+	events = nil
+	s.SuiteStarted(SuiteEvent{Name: "suite"})
+	require.Equal(t, []string{"runner:suite started suite"}, events)
+}
+
+func TestNotifyStatus(t *testing.T) {
+	var events []string
+	s := Set{&recorder{name: "runner", events: &events}}
+	result := allure.NewResult("test", "test")
+
+	NotifyStatus(s, result)
+	require.Empty(t, events)
+
+	result.Status = allure.Failed
+	NotifyStatus(s, result)
+	NotifyStatus(s, result)
+	require.Equal(t, []string{"runner:status ->failed"}, events)
+
+	result.Status = allure.Broken
+	NotifyTestFinished(s, result)
+	require.Equal(t, []string{"runner:status ->failed", "runner:status failed->broken", "runner:test finished test"}, events)
+
+	statuses.Lock()
+	require.NotContains(t, statuses.seen, result)
+	statuses.Unlock()
+
+	// inactive set is ignored
+	NotifyStatus(nil, result)
+	NotifyStatus(s, nil)
+	require.Len(t, events, 3)
+}
+
+func TestNotifyTestFinished_inactive(t *testing.T) {
+	var events []string
+	unregister := Register(&recorder{name: "global", events: &events})
+	result := allure.NewResult("test", "test")
+	result.Status = allure.Failed
+	NotifyStatus(nil, result)
+
+	// listener is unregistered while the test is running
+	unregister()
+	NotifyTestFinished(nil, result)
+	require.Equal(t, []string{"global:status ->failed"}, events)
+
+	statuses.Lock()
+	require.NotContains(t, statuses.seen, result)
+	statuses.Unlock()
+}
+
+func TestForgetStatus(t *testing.T) {
+	var events []string
+	s := Set{&recorder{name: "runner", events: &events}}
+	result := allure.NewResult("suite", "suite")
+	result.Status = allure.Broken
+	NotifyStatus(s, result)
+
+	ForgetStatus(result)
+	statuses.Lock()
+	require.NotContains(t, statuses.seen, result)
+	statuses.Unlock()
+}
@@ -0,0 +1,57 @@
+package listener
+
+import (
+	"sync"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// statuses keeps the last status of unfinished results seen by NotifyStatus
+var statuses = struct {
+	sync.Mutex
+	seen map[*allure.Result]allure.Status
+}{seen: make(map[*allure.Result]allure.Status)}
+
+// NotifyStatus emits StatusChanged to l if status of the result differs from the status seen by the previous call
+func NotifyStatus(l Set, result *allure.Result) {
+	if result == nil || !l.Active() {
+		return
+	}
+
+	statuses.Lock()
+	previous := statuses.seen[result]
+	current := result.Status
+	if previous == current {
+		statuses.Unlock()
+		return
+	}
+	statuses.seen[result] = current
+	statuses.Unlock()
+
+	l.StatusChanged(StatusEvent{Result: result, Previous: previous})
+}
+
+// NotifyTestFinished emits pending StatusChanged and TestFinished to l.
+// The status seen for the result is forgotten even if l is not active anymore (e.g. listener was unregistered)
+func NotifyTestFinished(l Set, result *allure.Result) {
+	if result == nil {
+		return
+	}
+	defer ForgetStatus(result)
+
+	if !l.Active() {
+		return
+	}
+
+	NotifyStatus(l, result)
+	l.TestFinished(TestEvent{Result: result})
+}
+
+// ForgetStatus forgets the status of the result seen by NotifyStatus.
+// It is called for results which end without NotifyTestFinished, e.g. result of the suite used by all hooks
+func ForgetStatus(result *allure.Result) {
+	statuses.Lock()
+	defer statuses.Unlock()
+
+	delete(statuses.seen, result)
+}
@@ -0,0 +1,204 @@
+package allurehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// requestHeaders formats request line and headers of the request
+func (t *Transport) requestHeaders(req *http.Request) []byte {
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "%s %s %s\n", req.Method, t.redactURL(req.URL).RequestURI(), req.Proto)
+	_, _ = fmt.Fprintf(&buf, "Host: %s\n", requestHost(req))
+	t.writeHeaders(&buf, req.Header)
+
+	return buf.Bytes()
+}
+
+// responseHeaders formats status line and headers of the response
+func (t *Transport) responseHeaders(resp *http.Response) []byte {
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "%s %s\n", resp.Proto, resp.Status)
+	t.writeHeaders(&buf, resp.Header)
+
+	return buf.Bytes()
+}
+
+func (t *Transport) writeHeaders(buf *bytes.Buffer, header http.Header) {
+	for _, name := range sortedKeys(header) {
+		for _, value := range header[name] {
+			_, _ = fmt.Fprintf(buf, "%s: %s\n", name, t.headerValue(name, value))
+		}
+	}
+}
+
+// headerValue returns the value or allure.MaskedParameterValue, if the header is redacted
+func (t *Transport) headerValue(name, value string) string {
+	if _, ok := t.redactedHeaders[http.CanonicalHeaderKey(name)]; ok {
+		return allure.MaskedParameterValue
+	}
+
+	return value
+}
+
+// redactURL returns copy of the URL with values of redacted query parameters replaced with allure.MaskedParameterValue.
+// Order and encoding of other parameters are kept
+func (t *Transport) redactURL(u *url.URL) *url.URL {
+	if u.RawQuery == "" || len(t.redactedQuery) == 0 {
+		return u
+	}
+
+	pairs := strings.Split(u.RawQuery, "&")
+	for i, pair := range pairs {
+		rawKey := pair
+		if j := strings.IndexByte(pair, '='); j >= 0 {
+			rawKey = pair[:j]
+		}
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		if _, ok := t.redactedQuery[strings.ToLower(key)]; ok {
+			pairs[i] = rawKey + "=" + allure.MaskedParameterValue
+		}
+	}
+
+	redacted := *u
+	redacted.RawQuery = strings.Join(pairs, "&")
+
+	return &redacted
+}
+
+// curl returns the curl command, which repeats the request. Truncated body is omitted with the comment
+func (t *Transport) curl(req *http.Request, body []byte, truncated bool) string {
+	var cmd strings.Builder
+	cmd.WriteString("curl")
+	if req.Method != http.MethodGet || len(body) > 0 {
+		cmd.WriteString(" -X " + shellQuote(req.Method))
+	}
+	cmd.WriteString(" " + shellQuote(t.redactURL(req.URL).Redacted()))
+	if host := requestHost(req); host != req.URL.Host {
+		cmd.WriteString(" \\\n  -H " + shellQuote("Host: "+host))
+	}
+	for _, name := range sortedKeys(req.Header) {
+		for _, value := range req.Header[name] {
+			cmd.WriteString(" \\\n  -H " + shellQuote(name+": "+t.headerValue(name, value)))
+		}
+	}
+
+	switch {
+	case truncated:
+		cmd.WriteString(fmt.Sprintf("\n# request body is omitted: it is longer than %d bytes", len(body)))
+	case len(body) > 0 && utf8.Valid(body):
+		cmd.WriteString(" \\\n  --data-binary " + shellQuote(string(body)))
+	case len(body) > 0:
+		cmd.WriteString("\n# binary request body is omitted")
+	}
+
+	return cmd.String()
+}
+
+// Marks of the body attachment, which doesn't have the whole body
+const (
+	truncatedMark = "truncated" // the body is longer than the limit
+	partialMark   = "partial"   // the body is not read to the end
+)
+
+// bodyMark returns the mark of the body attachment or empty string for the whole body. Partial body is marked partial
+func bodyMark(truncated, partial bool) string {
+	switch {
+	case partial:
+		return partialMark
+	case truncated:
+		return truncatedMark
+	default:
+		return ""
+	}
+}
+
+// bodyAttachment returns attachment with the body, which is pretty-printed by content type.
+// Marked (not whole) body is attached as is, with the mark in the name, e.g. "Response Body (truncated)"
+func bodyAttachment(name, contentType string, body []byte, mark string) *allure.Attachment {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mark != "" {
+		mimeType := allure.MimeType(mediaType)
+		if isJSON(mediaType) || mediaType == "" || mediaType == "application/x-www-form-urlencoded" {
+			mimeType = allure.Text
+		}
+		return allure.NewAttachment(fmt.Sprintf("%s (%s)", name, mark), mimeType, body)
+	}
+
+	switch {
+	case isJSON(mediaType) || (mediaType == "" && json.Valid(body)):
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, body, "", "  "); err == nil {
+			return allure.NewAttachment(name, allure.JSON, indented.Bytes())
+		}
+		return allure.NewAttachment(name, allure.Text, body)
+	case mediaType == "application/x-www-form-urlencoded":
+		return allure.NewAttachment(name, allure.Text, formatForm(body))
+	case mediaType == "text/xml" || mediaType == "application/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return allure.NewAttachment(name, allure.XML, body)
+	case mediaType == "text/html":
+		return allure.NewAttachment(name, allure.HTML, body)
+	case mediaType == "text/csv":
+		return allure.NewAttachment(name, allure.Csv, body)
+	case mediaType == "" || strings.HasPrefix(mediaType, "text/"):
+		return allure.NewAttachment(name, allure.Text, body)
+	default:
+		return allure.NewAttachment(name, allure.MimeType(mediaType), body)
+	}
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// formatForm formats url-encoded form as "key=value" lines
+func formatForm(body []byte) []byte {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return body
+	}
+
+	var buf bytes.Buffer
+	for _, key := range sortedKeys(values) {
+		for _, value := range values[key] {
+			_, _ = fmt.Fprintf(&buf, "%s=%s\n", key, value)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func requestHost(req *http.Request) string {
+	if req.Host != "" {
+		return req.Host
+	}
+
+	return req.URL.Host
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// shellQuote quotes s with single quotes for POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
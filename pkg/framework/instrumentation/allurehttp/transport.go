@@ -0,0 +1,266 @@
+// Package allurehttp provides http.RoundTripper, which adds each request as the step of the test:
+//
+//	func (s *SampleSuite) TestGetUser(t provider.T) {
+//		client := allurehttp.NewClient(t)
+//		resp, err := client.Get("http://localhost/users/1")
+//		...
+//	}
+//
+// The step is named by method and URL of the request, it has "Status" and "Duration" parameters,
+// and attachments with headers and bodies of the request and the response, and the curl command, which repeats the request.
+// The step is added, when the response is returned. The response body is attached to it, when the body is read to the end
+// or closed: body closed before the end is attached as partial, body that is never read or closed is not attached.
+package allurehttp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// DefaultRedactedHeaders are headers, which values are replaced with allure.MaskedParameterValue by default
+var DefaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultRedactedQueryParams are query parameters, which values are replaced with allure.MaskedParameterValue by default
+var DefaultRedactedQueryParams = []string{"access_token", "api_key", "apikey", "password", "secret", "token"}
+
+// DefaultMaxBodySize is the default limit of bodies in attachments (64 KiB)
+const DefaultMaxBodySize = 64 << 10
+
+// Transport is http.RoundTripper, which adds each request as the step.
+// The step is added to the test or the step carried by context of the request (see provider.StepsFromContext),
+// otherwise to steps, which the transport is bound to. Requests without steps are sent without recording.
+type Transport struct {
+	base            http.RoundTripper
+	steps           provider.Steps
+	redactedHeaders map[string]struct{}
+	redactedQuery   map[string]struct{}
+	maxBodySize     int
+}
+
+// NewTransport returns transport, which sends requests with base (http.DefaultTransport, if base is nil)
+// and adds them as steps to steps (provider.T or provider.StepCtx). Steps can be nil, if requests carry them in context
+func NewTransport(steps provider.Steps, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return (&Transport{
+		base:        base,
+		steps:       steps,
+		maxBodySize: DefaultMaxBodySize,
+	}).WithRedactedHeaders(DefaultRedactedHeaders...).WithRedactedQueryParams(DefaultRedactedQueryParams...)
+}
+
+// NewClient returns http.Client with Transport bound to steps
+func NewClient(steps provider.Steps) *http.Client {
+	return &http.Client{Transport: NewTransport(steps, nil)}
+}
+
+// WithRedactedHeaders replaces the list of headers, which values are masked in attachments and the curl command.
+// Names are case-insensitive, DefaultRedactedHeaders are redacted by default
+func (t *Transport) WithRedactedHeaders(names ...string) *Transport {
+	t.redactedHeaders = make(map[string]struct{}, len(names))
+	for _, name := range names {
+		t.redactedHeaders[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+
+	return t
+}
+
+// WithRedactedQueryParams replaces the list of query parameters, which values are masked in the name of the step,
+// attachments and the curl command. Names are case-insensitive, DefaultRedactedQueryParams are redacted by default
+func (t *Transport) WithRedactedQueryParams(names ...string) *Transport {
+	t.redactedQuery = make(map[string]struct{}, len(names))
+	for _, name := range names {
+		t.redactedQuery[strings.ToLower(name)] = struct{}{}
+	}
+
+	return t
+}
+
+// WithMaxBodySize sets the limit of bodies in attachments in bytes, longer bodies are truncated.
+// Zero disables the limit. Bodies are still sent and returned in full
+func (t *Transport) WithMaxBodySize(size int) *Transport {
+	t.maxBodySize = size
+
+	return t
+}
+
+// RoundTrip sends the request with the base transport and adds it as the step, when the response is returned.
+// The response body is attached to the step, when the body is read to the end or closed,
+// so streaming responses are returned to the caller without waiting for the body
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	steps := t.steps
+	if ctxSteps, ok := provider.StepsFromContext(req.Context()); ok {
+		steps = ctxSteps
+	}
+	if steps == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	step := allure.NewSimpleStep(fmt.Sprintf("%s %s", req.Method, t.redactURL(req.URL).Redacted()))
+
+	req, reqBody, reqTruncated, err := t.captureRequestBody(req)
+	if err != nil {
+		_ = req.Body.Close()
+		step.Broken().WithStatusDetails(err.Error(), "")
+		steps.Step(step)
+		return nil, err
+	}
+	step.WithAttachments(
+		allure.NewAttachment("Request Headers", allure.Text, t.requestHeaders(req)),
+		allure.NewAttachment("curl", allure.Text, []byte(t.curl(req, reqBody, reqTruncated))),
+	)
+	if len(reqBody) > 0 {
+		step.WithAttachments(bodyAttachment("Request Body", req.Header.Get("Content-Type"), reqBody, bodyMark(reqTruncated, false)))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+	step.Finish()
+
+	if err != nil {
+		step.WithNewParameters("Duration", duration.String())
+		step.Broken().WithStatusDetails(err.Error(), "")
+		steps.Step(step)
+		return resp, err
+	}
+
+	step.WithNewParameters("Status", resp.Status, "Duration", duration.String())
+	step.WithAttachments(allure.NewAttachment("Response Headers", allure.Text, t.responseHeaders(resp)))
+	steps.Step(step)
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return resp, nil
+	}
+
+	resp.Body = &recordingBody{
+		ReadCloser: resp.Body,
+		limit:      t.maxBodySize,
+		done: func(body []byte, truncated, partial bool, err error) {
+			if err != nil {
+				step.Broken().WithStatusDetails(err.Error(), "")
+			}
+			if len(body) > 0 {
+				step.WithAttachments(bodyAttachment("Response Body", resp.Header.Get("Content-Type"), body, bodyMark(truncated, partial)))
+			}
+		},
+	}
+
+	return resp, nil
+}
+
+// captureRequestBody reads body of the request up to the limit and returns copy of the request with the body,
+// which returns the read part and the rest of the original body
+func (t *Transport) captureRequestBody(req *http.Request) (*http.Request, []byte, bool, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, false, nil
+	}
+
+	body, truncated, reader, err := t.capture(req.Body)
+	req = req.Clone(req.Context())
+	req.Body = reader
+
+	return req, body, truncated, err
+}
+
+// capture reads body up to the limit. Returned reader replays the read part and then the rest of body,
+// if reading failed, it returns the error after the read part
+func (t *Transport) capture(body io.ReadCloser) ([]byte, bool, io.ReadCloser, error) {
+	var (
+		head []byte
+		err  error
+	)
+	if t.maxBodySize > 0 {
+		head, err = io.ReadAll(io.LimitReader(body, int64(t.maxBodySize)+1))
+	} else {
+		head, err = io.ReadAll(body)
+	}
+
+	rest := io.Reader(body)
+	if err != nil {
+		rest = &errReader{err: err}
+	}
+	reader := &replayBody{Reader: io.MultiReader(bytes.NewReader(head), rest), Closer: body}
+	if t.maxBodySize > 0 && len(head) > t.maxBodySize {
+		return head[:t.maxBodySize], true, reader, err
+	}
+
+	return head, false, reader, err
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// replayBody returns the captured part of the body and then the rest of the original one
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// recordingBody keeps the read part of the response body up to the limit and calls done once,
+// when the body is read to the end (or reading fails) or closed. Body is partial, if it isn't read to the end
+type recordingBody struct {
+	io.ReadCloser
+	limit int
+	done  func(body []byte, truncated, partial bool, err error)
+
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	truncated bool
+	once      sync.Once
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	b.mu.Lock()
+	b.record(p[:n])
+	b.mu.Unlock()
+
+	switch {
+	case err == io.EOF:
+		b.finish(false, nil)
+	case err != nil:
+		b.finish(true, err)
+	}
+
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish(true, nil)
+
+	return err
+}
+
+func (b *recordingBody) record(p []byte) {
+	if b.limit > 0 && b.buf.Len()+len(p) > b.limit {
+		b.truncated = b.truncated || len(p) > 0
+		p = p[:b.limit-b.buf.Len()]
+	}
+	b.buf.Write(p)
+}
+
+func (b *recordingBody) finish(partial bool, err error) {
+	b.once.Do(func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.done(b.buf.Bytes(), b.truncated, partial, err)
+	})
+}
@@ -0,0 +1,304 @@
+package allurehttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+var (
+	_ provider.Steps = (provider.T)(nil)
+	_ provider.Steps = (provider.StepCtx)(nil)
+)
+
+type stepsMock struct {
+	mu    sync.Mutex
+	steps []*allure.Step
+}
+
+func (m *stepsMock) Step(step *allure.Step) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.steps = append(m.steps, step)
+}
+
+func (m *stepsMock) NewStep(stepName string, params ...*allure.Parameter) {
+	m.Step(allure.NewSimpleStep(stepName, params...))
+}
+
+func (m *stepsMock) WithAttachments(attachments ...*allure.Attachment) {}
+
+func (m *stepsMock) WithNewAttachment(name string, mimeType allure.MimeType, content []byte) {}
+
+func (m *stepsMock) WithNewStep(stepName string, step func(sCtx provider.StepCtx), params ...*allure.Parameter) {
+}
+
+func attachmentsByName(step *allure.Step) map[string]*allure.Attachment {
+	attachments := make(map[string]*allure.Attachment)
+	for _, attachment := range step.Attachments {
+		attachments[attachment.Name] = attachment
+	}
+	return attachments
+}
+
+func paramsOf(step *allure.Step) map[string]string {
+	params := make(map[string]string)
+	for _, param := range step.Parameters {
+		params[param.Name] = param.GetValue()
+	}
+	return params
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":5,"echo":` + string(body) + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	server := newServer(t)
+	steps := &stepsMock{}
+	client := NewClient(steps)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/users?x=1", strings.NewReader(`{"name":"alice"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":5,"echo":{"name":"alice"}}`, string(body))
+
+	require.Len(t, steps.steps, 1)
+	step := steps.steps[0]
+	require.Equal(t, "POST "+server.URL+"/users?x=1", step.Name)
+	require.Equal(t, allure.Passed, step.Status)
+	params := paramsOf(step)
+	require.Equal(t, "201 Created", params["Status"])
+	require.NotEmpty(t, params["Duration"])
+
+	attachments := attachmentsByName(step)
+	require.Len(t, attachments, 5)
+
+	reqHeaders := string(attachments["Request Headers"].GetContent())
+	require.Contains(t, reqHeaders, "POST /users?x=1 HTTP/1.1\n")
+	require.Contains(t, reqHeaders, "Authorization: ******\n")
+	require.NotContains(t, reqHeaders, "token")
+
+	require.Equal(t, allure.JSON, attachments["Request Body"].Type)
+	require.Equal(t, "{\n  \"name\": \"alice\"\n}", string(attachments["Request Body"].GetContent()))
+
+	respHeaders := string(attachments["Response Headers"].GetContent())
+	require.Contains(t, respHeaders, "HTTP/1.1 201 Created\n")
+	require.Contains(t, respHeaders, "Set-Cookie: ******\n")
+	require.Equal(t, allure.JSON, attachments["Response Body"].Type)
+	require.Contains(t, string(attachments["Response Body"].GetContent()), "\"id\": 5,")
+
+	curl := string(attachments["curl"].GetContent())
+	require.Contains(t, curl, "curl -X 'POST' '"+server.URL+"/users?x=1'")
+	require.Contains(t, curl, "-H 'Authorization: ******'")
+	require.Contains(t, curl, `--data-binary '{"name":"alice"}'`)
+}
+
+func TestTransport_RoundTrip_stepsFromContext(t *testing.T) {
+	server := newServer(t)
+	bound, fromCtx := &stepsMock{}, &stepsMock{}
+	client := NewClient(bound)
+
+	req, err := http.NewRequestWithContext(provider.ContextWithSteps(context.Background(), fromCtx), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.Empty(t, bound.steps)
+	require.Len(t, fromCtx.steps, 1)
+	require.Equal(t, "GET "+server.URL, fromCtx.steps[0].Name)
+	require.NotContains(t, attachmentsByName(fromCtx.steps[0]), "Request Body")
+}
+
+func TestTransport_RoundTrip_noSteps(t *testing.T) {
+	server := newServer(t)
+	client := &http.Client{Transport: NewTransport(nil, nil)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestTransport_RoundTrip_maxBodySize(t *testing.T) {
+	server := newServer(t)
+	steps := &stepsMock{}
+	client := &http.Client{Transport: NewTransport(steps, nil).WithMaxBodySize(4)}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`"abcdef"`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":5,"echo":"abcdef"}`, string(body))
+
+	attachments := attachmentsByName(steps.steps[0])
+	require.Equal(t, `"abc`, string(attachments["Request Body (truncated)"].GetContent()))
+	require.Equal(t, allure.Text, attachments["Request Body (truncated)"].Type)
+	require.Equal(t, `{"id`, string(attachments["Response Body (truncated)"].GetContent()))
+	require.Contains(t, string(attachments["curl"].GetContent()), "# request body is omitted: it is longer than 4 bytes")
+}
+
+func TestTransport_RoundTrip_streaming(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: first\n\n"))
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = w.Write([]byte("data: second\n\n"))
+	}))
+	defer server.Close()
+	steps := &stepsMock{}
+	client := NewClient(steps)
+
+	// the response is returned before the body ends, the step is added with it
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	require.Len(t, steps.steps, 1)
+	require.NotContains(t, attachmentsByName(steps.steps[0]), "Response Body")
+
+	close(release)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "data: first\n\ndata: second\n\n", string(body))
+	require.NoError(t, resp.Body.Close())
+
+	require.Len(t, steps.steps, 1)
+	require.Equal(t, "data: first\n\ndata: second\n\n", string(attachmentsByName(steps.steps[0])["Response Body"].GetContent()))
+}
+
+func TestTransport_RoundTrip_closedBody(t *testing.T) {
+	server := newServer(t)
+	steps := &stepsMock{}
+	client := NewClient(steps)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, resp.Body.Close())
+
+	require.Len(t, steps.steps, 1)
+	require.Equal(t, allure.Passed, steps.steps[0].Status)
+	require.Equal(t, "201 Created", paramsOf(steps.steps[0])["Status"])
+	require.NotContains(t, attachmentsByName(steps.steps[0]), "Response Body")
+}
+
+func TestTransport_RoundTrip_closedBeforeEOF(t *testing.T) {
+	server := newServer(t)
+	steps := &stepsMock{}
+	client := NewClient(steps)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	head := make([]byte, 4)
+	_, err = io.ReadFull(resp.Body, head)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Len(t, steps.steps, 1)
+	attachments := attachmentsByName(steps.steps[0])
+	require.NotContains(t, attachments, "Response Body")
+	require.Equal(t, `{"id`, string(attachments["Response Body (partial)"].GetContent()))
+	require.Equal(t, allure.Text, attachments["Response Body (partial)"].Type)
+}
+
+func TestTransport_RoundTrip_bodyNotRead(t *testing.T) {
+	server := newServer(t)
+	steps := &stepsMock{}
+	client := NewClient(steps)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	// the body is neither read nor closed, the step is added anyway
+	require.Len(t, steps.steps, 1)
+	require.Equal(t, "GET "+server.URL, steps.steps[0].Name)
+	require.Equal(t, "201 Created", paramsOf(steps.steps[0])["Status"])
+	require.Contains(t, attachmentsByName(steps.steps[0]), "Response Headers")
+	require.NotContains(t, attachmentsByName(steps.steps[0]), "Response Body")
+}
+
+func TestTransport_WithRedactedHeaders(t *testing.T) {
+	server := newServer(t)
+	steps := &stepsMock{}
+	client := &http.Client{Transport: NewTransport(steps, nil).WithRedactedHeaders("x-api-key")}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Api-Key", "secret")
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	headers := string(attachmentsByName(steps.steps[0])["Request Headers"].GetContent())
+	require.Contains(t, headers, "X-Api-Key: ******\n")
+	require.Contains(t, headers, "Authorization: Bearer token\n")
+}
+
+func TestTransport_RoundTrip_redactedQuery(t *testing.T) {
+	server := newServer(t)
+	steps := &stepsMock{}
+	client := &http.Client{Transport: NewTransport(steps, nil).WithRedactedQueryParams("sig")}
+
+	resp, err := client.Get(server.URL + "/files?name=a.txt&sig=secret")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	step := steps.steps[0]
+	require.Equal(t, "GET "+server.URL+"/files?name=a.txt&sig=******", step.Name)
+	attachments := attachmentsByName(step)
+	require.Contains(t, string(attachments["Request Headers"].GetContent()), "GET /files?name=a.txt&sig=****** HTTP/1.1\n")
+	require.Contains(t, string(attachments["curl"].GetContent()), "curl '"+server.URL+"/files?name=a.txt&sig=******'")
+	for _, attachment := range attachments {
+		require.NotContains(t, string(attachment.GetContent()), "secret")
+	}
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestTransport_RoundTrip_error(t *testing.T) {
+	steps := &stepsMock{}
+	client := &http.Client{Transport: NewTransport(steps, failingTransport{})}
+
+	_, err := client.Get("http://localhost/users")
+	require.Error(t, err)
+
+	step := steps.steps[0]
+	require.Equal(t, allure.Broken, step.Status)
+	require.Equal(t, "connection refused", step.StatusDetails.Message)
+	require.NotContains(t, paramsOf(step), "Status")
+	require.Contains(t, paramsOf(step), "Duration")
+}
@@ -0,0 +1,257 @@
+package alluresql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// conn adds queries, statements and transactions of the base connection as steps
+type conn struct {
+	base   driver.Conn
+	driver *Driver
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext prepares the statement, which is added as the step, when it's executed
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		s   driver.Stmt
+		err error
+	)
+	if preparer, ok := c.base.(driver.ConnPrepareContext); ok {
+		s, err = preparer.PrepareContext(ctx, query)
+	} else {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		s, err = c.base.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &stmt{base: s, conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return c.base.Close()
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx begins the transaction and adds "Begin" step. Commit and rollback are added as steps to steps of ctx
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	call := c.driver.begin(ctx, "Begin", "", nil, txParams(opts)...)
+
+	var (
+		t   driver.Tx
+		err error
+	)
+	if beginner, ok := c.base.(driver.ConnBeginTx); ok {
+		t, err = beginner.BeginTx(ctx, opts)
+	} else if opts.Isolation != driver.IsolationLevel(0) || opts.ReadOnly {
+		err = errors.New("alluresql: driver does not support non-default isolation level or read-only transactions")
+	} else {
+		t, err = c.base.Begin() //nolint:staticcheck // fallback for drivers without ConnBeginTx
+	}
+	call.end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx{base: t, ctx: ctx, driver: c.driver}, nil
+}
+
+// ExecContext executes the query without preparing, if the base connection supports it.
+// Otherwise, database/sql prepares the statement, which is added as the step
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.base.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	call := c.driver.begin(ctx, "Exec", query, args)
+	res, err := execer.ExecContext(ctx, query, args)
+	if errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	call.end(err, resultParams(res)...)
+
+	return res, err
+}
+
+// QueryContext queries without preparing, if the base connection supports it, see ExecContext
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.base.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	call := c.driver.begin(ctx, "Query", query, args)
+	r, err := queryer.QueryContext(ctx, query, args)
+	if errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	if err != nil {
+		call.end(err)
+		return nil, err
+	}
+
+	return &rows{base: r, call: call}, nil
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if pinger, ok := c.base.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.base.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if validator, ok := c.base.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.base.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
+
+// stmt adds each execution of the prepared statement as the step
+type stmt struct {
+	base  driver.Stmt
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return s.base.Close()
+}
+
+func (s *stmt) NumInput() int {
+	return s.base.NumInput()
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), valuesToNamedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), valuesToNamedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	call := s.conn.driver.begin(ctx, "Exec", s.query, args)
+
+	var (
+		res driver.Result
+		err error
+	)
+	if execer, ok := s.base.(driver.StmtExecContext); ok {
+		res, err = execer.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			res, err = s.base.Exec(values) //nolint:staticcheck // fallback for drivers without StmtExecContext
+		}
+	}
+	call.end(err, resultParams(res)...)
+
+	return res, err
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	call := s.conn.driver.begin(ctx, "Query", s.query, args)
+
+	var (
+		r   driver.Rows
+		err error
+	)
+	if queryer, ok := s.base.(driver.StmtQueryContext); ok {
+		r, err = queryer.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			r, err = s.base.Query(values) //nolint:staticcheck // fallback for drivers without StmtQueryContext
+		}
+	}
+	if err != nil {
+		call.end(err)
+		return nil, err
+	}
+
+	return &rows{base: r, call: call}, nil
+}
+
+// CheckNamedValue checks arguments with the statement or the connection, as database/sql does without the wrapper
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.base.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	if err := s.conn.CheckNamedValue(nv); !errors.Is(err, driver.ErrSkip) {
+		return err
+	}
+	if converter, ok := s.base.(driver.ColumnConverter); ok { //nolint:staticcheck // supported for old drivers
+		value, err := converter.ColumnConverter(nv.Ordinal - 1).ConvertValue(nv.Value)
+		if err != nil {
+			return err
+		}
+		nv.Value = value
+		return nil
+	}
+
+	return driver.ErrSkip
+}
+
+// tx adds commit and rollback of the transaction as steps to steps of context, which began it
+type tx struct {
+	base   driver.Tx
+	ctx    context.Context
+	driver *Driver
+}
+
+func (t *tx) Commit() error {
+	call := t.driver.begin(t.ctx, "Commit", "", nil)
+	err := t.base.Commit()
+	call.end(err)
+
+	return err
+}
+
+func (t *tx) Rollback() error {
+	call := t.driver.begin(t.ctx, "Rollback", "", nil)
+	err := t.base.Rollback()
+	call.end(err)
+
+	return err
+}
+
+func valuesToNamedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+
+	return named
+}
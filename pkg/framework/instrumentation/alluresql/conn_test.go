@@ -0,0 +1,154 @@
+package alluresql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+func testExecAndQuery(t *testing.T, dsn string) {
+	db := openDB(t, dsn)
+	steps := &stepsMock{}
+	ctx := provider.ContextWithSteps(context.Background(), steps)
+
+	res, err := db.ExecContext(ctx, "INSERT INTO users (name, age)\n\tVALUES (?, ?)", "alice", 30)
+	require.NoError(t, err)
+	affected, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), affected)
+
+	rows, err := db.QueryContext(ctx, "SELECT id FROM users")
+	require.NoError(t, err)
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Close())
+	require.Equal(t, []int64{2, 1}, ids)
+
+	require.Equal(t, []string{"Exec: INSERT INTO users (name, age) VALUES (?, ?)", "Query: SELECT id FROM users"}, stepNames(steps.steps))
+
+	exec := paramsOf(steps.steps[0])
+	require.Equal(t, "INSERT INTO users (name, age)\n\tVALUES (?, ?)", exec["SQL"])
+	require.Equal(t, "alice", exec["Arg 1"])
+	require.Equal(t, "30", exec["Arg 2"])
+	require.Equal(t, "3", exec["Rows Affected"])
+	require.NotEmpty(t, exec["Duration"])
+	require.Equal(t, allure.Passed, steps.steps[0].Status)
+
+	query := paramsOf(steps.steps[1])
+	require.Equal(t, "SELECT id FROM users", query["SQL"])
+	require.Equal(t, "2", query["Rows"])
+}
+
+func TestConn_ExecAndQuery(t *testing.T) {
+	testExecAndQuery(t, "ctx")
+}
+
+func TestConn_ExecAndQuery_prepared(t *testing.T) {
+	testExecAndQuery(t, "legacy")
+}
+
+func TestConn_preparedStatement(t *testing.T) {
+	db := openDB(t, "ctx")
+	steps := &stepsMock{}
+	ctx := provider.ContextWithSteps(context.Background(), steps)
+
+	stmt, err := db.PrepareContext(ctx, "DELETE FROM users WHERE id = ?")
+	require.NoError(t, err)
+	defer stmt.Close()
+	for _, id := range []int{1, 2} {
+		_, err = stmt.ExecContext(ctx, id)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, steps.steps, 2)
+	require.Equal(t, "1", paramsOf(steps.steps[0])["Arg 1"])
+	require.Equal(t, "2", paramsOf(steps.steps[1])["Arg 1"])
+}
+
+func TestConn_queryAddedOnClose(t *testing.T) {
+	db := openDB(t, "ctx")
+	steps := &stepsMock{}
+	ctx := provider.ContextWithSteps(context.Background(), steps)
+
+	rows, err := db.QueryContext(ctx, "SELECT id FROM users")
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	require.Empty(t, steps.steps)
+
+	require.NoError(t, rows.Close())
+	require.Equal(t, []string{"Query: SELECT id FROM users"}, stepNames(steps.steps))
+	require.Equal(t, "1", paramsOf(steps.steps[0])["Rows"])
+	require.NotEmpty(t, paramsOf(steps.steps[0])["Duration"])
+	require.Equal(t, allure.Passed, steps.steps[0].Status)
+}
+
+func TestConn_error(t *testing.T) {
+	db := openDB(t, "ctx")
+	steps := &stepsMock{}
+	ctx := provider.ContextWithSteps(context.Background(), steps)
+
+	_, err := db.ExecContext(ctx, "FAIL")
+	require.EqualError(t, err, "syntax error")
+	_, err = db.QueryContext(ctx, "SELECT FAIL")
+	require.EqualError(t, err, "syntax error")
+
+	require.Len(t, steps.steps, 2)
+	for _, step := range steps.steps {
+		require.Equal(t, allure.Broken, step.Status)
+		require.Equal(t, "syntax error", step.StatusDetails.Message)
+	}
+	require.NotContains(t, paramsOf(steps.steps[0]), "Rows Affected")
+}
+
+func TestConn_transaction(t *testing.T) {
+	for _, dsn := range []string{"ctx", "legacy"} {
+		db := openDB(t, dsn)
+		steps := &stepsMock{}
+		ctx := provider.ContextWithSteps(context.Background(), steps)
+
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, "UPDATE users SET age = ?", nil)
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+
+		tx, err = db.BeginTx(ctx, &sql.TxOptions{ReadOnly: dsn == "ctx"})
+		require.NoError(t, err)
+		require.Error(t, tx.Rollback())
+
+		require.Equal(t, []string{"Begin", "Exec: UPDATE users SET age = ?", "Commit", "Begin", "Rollback"}, stepNames(steps.steps), dsn)
+		require.Equal(t, "NULL", paramsOf(steps.steps[1])["Arg 1"])
+		require.Equal(t, allure.Broken, steps.steps[4].Status)
+		if dsn == "ctx" {
+			require.Equal(t, "true", paramsOf(steps.steps[3])["Read Only"])
+		}
+	}
+}
+
+func TestConn_transaction_unsupportedOptions(t *testing.T) {
+	db := openDB(t, "legacy")
+	steps := &stepsMock{}
+
+	_, err := db.BeginTx(provider.ContextWithSteps(context.Background(), steps), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	require.Error(t, err)
+	require.Equal(t, "Serializable", paramsOf(steps.steps[0])["Isolation"])
+	require.Equal(t, allure.Broken, steps.steps[0].Status)
+}
+
+func TestConn_noSteps(t *testing.T) {
+	db := openDB(t, "ctx")
+	_, err := db.ExecContext(context.Background(), "DELETE FROM users")
+	require.NoError(t, err)
+	rows, err := db.Query("SELECT id FROM users")
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+}
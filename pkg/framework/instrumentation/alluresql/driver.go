@@ -0,0 +1,226 @@
+// Package alluresql provides database/sql driver wrapper, which adds queries, statements and transactions
+// as steps of the test or the step carried by their context (see provider.StepsFromContext):
+//
+//	func init() {
+//		alluresql.Register("allure-postgres", "postgres")
+//	}
+//
+//	func (s *SampleSuite) TestCreateUser(t provider.T) {
+//		db, _ := sql.Open("allure-postgres", dsn)
+//		err := s.repo.CreateUser(t.Context(), db, user) // INSERT is added as the step of t
+//		...
+//	}
+//
+// Calls, which context doesn't carry steps (e.g. db.Query instead of db.QueryContext), are added to steps the driver
+// is bound to (see OpenDB and Driver.WithSteps), otherwise they are passed to the driver without recording:
+//
+//	db := alluresql.OpenDB(t, connector)
+//	rows, err := db.Query("SELECT id FROM users") // the query is added as the step of t
+//
+// The step has parameters with SQL text, bound arguments, duration and rows affected (or rows returned by the query).
+// Failed calls make the step broken with the error. The step of the query is added, when its rows are closed.
+package alluresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// MaskArgsFunc reports if the argument of the query must be masked in the report
+type MaskArgsFunc func(query string, arg driver.NamedValue) bool
+
+// MaskAllArgs masks all arguments of all queries
+func MaskAllArgs(string, driver.NamedValue) bool {
+	return true
+}
+
+// MaskNamedArgs returns function, which masks named arguments (sql.Named) with the names
+func MaskNamedArgs(names ...string) MaskArgsFunc {
+	masked := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		masked[name] = struct{}{}
+	}
+
+	return func(_ string, arg driver.NamedValue) bool {
+		_, ok := masked[arg.Name]
+		return ok
+	}
+}
+
+// Driver wraps driver.Driver, its connections add calls as steps
+type Driver struct {
+	mu         sync.Mutex
+	base       driver.Driver
+	driverName string
+	maskArgs   MaskArgsFunc
+	steps      provider.Steps
+}
+
+// Wrap returns driver, which opens connections with base
+func Wrap(base driver.Driver) *Driver {
+	return &Driver{base: base}
+}
+
+// Register wraps registered driver with driverName and registers the wrapper with name:
+//
+//	alluresql.Register("allure-postgres", "postgres")
+//	db, err := sql.Open("allure-postgres", dsn)
+//
+// The wrapped driver is looked up, when the first connection is opened. Like sql.Register, it panics if name is already registered
+func Register(name, driverName string) (*Driver, error) {
+	if !isRegistered(driverName) {
+		return nil, fmt.Errorf("alluresql: unknown driver %q (forgotten import?)", driverName)
+	}
+
+	d := &Driver{driverName: driverName}
+	sql.Register(name, d)
+
+	return d, nil
+}
+
+// WithMaskedArgs sets function, which reports arguments to be masked in the report, e.g. MaskAllArgs or MaskNamedArgs
+func (d *Driver) WithMaskedArgs(mask MaskArgsFunc) *Driver {
+	d.maskArgs = mask
+
+	return d
+}
+
+// WithSteps binds the driver to steps (provider.T or provider.StepCtx): calls without steps in context are added to them.
+// Registered driver is shared by all tests, so bind the driver of the test (Wrap, OpenDB) instead
+func (d *Driver) WithSteps(steps provider.Steps) *Driver {
+	d.steps = steps
+
+	return d
+}
+
+// Open opens connection with the base driver
+func (d *Driver) Open(name string) (driver.Conn, error) {
+	base, err := d.baseDriver(name)
+	if err != nil {
+		return nil, err
+	}
+	c, err := base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conn{base: c, driver: d}, nil
+}
+
+// OpenConnector returns connector of the base driver, if it implements driver.DriverContext
+func (d *Driver) OpenConnector(name string) (driver.Connector, error) {
+	base, err := d.baseDriver(name)
+	if err != nil {
+		return nil, err
+	}
+	if dc, ok := base.(driver.DriverContext); ok {
+		c, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+		return &connector{base: c, driver: d}, nil
+	}
+
+	return &connector{base: dsnConnector{name: name, driver: base}, driver: d}, nil
+}
+
+// baseDriver returns the wrapped driver, registered driver is looked up with sql.Open, which doesn't connect
+func (d *Driver) baseDriver(name string) (driver.Driver, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.base != nil {
+		return d.base, nil
+	}
+
+	db, err := sql.Open(d.driverName, name)
+	if err != nil {
+		return nil, err
+	}
+	d.base = db.Driver()
+
+	return d.base, db.Close()
+}
+
+func isRegistered(driverName string) bool {
+	for _, name := range sql.Drivers() {
+		if name == driverName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WrapConnector wraps connector for sql.OpenDB:
+//
+//	db := sql.OpenDB(alluresql.WrapConnector(connector))
+func WrapConnector(base driver.Connector) driver.Connector {
+	return Wrap(base.Driver()).WrapConnector(base)
+}
+
+// WrapConnector wraps connector, which connections are configured by the driver:
+//
+//	db := sql.OpenDB(alluresql.Wrap(connector.Driver()).WithMaskedArgs(alluresql.MaskAllArgs).WrapConnector(connector))
+func (d *Driver) WrapConnector(base driver.Connector) driver.Connector {
+	return &connector{base: base, driver: d}
+}
+
+// OpenDB returns database with connections of base, which calls are added to steps (provider.T or provider.StepCtx),
+// if their context doesn't carry steps:
+//
+//	db := alluresql.OpenDB(t, connector)
+//	defer db.Close()
+func OpenDB(steps provider.Steps, base driver.Connector) *sql.DB {
+	return sql.OpenDB(Wrap(base.Driver()).WithSteps(steps).WrapConnector(base))
+}
+
+type connector struct {
+	base   driver.Connector
+	driver *Driver
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conn{base: cn, driver: c.driver}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// dsnConnector is the connector of drivers, which don't implement driver.DriverContext
+type dsnConnector struct {
+	name   string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// namedValuesToValues converts arguments for deprecated methods of drivers, which don't support named arguments
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, fmt.Errorf("alluresql: driver does not support the use of Named Parameters")
+		}
+		values[i] = arg.Value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,244 @@
+package alluresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+func init() {
+	sql.Register("alluresql-fake", fakeDriver{})
+	if _, err := Register("allure-fake", "alluresql-fake"); err != nil {
+		panic(err)
+	}
+}
+
+// fakeDriver opens connections, which implement context interfaces of the driver, or only basic ones with "legacy" dsn
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	if name == "legacy" {
+		return &fakeConn{}, nil
+	}
+	return &fakeCtxConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{sql: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+type fakeCtxConn struct {
+	fakeConn
+}
+
+func (c *fakeCtxConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return (&fakeStmt{sql: query}).exec()
+}
+
+func (c *fakeCtxConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return (&fakeStmt{sql: query}).query()
+}
+
+func (c *fakeCtxConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (c *fakeCtxConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+type fakeStmt struct {
+	sql string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return s.exec()
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return s.query()
+}
+
+func (s *fakeStmt) exec() (driver.Result, error) {
+	if strings.Contains(s.sql, "FAIL") {
+		return nil, errors.New("syntax error")
+	}
+	return driver.RowsAffected(3), nil
+}
+
+func (s *fakeStmt) query() (driver.Rows, error) {
+	if strings.Contains(s.sql, "FAIL") {
+		return nil, errors.New("syntax error")
+	}
+	return &fakeRows{left: 2}, nil
+}
+
+type fakeRows struct {
+	left int
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"id"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.left == 0 {
+		return io.EOF
+	}
+	dest[0] = int64(r.left)
+	r.left--
+	return nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error {
+	return nil
+}
+
+func (fakeTx) Rollback() error {
+	return errors.New("already committed")
+}
+
+type stepsMock struct {
+	mu    sync.Mutex
+	steps []*allure.Step
+}
+
+func (m *stepsMock) Step(step *allure.Step) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.steps = append(m.steps, step)
+}
+
+func (m *stepsMock) NewStep(stepName string, params ...*allure.Parameter) {
+	m.Step(allure.NewSimpleStep(stepName, params...))
+}
+
+func (m *stepsMock) WithAttachments(attachments ...*allure.Attachment) {}
+
+func (m *stepsMock) WithNewAttachment(name string, mimeType allure.MimeType, content []byte) {}
+
+func (m *stepsMock) WithNewStep(stepName string, step func(sCtx provider.StepCtx), params ...*allure.Parameter) {
+}
+
+func paramsOf(step *allure.Step) map[string]string {
+	params := make(map[string]string)
+	for _, param := range step.Parameters {
+		params[param.Name] = param.GetValue()
+	}
+	return params
+}
+
+func stepNames(steps []*allure.Step) []string {
+	names := make([]string, 0, len(steps))
+	for _, step := range steps {
+		names = append(names, step.Name)
+	}
+	return names
+}
+
+func openDB(t *testing.T, dsn string) *sql.DB {
+	db, err := sql.Open("allure-fake", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestRegister(t *testing.T) {
+	_, err := Register("allure-unknown", "unknown")
+	require.EqualError(t, err, `alluresql: unknown driver "unknown" (forgotten import?)`)
+
+	db := openDB(t, "ctx")
+	require.IsType(t, &Driver{}, db.Driver())
+	require.NoError(t, db.Ping())
+}
+
+func TestDriver_WithMaskedArgs(t *testing.T) {
+	c, err := Wrap(fakeDriver{}).WithMaskedArgs(MaskNamedArgs("password")).OpenConnector("ctx")
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	steps := &stepsMock{}
+	ctx := provider.ContextWithSteps(context.Background(), steps)
+	_, err = db.ExecContext(ctx, "UPDATE users SET password = @password WHERE name = @name",
+		sql.Named("password", "secret"), sql.Named("name", "alice"))
+	require.NoError(t, err)
+
+	require.Len(t, steps.steps, 1)
+	params := steps.steps[0].Parameters
+	require.Equal(t, "Arg password", params[1].Name)
+	require.True(t, params[1].IsMasked())
+	require.Equal(t, "Arg name", params[2].Name)
+	require.False(t, params[2].IsMasked())
+	require.Equal(t, "alice", params[2].GetValue())
+}
+
+func TestMaskAllArgs(t *testing.T) {
+	require.True(t, MaskAllArgs("SELECT 1", driver.NamedValue{Ordinal: 1}))
+
+	mask := MaskNamedArgs("token")
+	require.True(t, mask("", driver.NamedValue{Name: "token"}))
+	require.False(t, mask("", driver.NamedValue{Ordinal: 1}))
+}
+
+func TestWrapConnector(t *testing.T) {
+	db := sql.OpenDB(WrapConnector(dsnConnector{name: "legacy", driver: fakeDriver{}}))
+	defer db.Close()
+
+	steps := &stepsMock{}
+	_, err := db.ExecContext(provider.ContextWithSteps(context.Background(), steps), "DELETE FROM users")
+	require.NoError(t, err)
+	require.Len(t, steps.steps, 1)
+}
+
+func TestOpenDB(t *testing.T) {
+	steps := &stepsMock{}
+	db := OpenDB(steps, dsnConnector{name: "ctx", driver: fakeDriver{}})
+	defer db.Close()
+
+	// no steps in context: the call is added to steps of the driver
+	_, err := db.Exec("DELETE FROM users")
+	require.NoError(t, err)
+
+	// steps in context have priority
+	ctxSteps := &stepsMock{}
+	_, err = db.ExecContext(provider.ContextWithSteps(context.Background(), ctxSteps), "DELETE FROM users")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"Exec: DELETE FROM users"}, stepNames(steps.steps))
+	require.Equal(t, []string{"Exec: DELETE FROM users"}, stepNames(ctxSteps.steps))
+}
@@ -0,0 +1,232 @@
+package alluresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// maxStepNameLength is the limit of SQL text in the name of the step, full text is in "SQL" parameter
+const maxStepNameLength = 80
+
+// call is the call of the driver, which is added as the step, when it's ended
+type call struct {
+	steps provider.Steps
+	step  *allure.Step
+	start time.Time
+}
+
+// begin starts the call, if ctx carries steps or the driver is bound to steps, otherwise returns nil, which ends without recording
+func (d *Driver) begin(ctx context.Context, name, query string, args []driver.NamedValue, params ...*allure.Parameter) *call {
+	steps := d.steps
+	if ctxSteps, ok := provider.StepsFromContext(ctx); ok {
+		steps = ctxSteps
+	}
+	if steps == nil {
+		return nil
+	}
+
+	step := allure.NewSimpleStep(stepName(name, query))
+	if query != "" {
+		step.WithParameters(allure.NewParameter("SQL", query))
+	}
+	step.WithParameters(d.argParams(query, args)...)
+	step.WithParameters(params...)
+
+	return &call{steps: steps, step: step, start: time.Now()}
+}
+
+// end adds the step with duration and params. Failed call makes the step broken
+func (c *call) end(err error, params ...*allure.Parameter) {
+	if c == nil {
+		return
+	}
+
+	c.step.Finish()
+	c.step.WithNewParameters("Duration", time.Since(c.start).String())
+	c.step.WithParameters(params...)
+	if err != nil {
+		c.step.Broken().WithStatusDetails(err.Error(), "")
+	}
+	c.steps.Step(c.step)
+}
+
+// stepName returns name of the step with the SQL text collapsed to one line
+func stepName(name, query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if query == "" {
+		return name
+	}
+	if utf8.RuneCountInString(query) > maxStepNameLength {
+		query = string([]rune(query)[:maxStepNameLength]) + "..."
+	}
+
+	return name + ": " + query
+}
+
+// argParams returns parameters of the arguments: "Arg 1" for positional and "Arg name" for named ones
+func (d *Driver) argParams(query string, args []driver.NamedValue) []*allure.Parameter {
+	params := make([]*allure.Parameter, 0, len(args))
+	for _, arg := range args {
+		name := fmt.Sprintf("Arg %d", arg.Ordinal)
+		if arg.Name != "" {
+			name = "Arg " + arg.Name
+		}
+
+		if d.maskArgs != nil && d.maskArgs(query, arg) {
+			params = append(params, allure.NewMaskedParameter(name, formatArg(arg.Value)))
+		} else {
+			params = append(params, allure.NewParameter(name, formatArg(arg.Value)))
+		}
+	}
+
+	return params
+}
+
+// formatArg formats the argument: strings are quoted, binary data is printed in hex, nil is NULL
+func formatArg(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []byte:
+		if utf8.Valid(v) {
+			return fmt.Sprintf("%q", v)
+		}
+		return fmt.Sprintf("0x%x", v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func txParams(opts driver.TxOptions) []*allure.Parameter {
+	var params []*allure.Parameter
+	if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) {
+		params = append(params, allure.NewParameter("Isolation", sql.IsolationLevel(opts.Isolation).String()))
+	}
+	if opts.ReadOnly {
+		params = append(params, allure.NewParameter("Read Only", true))
+	}
+
+	return params
+}
+
+func resultParams(res driver.Result) []*allure.Parameter {
+	if res == nil {
+		return nil
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil
+	}
+
+	return allure.NewParameters("Rows Affected", affected)
+}
+
+// rows counts rows returned by the query. The step of the query is added with "Rows" parameter, when they are closed.
+// Error of reading the rows makes the step broken
+type rows struct {
+	base  driver.Rows
+	call  *call
+	count int
+	err   error
+	once  sync.Once
+}
+
+func (r *rows) Columns() []string {
+	return r.base.Columns()
+}
+
+func (r *rows) Close() error {
+	err := r.base.Close()
+	r.once.Do(func() {
+		if r.err == nil {
+			r.err = err
+		}
+		r.call.end(r.err, allure.NewParameter("Rows", r.count))
+	})
+
+	return err
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	err := r.base.Next(dest)
+	switch {
+	case err == nil:
+		r.count++
+	case !errors.Is(err, io.EOF):
+		r.err = err
+	}
+
+	return err
+}
+
+func (r *rows) HasNextResultSet() bool {
+	if next, ok := r.base.(driver.RowsNextResultSet); ok {
+		return next.HasNextResultSet()
+	}
+
+	return false
+}
+
+func (r *rows) NextResultSet() error {
+	if next, ok := r.base.(driver.RowsNextResultSet); ok {
+		return next.NextResultSet()
+	}
+
+	return io.EOF
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	if ct, ok := r.base.(driver.RowsColumnTypeScanType); ok {
+		return ct.ColumnTypeScanType(index)
+	}
+
+	return reflect.TypeOf(new(interface{})).Elem()
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	if ct, ok := r.base.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return ct.ColumnTypeDatabaseTypeName(index)
+	}
+
+	return ""
+}
+
+func (r *rows) ColumnTypeLength(index int) (int64, bool) {
+	if ct, ok := r.base.(driver.RowsColumnTypeLength); ok {
+		return ct.ColumnTypeLength(index)
+	}
+
+	return 0, false
+}
+
+func (r *rows) ColumnTypeNullable(index int) (bool, bool) {
+	if ct, ok := r.base.(driver.RowsColumnTypeNullable); ok {
+		return ct.ColumnTypeNullable(index)
+	}
+
+	return false, false
+}
+
+func (r *rows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if ct, ok := r.base.(driver.RowsColumnTypePrecisionScale); ok {
+		return ct.ColumnTypePrecisionScale(index)
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,27 @@
+package alluresql
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepName(t *testing.T) {
+	require.Equal(t, "Commit", stepName("Commit", ""))
+	require.Equal(t, "Query: SELECT * FROM users WHERE id = $1", stepName("Query", "SELECT *\n  FROM users\n  WHERE id = $1"))
+
+	long := "SELECT " + strings.Repeat("a", 100)
+	require.Equal(t, "Query: "+long[:80]+"...", stepName("Query", long))
+}
+
+func TestFormatArg(t *testing.T) {
+	require.Equal(t, "NULL", formatArg(nil))
+	require.Equal(t, `"it's"`, formatArg("it's"))
+	require.Equal(t, `"abc"`, formatArg([]byte("abc")))
+	require.Equal(t, "0xfffe", formatArg([]byte{0xff, 0xfe}))
+	require.Equal(t, "2024-01-02T03:04:05Z", formatArg(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	require.Equal(t, "42", formatArg(int64(42)))
+	require.Equal(t, "true", formatArg(true))
+}
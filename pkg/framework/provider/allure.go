@@ -65,6 +65,7 @@ type Attachments interface {
 type Parameters interface {
 	WithParameters(params ...*allure.Parameter)
 	WithNewParameters(kv ...interface{})
+	WithNewMaskedParameters(kv ...interface{})
 }
 
 type AllureForward interface {
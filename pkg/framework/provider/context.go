@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// Steps is implemented by T and StepCtx. It allows to add steps and attachments to the test or the current step
+type Steps interface {
+	AllureSteps
+	Attachments
+
+	WithNewStep(stepName string, step func(sCtx StepCtx), params ...*allure.Parameter)
+}
+
+type stepsContextKey struct{}
+
+// ContextWithSteps returns copy of ctx, which carries steps (the test or the current step)
+func ContextWithSteps(ctx context.Context, steps Steps) context.Context {
+	return context.WithValue(ctx, stepsContextKey{}, steps)
+}
+
+// StepsFromContext returns the test or the current step carried by ctx.
+// Instrumentation code uses it to add steps to the report without being handed T or StepCtx explicitly.
+func StepsFromContext(ctx context.Context) (Steps, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	steps, ok := ctx.Value(stepsContextKey{}).(Steps)
+
+	return steps, ok
+}
@@ -1,10 +1,14 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/asserts_wrapper/matcher"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,17 +35,26 @@ type T interface {
 	SkipOnPrint()
 	Assert() Asserts
 	Require() Asserts
+	SoftAssert(stepName string, body func(a Asserts))
+	SoftRequire(stepName string, body func(a Asserts))
+	Expect(actual interface{}) matcher.Expectation
 	Run(testName string, testBody func(T), tags ...string) *allure.Result
 
 	LogStep(args ...interface{})
 	LogfStep(format string, args ...interface{})
 	WithNewStep(stepName string, step func(sCtx StepCtx), params ...*allure.Parameter)
 	WithNewAsyncStep(stepName string, step func(sCtx StepCtx), params ...*allure.Parameter)
+	WithNewStepTimeout(stepName string, timeout time.Duration, step func(sCtx StepCtx), params ...*allure.Parameter)
 	WithTestSetup(setup func(T))
 	WithTestTeardown(teardown func(T))
 
 	// GetCurrentTestResult returns the current test result (available in AfterEach hook)
 	GetCurrentTestResult() (*allure.CurrentResult, bool)
+
+	// Context returns context of the test. It is derived from context of the suite (parent test)
+	// and canceled on FailNow/BrokenNow, on timeout and when the test is completed.
+	// The context carries the test, see StepsFromContext.
+	Context() context.Context
 }
 
 type StepCtx interface {
@@ -49,15 +62,20 @@ type StepCtx interface {
 	NewStep(stepName string, parameters ...*allure.Parameter)
 	WithNewStep(stepName string, step func(sCtx StepCtx), params ...*allure.Parameter)
 	WithNewAsyncStep(stepName string, step func(sCtx StepCtx), params ...*allure.Parameter)
+	WithNewStepTimeout(stepName string, timeout time.Duration, step func(sCtx StepCtx), params ...*allure.Parameter)
 
 	WithParameters(parameters ...*allure.Parameter)
 	WithNewParameters(kv ...interface{})
+	WithNewMaskedParameters(kv ...interface{})
 
 	WithAttachments(attachment ...*allure.Attachment)
 	WithNewAttachment(name string, mimeType allure.MimeType, content []byte)
 
 	Assert() Asserts
 	Require() Asserts
+	SoftAssert(stepName string, body func(a Asserts))
+	SoftRequire(stepName string, body func(a Asserts))
+	Expect(actual interface{}) matcher.Expectation
 
 	LogStep(args ...interface{})
 	LogfStep(format string, args ...interface{})
@@ -76,6 +94,10 @@ type StepCtx interface {
 	Break(args ...interface{})
 	Breakf(format string, args ...interface{})
 	Name() string
+
+	// Context returns context of the test, which carries the step, see StepsFromContext.
+	// Context of the step with timeout is also canceled when the timeout expires.
+	Context() context.Context
 }
 
 // Asserts ...
@@ -120,4 +142,24 @@ type Asserts interface {
 	NotZero(i interface{}, msgAndArgs ...interface{})
 	InDelta(expected, actual interface{}, delta float64, msgAndArgs ...interface{})
 	Eventually(condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{})
+	Panics(f assert.PanicTestFunc, msgAndArgs ...interface{})
+	NotPanics(f assert.PanicTestFunc, msgAndArgs ...interface{})
+	PanicsWithError(errString string, f assert.PanicTestFunc, msgAndArgs ...interface{})
+	ErrorContains(theError error, contains string, msgAndArgs ...interface{})
+	FileExists(path string, msgAndArgs ...interface{})
+	NoFileExists(path string, msgAndArgs ...interface{})
+	NotRegexp(rx interface{}, str interface{}, msgAndArgs ...interface{})
+	InEpsilon(expected, actual interface{}, epsilon float64, msgAndArgs ...interface{})
+	InDeltaSlice(expected, actual interface{}, delta float64, msgAndArgs ...interface{})
+	YAMLEq(expected, actual string, msgAndArgs ...interface{})
+	NotElementsMatch(listA interface{}, listB interface{}, msgAndArgs ...interface{})
+	Never(condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	EventuallyWithT(condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{})
+	Positive(e interface{}, msgAndArgs ...interface{})
+	Negative(e interface{}, msgAndArgs ...interface{})
+	IsIncreasing(object interface{}, msgAndArgs ...interface{})
+	IsDecreasing(object interface{}, msgAndArgs ...interface{})
+	HTTPStatusCode(handler http.HandlerFunc, method, url string, values url.Values, statuscode int, msgAndArgs ...interface{})
+	HTTPBodyContains(handler http.HandlerFunc, method, url string, values url.Values, str interface{}, msgAndArgs ...interface{})
+	Expect(actual interface{}) matcher.Expectation
 }
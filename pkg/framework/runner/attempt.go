@@ -0,0 +1,257 @@
+package runner
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// attemptT wraps *testing.T of the test to run one attempt of the test in separate goroutine.
+// Soft attempt (the one that can be retried) doesn't fail the test. Its errors are only logged.
+// FailNow and Skip stop the attempt's goroutine only; the runner decides what to do next.
+// After the attempt is finished, calls from abandoned (timed out) goroutines are ignored.
+// Inline attempt runs on the goroutine of the test and passes FailNow and Skip to testing.T.
+type attemptT struct {
+	*testing.T
+
+	soft     bool
+	inline   bool
+	parallel *sync.Once
+
+	// resumed receives a signal when the test is resumed after Parallel call
+	resumed chan struct{}
+
+	mu              sync.Mutex
+	failed          bool
+	skipped         bool
+	skipMsg         string
+	finished        bool
+	waitingParallel bool
+}
+
+func newAttemptT(t *testing.T, soft bool, parallel *sync.Once) *attemptT {
+	return &attemptT{T: t, soft: soft, parallel: parallel, resumed: make(chan struct{}, 1)}
+}
+
+// run runs body in new goroutine (in the current one for inline attempt) and waits for it
+func (a *attemptT) run(body func()) {
+	a.runWithTimeout(0, body)
+}
+
+// runWithTimeout runs body in new goroutine and waits for it at most timeout (if timeout is positive).
+// Returns false if the timeout expired. The goroutine is abandoned in this case.
+// Inline attempt has no timeout, its body is called in the current goroutine.
+func (a *attemptT) runWithTimeout(timeout time.Duration, body func()) bool {
+	if a.inline {
+		body()
+		return true
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		body()
+	}()
+
+	if timeout <= 0 {
+		<-done
+		return true
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-done:
+			return true
+
+		// time spent waiting for parallel tests start is not counted
+		case <-a.resumed:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(timeout)
+
+		case <-timer.C:
+			select {
+			case <-a.resumed:
+				timer.Reset(timeout)
+			default:
+				if !a.isWaitingParallel() {
+					return false
+				}
+			}
+		}
+	}
+}
+
+func (a *attemptT) isWaitingParallel() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.waitingParallel
+}
+
+func (a *attemptT) setWaitingParallel(waiting bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.waitingParallel = waiting
+}
+
+func (a *attemptT) isFinished() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.finished
+}
+
+// Parallel signals that the test is to be run in parallel. Only the first call is passed to testing.T
+func (a *attemptT) Parallel() {
+	a.parallel.Do(func() {
+		a.setWaitingParallel(true)
+		a.T.Parallel()
+
+		select {
+		case a.resumed <- struct{}{}:
+		default:
+		}
+		a.setWaitingParallel(false)
+	})
+}
+
+func (a *attemptT) Fail() {
+	a.mu.Lock()
+	a.failed = true
+	finished := a.finished
+	a.mu.Unlock()
+
+	if !a.soft && !finished {
+		a.T.Fail()
+	}
+}
+
+func (a *attemptT) FailNow() {
+	a.Fail()
+	if a.inline {
+		a.T.FailNow()
+	}
+	runtime.Goexit()
+}
+
+func (a *attemptT) Failed() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.failed
+}
+
+func (a *attemptT) Error(args ...interface{}) {
+	a.T.Helper()
+	a.log(fmt.Sprintln(args...))
+	a.Fail()
+}
+
+func (a *attemptT) Errorf(format string, args ...interface{}) {
+	a.T.Helper()
+	a.log(fmt.Sprintf(format, args...))
+	a.Fail()
+}
+
+func (a *attemptT) Fatal(args ...interface{}) {
+	a.T.Helper()
+	a.log(fmt.Sprintln(args...))
+	a.FailNow()
+}
+
+func (a *attemptT) Fatalf(format string, args ...interface{}) {
+	a.T.Helper()
+	a.log(fmt.Sprintf(format, args...))
+	a.FailNow()
+}
+
+func (a *attemptT) Skip(args ...interface{}) {
+	a.T.Helper()
+	a.skip(fmt.Sprintln(args...))
+}
+
+func (a *attemptT) Skipf(format string, args ...interface{}) {
+	a.T.Helper()
+	a.skip(fmt.Sprintf(format, args...))
+}
+
+func (a *attemptT) SkipNow() {
+	a.skip("")
+}
+
+func (a *attemptT) Skipped() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.skipped
+}
+
+func (a *attemptT) skip(msg string) {
+	a.mu.Lock()
+	a.skipped = true
+	a.skipMsg = msg
+	a.mu.Unlock()
+
+	if a.inline {
+		a.passSkip()
+	}
+	runtime.Goexit()
+}
+
+func (a *attemptT) Log(args ...interface{}) {
+	if a.isFinished() {
+		return
+	}
+
+	a.T.Helper()
+	a.T.Log(args...)
+}
+
+func (a *attemptT) Logf(format string, args ...interface{}) {
+	if a.isFinished() {
+		return
+	}
+
+	a.T.Helper()
+	a.T.Logf(format, args...)
+}
+
+func (a *attemptT) log(msg string) {
+	a.T.Helper()
+	if a.soft {
+		msg = "[attempt failed, will be retried] " + msg
+	}
+	a.Log(msg)
+}
+
+// finish closes the attempt and passes its skip to testing.T. Must be called from the test's goroutine
+func (a *attemptT) finish() {
+	a.mu.Lock()
+	a.finished = true
+	a.mu.Unlock()
+
+	// inline attempt has passed the skip already
+	if a.inline || !a.Skipped() {
+		return
+	}
+
+	a.passSkip()
+}
+
+func (a *attemptT) passSkip() {
+	if a.skipMsg != "" {
+		a.T.Skip(a.skipMsg)
+	}
+	a.T.SkipNow()
+}
@@ -0,0 +1,102 @@
+package runner
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttemptT_softFail(t *testing.T) {
+	var reached bool
+
+	at := newAttemptT(t, true, &sync.Once{})
+	at.run(func() {
+		at.Errorf("error %d", 1)
+		at.FailNow()
+		reached = true
+	})
+
+	require.True(t, at.Failed())
+	require.False(t, reached)
+	require.False(t, t.Failed())
+}
+
+func TestAttemptT_skip(t *testing.T) {
+	var reached bool
+
+	at := newAttemptT(t, true, &sync.Once{})
+	at.run(func() {
+		at.Skip("skip")
+		reached = true
+	})
+
+	require.True(t, at.Skipped())
+	require.False(t, at.Failed())
+	require.False(t, reached)
+	require.False(t, t.Skipped())
+}
+
+func TestAttemptT_inline(t *testing.T) {
+	var (
+		inner              *testing.T
+		reached, afterBody bool
+	)
+
+	t.Run("skipped", func(t *testing.T) {
+		inner = t
+		at := newAttemptT(t, false, &sync.Once{})
+		at.inline = true
+		defer at.finish()
+
+		at.run(func() {
+			at.Skip("skip")
+			reached = true
+		})
+		afterBody = true
+	})
+
+	// skip is passed to testing.T and stops the goroutine of the test
+	require.True(t, inner.Skipped())
+	require.False(t, reached)
+	require.False(t, afterBody)
+
+	at := newAttemptT(t, false, &sync.Once{})
+	at.inline = true
+	require.True(t, at.runWithTimeout(0, func() {}))
+}
+
+func TestAttemptT_parallelOnce(t *testing.T) {
+	once := &sync.Once{}
+	first := newAttemptT(t, true, once)
+	second := newAttemptT(t, false, once)
+
+	require.NotPanics(t, func() {
+		first.run(first.Parallel)
+		second.run(second.Parallel)
+	})
+}
+
+func TestAttemptT_runWithTimeout(t *testing.T) {
+	at := newAttemptT(t, true, &sync.Once{})
+	require.True(t, at.runWithTimeout(time.Second, func() {}))
+
+	release := make(chan struct{})
+	defer close(release)
+	require.False(t, at.runWithTimeout(10*time.Millisecond, func() { <-release }))
+}
+
+func TestAttemptT_finished(t *testing.T) {
+	at := newAttemptT(t, false, &sync.Once{})
+	at.finish()
+
+	require.NotPanics(t, func() {
+		at.run(func() {
+			at.Log("ignored")
+			at.Fail()
+		})
+	})
+	require.True(t, at.Failed())
+	require.False(t, t.Failed())
+}
@@ -0,0 +1,69 @@
+package runner
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ozontech/allure-go/pkg/framework/core/dataprovider"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// DataProviderTag is the struct tag of suite's Param field with path to the data file of the table test,
+// e.g. `dataprovider:"testdata/cities.yaml"`. Cases of the file are added after values of the field.
+const DataProviderTag = "dataprovider"
+
+// NewDataProviderTest registers one test per case of the data file (see dataprovider.Load for supported formats).
+// Cases are decoded into T and named like cases of NewParametrizedTest. Source file and row of the case
+// are recorded as parameters of its result. Malformed rows and unreadable file are reported as broken tests.
+func NewDataProviderTest[T any](r TestRunner, testName, path string, body func(t provider.T, c T), opts ...TestOption) {
+	registrar, ok := r.(parametrizedRegistrar)
+	if !ok {
+		panic(fmt.Sprintf("runner %T doesn't support parametrized tests", r))
+	}
+
+	var (
+		packageName = getPackage(defaultPackageDepth)
+		options     = newTestOptions(opts...)
+		namer       = newParamNamer(testName)
+		zero        T
+	)
+
+	rows, err := dataprovider.Load(path, reflect.TypeOf(&zero).Elem())
+	if err != nil {
+		registrar.newBrokenCase(testName, testName, err, packageName, options)
+		return
+	}
+
+	for i, row := range rows {
+		if row.Err != nil {
+			caseName := namer.name(i, nil, fmt.Sprintf("%s_%s", testName, row.Name()))
+			registrar.newBrokenCase(testName, caseName, row.Err, packageName, options, row.Parameters()...)
+			continue
+		}
+
+		value := castCase[T](row.Value)
+		caseName := namer.name(i, value, caseTitle(value, options))
+		registrar.newParametrizedCase(testName, caseName, value, func(t provider.T) { body(t, value) }, packageName, options, row.Parameters()...)
+	}
+}
+
+// loadTableParams loads cases of the table test from the data file set by DataProviderTag.
+// Malformed rows and unreadable file are returned as params with error and preset name
+func loadTableParams(path, paramName string, typ reflect.Type) []tableParam {
+	rows, err := dataprovider.Load(path, typ)
+	if err != nil {
+		return []tableParam{{name: paramName, err: err}}
+	}
+
+	params := make([]tableParam, 0, len(rows))
+	for _, row := range rows {
+		param := tableParam{value: row.Value, err: row.Err, extra: row.Parameters()}
+		if row.Err != nil {
+			param.name = fmt.Sprintf("%s_%s", paramName, row.Name())
+		}
+
+		params = append(params, param)
+	}
+
+	return params
+}
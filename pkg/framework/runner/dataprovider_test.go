@@ -0,0 +1,95 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/dataprovider"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+func TestNewDataProviderTest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cities.csv")
+	require.NoError(t, os.WriteFile(path, []byte("name,population\nMoscow,12\nParis,many\n"), 0o644))
+
+	r := NewRunner(t, "suite").(*runner)
+
+	var got []cityCase
+	NewDataProviderTest(r, "Cities", path, func(t provider.T, c cityCase) {
+		got = append(got, c)
+	}, WithRetries(2), WithCaseName(func(c cityCase) string { return c.Name }))
+
+	require.Equal(t, []string{
+		"TestNewDataProviderTest/Cities/Moscow",
+		"TestNewDataProviderTest/Cities/Cities_cities.csv:2",
+	}, r.orderedTestNames())
+
+	valid := r.tests["TestNewDataProviderTest/Cities/Moscow"]
+	valid.GetBody()(nil)
+	require.Equal(t, []cityCase{{"Moscow", 12}}, got)
+	require.Equal(t, []*allure.Parameter{
+		allure.NewParameter("Name", "Moscow"),
+		allure.NewParameter("Population", 12),
+		allure.NewExcludedParameter(dataprovider.SourceParameterName, path),
+		allure.NewExcludedParameter(dataprovider.RowParameterName, "1 (line 2)"),
+	}, valid.GetMeta().GetResult().Parameters)
+	require.Equal(t, 2, getRetries(valid))
+
+	broken := r.tests["TestNewDataProviderTest/Cities/Cities_cities.csv:2"]
+	require.Equal(t, 0, getRetries(broken))
+	require.Equal(t, valid.GetMeta().GetResult().TestCaseID, broken.GetMeta().GetResult().TestCaseID)
+	require.Equal(t, []*allure.Parameter{
+		allure.NewExcludedParameter(dataprovider.SourceParameterName, path),
+		allure.NewExcludedParameter(dataprovider.RowParameterName, "2 (line 3)"),
+	}, broken.GetMeta().GetResult().Parameters)
+}
+
+func TestNewDataProviderTest_missingFile(t *testing.T) {
+	r := NewRunner(t, "suite").(*runner)
+
+	NewDataProviderTest(r, "Cities", filepath.Join(t.TempDir(), "missing.yaml"), func(t provider.T, c cityCase) {})
+	require.Equal(t, []string{"TestNewDataProviderTest_missingFile/Cities"}, r.orderedTestNames())
+}
+
+type dataProviderSuite struct {
+	runner      TestRunner
+	ParamCities []cityCase `dataprovider:"cities.json"`
+	ParamBroken []cityCase `dataprovider:"missing.csv"`
+}
+
+func (s *dataProviderSuite) GetRunner() TestRunner  { return s.runner }
+func (s *dataProviderSuite) SetRunner(r TestRunner) { s.runner = r }
+
+func (s *dataProviderSuite) TableTestCities(t provider.T, c cityCase) {}
+func (s *dataProviderSuite) TableTestBroken(t provider.T, c cityCase) {}
+
+func TestSuiteRunner_dataProvider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cities.json"), []byte(`[{"Name": "Moscow"}, {"Name": 1}]`), 0o644))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	r := NewSuiteRunner(t, "package", "suite", &dataProviderSuite{}).(*suiteRunner)
+	r.adjustTableTests()
+
+	require.Equal(t, []string{"Broken", "Cities_0", "Cities_cities.json:2"}, r.orderedTestNames())
+
+	for _, name := range []string{"Broken", "Cities_cities.json:2"} {
+		test := r.tests[name]
+		require.IsType(t, &testFunc{}, test)
+		require.Equal(t, 0, getRetries(test))
+	}
+
+	require.Equal(t, []*allure.Parameter{
+		allure.NewExcludedParameter(dataprovider.SourceParameterName, "cities.json"),
+		allure.NewExcludedParameter(dataprovider.RowParameterName, "2"),
+	}, r.tests["Cities_cities.json:2"].GetMeta().GetResult().Parameters)
+	require.IsType(t, &testMethod{}, r.tests["Cities_0"])
+}
@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"fmt"
+
+	"github.com/ozontech/allure-go/pkg/framework/core/common"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// FixtureScope is the lifetime of the fixture's value
+type FixtureScope = common.FixtureScope
+
+// Fixture scopes
+const (
+	// TestScope fixture is created for every test requesting it and torn down after the test's AfterEach hook
+	TestScope = common.TestScope
+	// SuiteScope fixture is shared by tests of the suite and torn down after the suite's AfterAll hook
+	SuiteScope = common.SuiteScope
+	// PackageScope fixture is shared by all tests of the package and torn down by TeardownPackageFixtures
+	PackageScope = common.PackageScope
+)
+
+// Fixture is the value of type T, which is set up on the first request in its scope and shared within it
+type Fixture[T any] struct {
+	def *common.FixtureDef
+}
+
+// FixtureOption customizes the fixture
+type FixtureOption[T any] func(def *common.FixtureDef)
+
+// WithFixtureTeardown sets teardown of the fixture's value. Teardown is run once per set up value
+func WithFixtureTeardown[T any](teardown func(ctx provider.StepCtx, value T)) FixtureOption[T] {
+	return func(def *common.FixtureDef) {
+		def.Teardown = func(ctx provider.StepCtx, value interface{}) {
+			teardown(ctx, value.(T))
+		}
+	}
+}
+
+// NewFixture returns fixture, which value is created by setup. Setup is reported as step `Setup fixture <name>`
+// in befores of the scope's container (test, suite or package one) and teardown as step `Teardown fixture <name>` in its afters.
+// Setup may get other fixtures with t, as long as their scope isn't narrower. Setup error breaks every test requesting the fixture.
+func NewFixture[T any](name string, scope FixtureScope, setup func(t provider.T, ctx provider.StepCtx) (T, error), opts ...FixtureOption[T]) *Fixture[T] {
+	def := &common.FixtureDef{
+		Name:  name,
+		Scope: scope,
+		Setup: func(t provider.T, ctx provider.StepCtx) (interface{}, error) {
+			return setup(t, ctx)
+		},
+	}
+
+	for _, opt := range opts {
+		opt(def)
+	}
+
+	return &Fixture[T]{def: def}
+}
+
+// Name returns name of the fixture
+func (f *Fixture[T]) Name() string {
+	return f.def.Name
+}
+
+// Scope returns scope of the fixture
+func (f *Fixture[T]) Scope() FixtureScope {
+	return f.def.Scope
+}
+
+// Get returns value of the fixture in the scope of the test, setting it up if needed
+func (f *Fixture[T]) Get(t provider.T) T {
+	t.Helper()
+
+	ft, ok := t.(common.FixtureT)
+	if !ok {
+		panic(fmt.Sprintf("test %T doesn't support fixtures", t))
+	}
+
+	value, _ := ft.GetFixture(f.def).(T)
+
+	return value
+}
+
+// TeardownPackageFixtures tears down fixtures with PackageScope and prints their container.
+// Call it in TestMain after m.Run
+func TeardownPackageFixtures() {
+	common.TeardownPackageFixtures()
+}
@@ -0,0 +1,66 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/common"
+	"github.com/ozontech/allure-go/pkg/framework/core/constants"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+func TestNewFixture(t *testing.T) {
+	var tornDown int
+	f := NewFixture("number", SuiteScope,
+		func(t provider.T, ctx provider.StepCtx) (int, error) {
+			return 42, nil
+		},
+		WithFixtureTeardown(func(ctx provider.StepCtx, value int) {
+			tornDown = value
+		}),
+	)
+
+	require.Equal(t, "number", f.Name())
+	require.Equal(t, SuiteScope, f.Scope())
+
+	value, err := f.def.Setup(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 42, value)
+
+	f.def.Teardown(nil, 42)
+	require.Equal(t, 42, tornDown)
+}
+
+func TestFixture_Get(t *testing.T) {
+	f := NewFixture("number", TestScope, func(t provider.T, ctx provider.StepCtx) (int, error) {
+		return 42, nil
+	})
+
+	testT := &common.Common{
+		TestingT: t,
+		Provider: &providerMockRunner{
+			executionMock: newExecContextRunnerMock(constants.TestContextName),
+			testMetaMock:  &testMetaMockRunner{result: allure.NewResult("test", "test"), container: allure.NewContainer()},
+			suiteMetaMock: &suiteMetaMockRunner{container: allure.NewContainer()},
+		},
+	}
+	require.Equal(t, 42, f.Get(testT))
+	require.Len(t, testT.Provider.GetTestMeta().GetContainer().Befores, 1)
+	testT.TeardownFixtures(common.TestScope)
+}
+
+type noFixturesT struct {
+	provider.T
+}
+
+func (noFixturesT) Helper() {}
+
+func TestFixture_Get_unsupported(t *testing.T) {
+	f := NewFixture("number", TestScope, func(t provider.T, ctx provider.StepCtx) (int, error) {
+		return 42, nil
+	})
+
+	require.Panics(t, func() { f.Get(noFixturesT{}) })
+}
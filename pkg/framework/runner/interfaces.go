@@ -1,10 +1,14 @@
 package runner
 
 import (
+	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/common"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 )
 
@@ -38,12 +42,49 @@ type AllureIDSuite interface {
 	GetAllureID(testName string) string
 }
 
+// RetrySuite has a GetRetries method,
+// which returns number of retries for the failed test by its name.
+// Negative value means global default (-allure-go.retries flag or ALLURE_RETRIES)
+type RetrySuite interface {
+	GetRetries(testName string) int
+}
+
+// TimeoutSuite has a GetTimeout method,
+// which returns timeout for the test by its name. Zero value means no timeout
+type TimeoutSuite interface {
+	GetTimeout(testName string) time.Duration
+}
+
+// TagsSuite has a GetTags method, which returns tags of the test by its name.
+// Tags are known before the test is scheduled, so tag expression can select suite methods by them
+type TagsSuite interface {
+	GetTags(testName string) []string
+}
+
 // ParametrizedSuite suit can initialize parameters for
 // parametrized test before running hooks
 type ParametrizedSuite interface {
 	InitializeTestsParams()
 }
 
+// GenericParametrizedSuite has a ParametrizedTests method, which registers parametrized tests
+// of the suite with NewParametrizedTest. It is called after BeforeAll, so cases can be prepared there.
+type GenericParametrizedSuite interface {
+	ParametrizedTests(r TestRunner)
+}
+
+// ParamNameSuite has a GetParamName method, which returns name of the case of table test.
+// The method gets name of the table test method, the param and its index in the params slice.
+// Empty string means default name
+type ParamNameSuite interface {
+	GetParamName(testName string, param interface{}, index int) string
+}
+
+// ListenersSuite has a GetListeners method, which returns listeners of the suite's events
+type ListenersSuite interface {
+	GetListeners() []listener.Listener
+}
+
 // ParametrizedTestParam parameter for parametrized test
 // with custom AllureId and Title
 type ParametrizedTestParam interface {
@@ -65,10 +106,12 @@ type TestingT interface {
 
 type TestRunner interface {
 	NewTest(testName string, testBody func(provider.T), tags ...string)
+	NewTestWithOptions(testName string, testBody func(provider.T), opts ...TestOption)
 	BeforeEach(hookBody func(provider.T))
 	AfterEach(hookBody func(provider.T))
 	BeforeAll(hookBody func(provider.T))
 	AfterAll(hookBody func(provider.T))
+	AddListener(listeners ...listener.Listener)
 	RunTests() SuiteResult
 }
 
@@ -100,4 +143,9 @@ type internalT interface {
 	GetProvider() provider.Provider
 	WG() *sync.WaitGroup
 	GetResult() *allure.Result
+	TeardownFixtures(scope common.FixtureScope)
+	SetContext(parent context.Context)
+	CancelContext()
+	SetListeners(listeners listener.Set)
+	Listener() listener.Set
 }
@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/common"
+)
+
+const (
+	retriesEnvKey   = "ALLURE_RETRIES"
+	interruptEnvKey = "ALLURE_INTERRUPT"
+)
+
+var (
+	retriesFlag   = flag.Int("allure-go.retries", -1, "number of retries for failed tests (overrides "+retriesEnvKey+")")
+	interruptFlag = flag.Bool("allure-go.interrupt", false, "cancel contexts of running tests on interrupt (same as "+interruptEnvKey+"=true)")
+)
+
+// TestOption configures single test registered with TestRunner.NewTestWithOptions
+type TestOption func(opts *testOptions)
+
+type testOptions struct {
+	tags    []string
+	retries int
+	timeout time.Duration
+
+	// parametrized test cases
+	caseName     func(c interface{}) string
+	caseAllureID func(c interface{}) string
+	caseLabels   func(c interface{}) []*allure.Label
+}
+
+func newTestOptions(opts ...TestOption) *testOptions {
+	options := &testOptions{retries: -1}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithTags adds tags to the test
+func WithTags(tags ...string) TestOption {
+	return func(opts *testOptions) {
+		opts.tags = append(opts.tags, tags...)
+	}
+}
+
+// WithRetries sets how many times the test will be retried after failed attempt.
+// Overrides suite (RetrySuite) and global (-allure-go.retries, ALLURE_RETRIES) settings.
+func WithRetries(retries int) TestOption {
+	return func(opts *testOptions) {
+		opts.retries = retries
+	}
+}
+
+// WithTimeout sets timeout of the test (before each hook and test body).
+// When it expires, the test is marked broken, goroutine dump is attached and after each hook is executed.
+func WithTimeout(timeout time.Duration) TestOption {
+	return func(opts *testOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// getDefaultRetries returns global number of retries.
+// -allure-go.retries flag has priority over ALLURE_RETRIES environment variable
+func getDefaultRetries() int {
+	if retriesFlag != nil && *retriesFlag >= 0 {
+		return *retriesFlag
+	}
+
+	if retries, err := strconv.Atoi(os.Getenv(retriesEnvKey)); err == nil && retries > 0 {
+		return retries
+	}
+
+	return 0
+}
+
+// HandleInterrupt makes the first interrupt of the test binary cancel contexts of running tests,
+// so they can stop and write their results. The second interrupt stops the binary as usual.
+// It's off by default, because it replaces the interrupt handler of the whole process.
+// Call it in TestMain or enable with -allure-go.interrupt flag or ALLURE_INTERRUPT=true
+func HandleInterrupt() {
+	common.HandleInterrupt()
+}
+
+// handleInterruptEnabled returns true, if interrupt handling is enabled with -allure-go.interrupt flag
+// or ALLURE_INTERRUPT environment variable
+func handleInterruptEnabled() bool {
+	if interruptFlag != nil && *interruptFlag {
+		return true
+	}
+
+	enabled, _ := strconv.ParseBool(os.Getenv(interruptEnvKey))
+
+	return enabled
+}
+
+// handleInterruptIfEnabled calls HandleInterrupt, if it's enabled with the flag or the environment variable
+func handleInterruptIfEnabled() {
+	if handleInterruptEnabled() {
+		HandleInterrupt()
+	}
+}
@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTestOptions(t *testing.T) {
+	opts := newTestOptions()
+	require.Equal(t, -1, opts.retries)
+	require.Empty(t, opts.tags)
+
+	opts = newTestOptions(WithTags("a", "b"), WithRetries(2), WithTags("c"), WithTimeout(time.Second))
+	require.Equal(t, 2, opts.retries)
+	require.Equal(t, time.Second, opts.timeout)
+	require.Equal(t, []string{"a", "b", "c"}, opts.tags)
+}
+
+func TestGetDefaultRetries(t *testing.T) {
+	t.Setenv(retriesEnvKey, "")
+	require.Equal(t, 0, getDefaultRetries())
+
+	t.Setenv(retriesEnvKey, "3")
+	require.Equal(t, 3, getDefaultRetries())
+
+	t.Setenv(retriesEnvKey, "wrong")
+	require.Equal(t, 0, getDefaultRetries())
+
+	*retriesFlag = 1
+	defer func() { *retriesFlag = -1 }()
+	t.Setenv(retriesEnvKey, "3")
+	require.Equal(t, 1, getDefaultRetries())
+}
+
+func TestHandleInterruptEnabled(t *testing.T) {
+	t.Setenv(interruptEnvKey, "")
+	require.False(t, handleInterruptEnabled())
+
+	t.Setenv(interruptEnvKey, "true")
+	require.True(t, handleInterruptEnabled())
+
+	t.Setenv(interruptEnvKey, "wrong")
+	require.False(t, handleInterruptEnabled())
+
+	*interruptFlag = true
+	defer func() { *interruptFlag = false }()
+	require.True(t, handleInterruptEnabled())
+}
+
+func TestGetRetries(t *testing.T) {
+	t.Setenv(retriesEnvKey, "2")
+	require.Equal(t, 2, getRetries(&testFunc{retries: -1}))
+	require.Equal(t, 0, getRetries(&testFunc{retries: 0}))
+	require.Equal(t, 5, getRetries(&testMethod{retries: 5}))
+}
+
+func TestGetTimeout(t *testing.T) {
+	require.Equal(t, time.Duration(0), getTimeout(&testFunc{}))
+	require.Equal(t, time.Second, getTimeout(&testMethod{timeout: time.Second}))
+}
@@ -0,0 +1,176 @@
+package runner
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const shuffleEnvKey = "ALLURE_SHUFFLE"
+
+var shuffleFlag = flag.String("allure-go.shuffle", "", "randomize execution order of tests: off, on or seed (overrides "+shuffleEnvKey+")")
+
+var (
+	shuffleOnce sync.Once
+	shuffleSeed int64
+	shuffleOn   bool
+	shuffleErr  error
+)
+
+// getShuffleSeed returns seed to shuffle tests with and false if shuffling is off.
+// Invalid value of the flag or the environment variable is returned as error
+func getShuffleSeed() (int64, bool, error) {
+	shuffleOnce.Do(func() {
+		value := os.Getenv(shuffleEnvKey)
+		if shuffleFlag != nil && *shuffleFlag != "" {
+			value = *shuffleFlag
+		}
+
+		shuffleSeed, shuffleOn, shuffleErr = parseShuffle(value)
+		if shuffleOn {
+			fmt.Printf("allure-go: tests are shuffled with seed %d (use -allure-go.shuffle=%d to reproduce the order)\n", shuffleSeed, shuffleSeed)
+		}
+	})
+
+	return shuffleSeed, shuffleOn, shuffleErr
+}
+
+// parseShuffle parses value of shuffle mode: off (or empty), on (seed is current time) or seed
+func parseShuffle(value string) (int64, bool, error) {
+	switch value {
+	case "", "off":
+		return 0, false, nil
+	case "on":
+		return time.Now().UnixNano(), true, nil
+	}
+
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid value for -allure-go.shuffle (%s): %q, expected off, on or seed", shuffleEnvKey, value)
+	}
+
+	return seed, true, nil
+}
+
+// addTest registers the test keeping declaration order
+func (r *runner) addTest(name string, test Test) {
+	if _, ok := r.tests[name]; !ok {
+		r.order = append(r.order, name)
+	}
+	r.tests[name] = test
+}
+
+// orderedTestNames returns names of the tests in declaration order.
+// Tests added to the map directly go last in alphabetical order.
+func (r *runner) orderedTestNames() []string {
+	var (
+		names = make([]string, 0, len(r.tests))
+		seen  = make(map[string]bool, len(r.tests))
+		rest  []string
+	)
+
+	for _, name := range r.order {
+		if _, ok := r.tests[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	for name := range r.tests {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(names, rest...)
+}
+
+// orderedTests returns the tests in declaration order
+func (r *runner) orderedTests() []Test {
+	return r.testsByNames(r.orderedTestNames())
+}
+
+// runOrder returns the tests in order of execution: declaration order or shuffled one if shuffle mode is on
+func (r *runner) runOrder() ([]Test, error) {
+	seed, ok, err := getShuffleSeed()
+	if err != nil {
+		return nil, err
+	}
+
+	names := r.orderedTestNames()
+	if ok {
+		shuffleNames(names, seed)
+	}
+
+	return r.testsByNames(names), nil
+}
+
+// shuffleNames shuffles names in place. The same seed gives the same order
+func shuffleNames(names []string, seed int64) {
+	rand.New(rand.NewSource(seed)).Shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+}
+
+func (r *runner) testsByNames(names []string) []Test {
+	tests := make([]Test, 0, len(names))
+	for _, name := range names {
+		tests = append(tests, r.tests[name])
+	}
+
+	return tests
+}
+
+// paramNamer generates unique names for cases of parametrized test
+type paramNamer struct {
+	testName string
+	used     map[string]bool
+}
+
+func newParamNamer(testName string) *paramNamer {
+	return &paramNamer{testName: testName, used: make(map[string]bool)}
+}
+
+// name returns `<testName>_<param>` for simple values and fmt.Stringer implementations
+// and `<testName>_<index>` for others (e.g. structs). Custom name is used as is, if it's not empty.
+// Duplicated names get `#<index>` suffix.
+func (n *paramNamer) name(index int, param interface{}, custom string) string {
+	name := custom
+	if name == "" {
+		name = fmt.Sprintf("%s_%s", n.testName, paramString(index, param))
+	}
+
+	if n.used[name] {
+		name = fmt.Sprintf("%s#%d", name, index)
+	}
+	n.used[name] = true
+
+	return name
+}
+
+func paramString(index int, param interface{}) string {
+	if stringer, ok := param.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	if param == nil {
+		return "nil"
+	}
+
+	switch reflect.TypeOf(param).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return fmt.Sprintf("%+v", param)
+	}
+
+	return strconv.Itoa(index)
+}
@@ -0,0 +1,97 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+type stringerParam struct {
+	id int
+}
+
+func (p stringerParam) String() string {
+	return "param"
+}
+
+func TestRunner_orderedTestNames(t *testing.T) {
+	r := NewRunner(t, "suite").(*runner)
+
+	for _, name := range []string{"c", "a", "b"} {
+		r.NewTest(name, func(t provider.T) {})
+	}
+	// tests added directly to the map go last in alphabetical order
+	r.tests["z"] = r.tests["TestRunner_orderedTestNames/a"]
+	r.tests["y"] = r.tests["TestRunner_orderedTestNames/a"]
+	delete(r.tests, "TestRunner_orderedTestNames/b")
+
+	require.Equal(t, []string{
+		"TestRunner_orderedTestNames/c",
+		"TestRunner_orderedTestNames/a",
+		"y",
+		"z",
+	}, r.orderedTestNames())
+	require.Len(t, r.orderedTests(), 4)
+}
+
+func TestShuffleNames(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	first := append([]string(nil), names...)
+	shuffleNames(first, 42)
+	second := append([]string(nil), names...)
+	shuffleNames(second, 42)
+
+	require.Equal(t, first, second)
+	require.ElementsMatch(t, names, first)
+}
+
+func TestParseShuffle(t *testing.T) {
+	for _, value := range []string{"", "off"} {
+		_, on, err := parseShuffle(value)
+		require.NoError(t, err)
+		require.False(t, on)
+	}
+
+	_, on, err := parseShuffle("on")
+	require.NoError(t, err)
+	require.True(t, on)
+
+	seed, on, err := parseShuffle("42")
+	require.NoError(t, err)
+	require.True(t, on)
+	require.Equal(t, int64(42), seed)
+
+	_, on, err = parseShuffle("yes")
+	require.EqualError(t, err, `invalid value for -allure-go.shuffle (ALLURE_SHUFFLE): "yes", expected off, on or seed`)
+	require.False(t, on)
+}
+
+func TestParamNamer(t *testing.T) {
+	namer := newParamNamer("Test")
+
+	require.Equal(t, "Test_1", namer.name(0, 1, ""))
+	require.Equal(t, "Test_1#1", namer.name(1, 1, ""))
+	require.Equal(t, "Test_text", namer.name(2, "text", ""))
+	require.Equal(t, "Test_param", namer.name(3, stringerParam{id: 1}, ""))
+	require.Equal(t, "Test_param#4", namer.name(4, stringerParam{id: 2}, ""))
+	require.Equal(t, "Test_5", namer.name(5, cityCase{Name: "Moscow"}, ""))
+	require.Equal(t, "Test_6", namer.name(6, &cityCase{Name: "Paris"}, ""))
+	require.Equal(t, "Test_nil", namer.name(7, nil, ""))
+	require.Equal(t, "custom", namer.name(8, 1, "custom"))
+	require.Equal(t, "custom#9", namer.name(9, 2, "custom"))
+}
+
+func TestNewParametrizedTest_order(t *testing.T) {
+	r := NewRunner(t, "suite").(*runner)
+
+	NewParametrizedTest(r, "Numbers", []int{3, 1, 3, 2}, func(t provider.T, n int) {})
+	require.Equal(t, []string{
+		"TestNewParametrizedTest_order/Numbers/Numbers_3",
+		"TestNewParametrizedTest_order/Numbers/Numbers_1",
+		"TestNewParametrizedTest_order/Numbers/Numbers_3#2",
+		"TestNewParametrizedTest_order/Numbers/Numbers_2",
+	}, r.orderedTestNames())
+}
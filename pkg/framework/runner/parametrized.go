@@ -0,0 +1,160 @@
+package runner
+
+import (
+	"fmt"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/adapter"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// NewParametrizedTest registers one test per case. Every case is run as a separate test
+// with the case passed to the body, so types of cases and body are checked at compile time.
+//
+// By default, the case is named `<testName>_<case>` for simple values and fmt.Stringer implementations
+// and `<testName>_<index>` for other types. Cases with the same name get `#<index>` suffix. Use WithCaseName, WithCaseAllureID and WithCaseLabels
+// options (or implement ParametrizedTestParam by the case type) to customize results of the cases.
+// Other test options (tags, retries, timeout) are applied to every case.
+//
+// Use it with runner from NewRunner before RunTests call, or inside ParametrizedTests method of GenericParametrizedSuite.
+func NewParametrizedTest[T any](r TestRunner, testName string, cases []T, body func(t provider.T, c T), opts ...TestOption) {
+	registrar, ok := r.(parametrizedRegistrar)
+	if !ok {
+		panic(fmt.Sprintf("runner %T doesn't support parametrized tests", r))
+	}
+
+	var (
+		packageName = getPackage(defaultPackageDepth)
+		options     = newTestOptions(opts...)
+		namer       = newParamNamer(testName)
+	)
+
+	for i, c := range cases {
+		value := c
+		caseName := namer.name(i, value, caseTitle(value, options))
+		registrar.newParametrizedCase(testName, caseName, value, func(t provider.T) { body(t, value) }, packageName, options)
+	}
+}
+
+// WithCaseName sets function that returns display name of the case of parametrized test
+func WithCaseName[T any](name func(c T) string) TestOption {
+	return func(opts *testOptions) {
+		opts.caseName = func(c interface{}) string { return name(castCase[T](c)) }
+	}
+}
+
+// WithCaseAllureID sets function that returns ALLURE_ID of the case of parametrized test
+func WithCaseAllureID[T any](id func(c T) string) TestOption {
+	return func(opts *testOptions) {
+		opts.caseAllureID = func(c interface{}) string { return id(castCase[T](c)) }
+	}
+}
+
+// WithCaseLabels sets function that returns additional labels of the case of parametrized test
+func WithCaseLabels[T any](labels func(c T) []*allure.Label) TestOption {
+	return func(opts *testOptions) {
+		opts.caseLabels = func(c interface{}) []*allure.Label { return labels(castCase[T](c)) }
+	}
+}
+
+func castCase[T any](c interface{}) T {
+	value, ok := c.(T)
+	if !ok {
+		var expected T
+		panic(fmt.Sprintf("case option expects case of type %T, got %T", expected, c))
+	}
+
+	return value
+}
+
+type parametrizedRegistrar interface {
+	newParametrizedCase(testName, caseName string, c interface{}, body TestBody, packageName string, options *testOptions, params ...*allure.Parameter)
+	newBrokenCase(testName, caseName string, err error, packageName string, options *testOptions, params ...*allure.Parameter)
+}
+
+// caseTitle returns custom name of the case set by WithCaseName or ParametrizedTestParam, empty if it's not set.
+// It's passed to paramNamer, so cases with the same title get unique names
+func caseTitle(c interface{}, options *testOptions) string {
+	if options.caseName != nil {
+		return options.caseName(c)
+	}
+
+	if ptp, ok := c.(ParametrizedTestParam); ok {
+		return ptp.GetAllureTitle()
+	}
+
+	return ""
+}
+
+// newParametrizedCase registers the case of parametrized test as separate test.
+// Params are added to the result after parameters of the case
+func (r *runner) newParametrizedCase(testName, caseName string, c interface{}, body TestBody, packageName string, options *testOptions, params ...*allure.Parameter) {
+	var (
+		allureID string
+		labels   []*allure.Label
+	)
+
+	if ptp, ok := c.(ParametrizedTestParam); ok {
+		allureID = ptp.GetAllureID()
+	}
+
+	if options.caseAllureID != nil {
+		allureID = options.caseAllureID(c)
+	}
+
+	if options.caseLabels != nil {
+		labels = options.caseLabels(c)
+	}
+
+	testMeta, testFullName := r.newCaseMeta(testName, caseName, packageName, options)
+	if allureID != "" {
+		testMeta.GetResult().AddLabel(allure.IDAllureLabel(allureID))
+	}
+	testMeta.GetResult().AddLabel(labels...)
+	withCaseParameters(testMeta.GetResult(), testFullName, testName, c)
+	testMeta.GetResult().Parameters = append(testMeta.GetResult().Parameters, params...)
+
+	if !r.toRun(testMeta.GetResult()) {
+		return
+	}
+
+	r.addTest(testMeta.GetResult().FullName, newTestFunc(body, testMeta, options))
+}
+
+// newBrokenCase registers the case, which can't be prepared (e.g. malformed row of data file).
+// The case is reported broken with the error and is not retried
+func (r *runner) newBrokenCase(testName, caseName string, err error, packageName string, options *testOptions, params ...*allure.Parameter) {
+	testMeta, testFullName := r.newCaseMeta(testName, caseName, packageName, options)
+	testMeta.GetResult().Parameters = append(testMeta.GetResult().Parameters, params...)
+	testMeta.GetResult().WithTestCaseFullName(testFullName)
+
+	if !r.toRun(testMeta.GetResult()) {
+		return
+	}
+
+	brokenOptions := *options
+	brokenOptions.retries = 0
+	r.addTest(testMeta.GetResult().FullName, newTestFunc(brokenCaseBody(err), testMeta, &brokenOptions))
+}
+
+// newCaseMeta creates meta of the case of parametrized test. Returns full name of the test
+func (r *runner) newCaseMeta(testName, caseName, packageName string, options *testOptions) (*adapter.TestAdapter, string) {
+	suiteMeta := r.t().GetProvider().GetSuiteMeta()
+	testFullName := fmt.Sprintf("%s/%s", suiteMeta.GetSuiteFullName(), testName)
+
+	return adapter.NewTestMeta(testFullName, suiteMeta.GetSuiteName(), caseName, packageName, options.tags...), testFullName
+}
+
+// brokenCaseBody returns body of the test, which reports it broken with the error
+func brokenCaseBody(err error) TestBody {
+	return func(t provider.T) {
+		t.Breakf("%v", err)
+	}
+}
+
+// withCaseParameters records the case as parameters of the result (see allure.NewParametersFromValue).
+// All cases of the test share TestCaseID based on the test's full name, HistoryID depends on the parameters
+func withCaseParameters(result *allure.Result, testFullName, paramName string, c interface{}) {
+	result.Parameters = append(result.Parameters, allure.NewParametersFromValue(paramName, c)...)
+	result.WithTestCaseFullName(testFullName).UpdateHistoryID()
+}
@@ -0,0 +1,135 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+type cityCase struct {
+	Name       string
+	Population int
+}
+
+type titledCase struct {
+	id string
+}
+
+func (c titledCase) GetAllureID() string {
+	return c.id
+}
+
+func (c titledCase) GetAllureTitle() string {
+	return "case " + c.id
+}
+
+func TestNewParametrizedTest(t *testing.T) {
+	r := NewRunner(t, "suite").(*runner)
+
+	var got []cityCase
+	cases := []cityCase{{"Moscow", 12}, {"Paris", 2}}
+	NewParametrizedTest(r, "Cities", cases, func(t provider.T, c cityCase) {
+		got = append(got, c)
+	},
+		WithTags("cities"),
+		WithRetries(1),
+		WithCaseName(func(c cityCase) string { return c.Name }),
+		WithCaseAllureID(func(c cityCase) string { return c.Name + "_id" }),
+		WithCaseLabels(func(c cityCase) []*allure.Label { return []*allure.Label{allure.OwnerLabel(c.Name)} }),
+	)
+
+	require.Len(t, r.tests, 2)
+	for _, c := range cases {
+		test, ok := r.tests["TestNewParametrizedTest/Cities/"+c.Name]
+		require.True(t, ok)
+
+		result := test.GetMeta().GetResult()
+		require.Equal(t, c.Name, result.Name)
+
+		id, ok := result.GetFirstLabel(allure.AllureID)
+		require.True(t, ok)
+		require.Equal(t, c.Name+"_id", id.GetValue())
+
+		owner, ok := result.GetFirstLabel(allure.Owner)
+		require.True(t, ok)
+		require.Equal(t, c.Name, owner.GetValue())
+
+		tag, ok := result.GetFirstLabel(allure.Tag)
+		require.True(t, ok)
+		require.Equal(t, "cities", tag.GetValue())
+
+		require.Equal(t, 1, getRetries(test))
+
+		test.GetBody()(nil)
+	}
+	require.ElementsMatch(t, cases, got)
+}
+
+func TestNewParametrizedTest_defaultNames(t *testing.T) {
+	r := NewRunner(t, "suite").(*runner)
+
+	NewParametrizedTest(r, "Numbers", []int{1, 2}, func(t provider.T, n int) {})
+	require.Contains(t, r.tests, "TestNewParametrizedTest_defaultNames/Numbers/Numbers_1")
+	require.Contains(t, r.tests, "TestNewParametrizedTest_defaultNames/Numbers/Numbers_2")
+
+	NewParametrizedTest(r, "Titled", []titledCase{{id: "42"}}, func(t provider.T, c titledCase) {})
+	test, ok := r.tests["TestNewParametrizedTest_defaultNames/Titled/case 42"]
+	require.True(t, ok)
+	id, ok := test.GetMeta().GetResult().GetFirstLabel(allure.AllureID)
+	require.True(t, ok)
+	require.Equal(t, "42", id.GetValue())
+	require.Equal(t, "github.com/ozontech/allure-go/pkg/framework/runner", test.GetMeta().GetResult().GetLabels(allure.Package)[0].GetValue())
+}
+
+func TestNewParametrizedTest_duplicatedTitles(t *testing.T) {
+	r := NewRunner(t, "suite").(*runner)
+
+	NewParametrizedTest(r, "Cities", []cityCase{{"Moscow", 12}, {"Moscow", 13}}, func(t provider.T, c cityCase) {},
+		WithCaseName(func(c cityCase) string { return c.Name }))
+	require.Contains(t, r.tests, "TestNewParametrizedTest_duplicatedTitles/Cities/Moscow")
+	require.Contains(t, r.tests, "TestNewParametrizedTest_duplicatedTitles/Cities/Moscow#1")
+
+	NewParametrizedTest(r, "Titled", []titledCase{{id: "42"}, {id: "42"}}, func(t provider.T, c titledCase) {})
+	require.Contains(t, r.tests, "TestNewParametrizedTest_duplicatedTitles/Titled/case 42")
+	require.Contains(t, r.tests, "TestNewParametrizedTest_duplicatedTitles/Titled/case 42#1")
+	require.Len(t, r.tests, 4)
+}
+
+func TestNewParametrizedTest_wrongCaseOption(t *testing.T) {
+	r := NewRunner(t, "suite")
+
+	require.Panics(t, func() {
+		NewParametrizedTest(r, "Numbers", []int{1}, func(t provider.T, n int) {},
+			WithCaseName(func(s string) string { return s }))
+	})
+}
+
+type credentialsCase struct {
+	Login    string
+	Password string `allure:",masked"`
+}
+
+func TestNewParametrizedTest_parameters(t *testing.T) {
+	r := NewRunner(t, "suite").(*runner)
+
+	cases := []credentialsCase{{"alice", "qwerty"}, {"bob", "qwerty"}}
+	NewParametrizedTest(r, "Login", cases, func(t provider.T, c credentialsCase) {})
+
+	first := r.tests["TestNewParametrizedTest_parameters/Login/Login_0"].GetMeta().GetResult()
+	second := r.tests["TestNewParametrizedTest_parameters/Login/Login_1"].GetMeta().GetResult()
+
+	require.Equal(t, []*allure.Parameter{
+		allure.NewParameter("Login", "alice"),
+		allure.NewMaskedParameter("Password", "qwerty"),
+	}, first.Parameters)
+	require.Equal(t, first.TestCaseID, second.TestCaseID)
+	require.Equal(t, allure.NewResult("Login", "TestNewParametrizedTest_parameters/Login").TestCaseID, first.TestCaseID)
+	require.NotEqual(t, first.HistoryID, second.HistoryID)
+
+	NewParametrizedTest(r, "Numbers", []int{1}, func(t provider.T, n int) {})
+	numbers := r.tests["TestNewParametrizedTest_parameters/Numbers/Numbers_1"].GetMeta().GetResult()
+	require.Equal(t, []*allure.Parameter{allure.NewParameter("Numbers", 1)}, numbers.Parameters)
+}
@@ -8,12 +8,15 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/adapter"
 	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/manager"
+	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/tagfilter"
 	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/testplan"
 	"github.com/ozontech/allure-go/pkg/framework/core/common"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 )
 
@@ -21,6 +24,7 @@ type runner struct {
 	internalT        internalT
 	testPlan         *testplan.TestPlan
 	tests            map[string]Test
+	order            []string
 	adjustTableTests func()
 }
 
@@ -50,9 +54,12 @@ func (r *runner) realT() TestingT {
 	return r.t().RealT()
 }
 
+// toRun checks that the test is selected by tag expression.
+// Test plan is checked later (see filterByTestPlan), when all labels of the test are known
 func (r *runner) toRun(result *allure.Result) bool {
-	if r.testPlan != nil {
-		return r.testPlan.IsSelected(result.TestCaseID, result.FullName)
+	if !tagfilter.IsSelected(result) {
+		logDropped(result, "it is not selected by tag expression")
+		return false
 	}
 
 	return true
@@ -62,10 +69,14 @@ func (r *runner) filterByTestPlan() map[string]Test {
 	if plan := r.testPlan; plan != nil {
 		tests := make(map[string]Test, len(r.tests))
 
-		for fullName, testData := range r.tests {
-			if r.testPlan.IsSelected(testData.GetMeta().GetResult().TestCaseID, testData.GetMeta().GetResult().FullName) {
+		for _, fullName := range r.orderedTestNames() {
+			testData := r.tests[fullName]
+			if plan.IsSelectedResult(testData.GetMeta().GetResult()) {
 				tests[fullName] = testData
+				continue
 			}
+
+			logDropped(testData.GetMeta().GetResult(), "it is not selected in test plan")
 		}
 
 		return tests
@@ -74,22 +85,44 @@ func (r *runner) filterByTestPlan() map[string]Test {
 	return r.tests
 }
 
+// logDropped prints the test that will not be run
+func logDropped(result *allure.Result, reason string) {
+	id := ""
+	if label, ok := result.GetFirstLabel(allure.AllureID); ok {
+		id = fmt.Sprintf(" [ALLURE_ID=%s]", label.GetValue())
+	}
+
+	fmt.Printf("Test %s%s is dropped: %s\n", result.FullName, id, reason)
+}
+
 func (r *runner) NewTest(testName string, testBody func(provider.T), tags ...string) {
-	fullName := fmt.Sprintf("%s/%s", r.t().Name(), testName)
+	r.newTest(testName, testBody, getPackage(defaultPackageDepth), WithTags(tags...))
+}
+
+// NewTestWithOptions registers new test with passed options (tags, retries etc.)
+func (r *runner) NewTestWithOptions(testName string, testBody func(provider.T), opts ...TestOption) {
+	r.newTest(testName, testBody, getPackage(defaultPackageDepth), opts...)
+}
+
+func (r *runner) newTest(testName string, testBody func(provider.T), packageName string, opts ...TestOption) {
+	var (
+		options  = newTestOptions(opts...)
+		fullName = fmt.Sprintf("%s/%s", r.t().Name(), testName)
+	)
 
 	testMeta := adapter.NewTestMeta(
 		r.t().GetProvider().GetSuiteMeta().GetSuiteFullName(),
 		r.t().GetProvider().GetSuiteMeta().GetSuiteName(),
 		testName,
-		getPackage(defaultPackageDepth),
-		tags...,
+		packageName,
+		options.tags...,
 	)
 
 	if !r.toRun(testMeta.GetResult()) {
 		return
 	}
 
-	r.tests[fullName] = newTestFunc(testBody, testMeta)
+	r.addTest(fullName, newTestFunc(testBody, testMeta, options))
 }
 
 func (r *runner) BeforeEach(hookBody func(provider.T)) {
@@ -108,9 +141,15 @@ func (r *runner) AfterAll(hookBody func(provider.T)) {
 	r.internalT.GetProvider().GetSuiteMeta().SetAfterAll(hookBody)
 }
 
+// AddListener adds listeners of the runner's events. Listeners registered with listener.Register get events of all runners
+func (r *runner) AddListener(listeners ...listener.Listener) {
+	r.t().SetListeners(r.t().Listener().With(listeners...))
+}
+
 func (r *runner) RunTests() SuiteResult {
 	var (
-		wg = &sync.WaitGroup{}
+		wg          = &sync.WaitGroup{}
+		containerMu = &sync.Mutex{}
 
 		parentSuiteMeta = r.t().GetProvider().GetSuiteMeta()
 		parentTestMeta  = r.t().GetProvider().GetTestMeta()
@@ -121,6 +160,7 @@ func (r *runner) RunTests() SuiteResult {
 		beforeEachHook = common.CarriedHook(common.BeforeEach, parentTestMeta.GetBeforeEach)
 		afterEachHook  = common.CarriedHook(common.AfterEach, parentTestMeta.GetAfterEach)
 	)
+	handleInterruptIfEnabled()
 
 	r.realT().Run(parentSuiteMeta.GetSuiteName(), func(t *testing.T) {
 		oldParentT := r.realT()
@@ -128,36 +168,51 @@ func (r *runner) RunTests() SuiteResult {
 
 		defer r.t().SetRealT(oldParentT)
 
+		l := r.t().Listener()
+		suiteEvent := listener.SuiteEvent{
+			Name:      parentSuiteMeta.GetSuiteName(),
+			FullName:  parentSuiteMeta.GetSuiteFullName(),
+			Container: parentSuiteMeta.GetContainer(),
+		}
+		l.SuiteStarted(suiteEvent)
+
+		defer r.t().CancelContext()
+		// result of the suite is used by all hooks and never finished as a test
+		defer func() { listener.ForgetStatus(r.t().GetResult()) }()
 		defer wg.Wait()
-		defer finishSuite(r.internalT.GetProvider())
+		defer l.SuiteFinished(suiteEvent)
+		defer finishSuite(t, r.internalT.GetProvider())
+		defer r.t().TeardownFixtures(common.SuiteScope)
 		defer func() { _, _ = runHook(r.t(), afterAllHook) }()
 
-		for _, test := range r.tests {
+		for _, test := range r.orderedTests() {
 			result.GetContainer().AddChild(test.GetMeta().GetResult().UUID)
 		}
 
 		// before all hook
 		ok, err := runHook(r.t(), beforeAllHook)
 		if err != nil {
-			for _, test := range r.tests {
+			for _, test := range r.orderedTests() {
 				result = setupErrorHandler(
 					fmt.Sprintf("%v setup was failed", r.t().Name()),
 					err,
 					test.GetMeta(),
 					result,
 				)
+				listener.NotifyTestFinished(l, test.GetMeta().GetResult())
 			}
 
 			return
 		}
 		if !ok {
-			for _, test := range r.tests {
+			for _, test := range r.orderedTests() {
 				result = setupErrorHandler(
 					fmt.Sprintf("%v setup was failed", r.t().Name()),
 					fmt.Errorf("something goes wrong in beforeAll"),
 					test.GetMeta(),
 					result,
 				)
+				listener.NotifyTestFinished(l, test.GetMeta().GetResult())
 			}
 
 			return
@@ -183,50 +238,48 @@ func (r *runner) RunTests() SuiteResult {
 			r.t().SetRealT(t)
 			defer r.t().SetRealT(oldTestT)
 
-			for _, testData := range r.tests {
+			tests, err := r.runOrder()
+			if err != nil {
+				t.Fatalf("allure-go: %v", err)
+			}
+
+			for _, testData := range tests {
 				test := testData
 				wg.Add(1)
+				l.TestScheduled(listener.TestEvent{Result: test.GetMeta().GetResult()})
 				r.realT().Run(test.GetMeta().GetResult().Begin().Name, func(t *testing.T) {
 					defer wg.Done()
-					defer func() {
-						result.NewResult(finishTest(t, test.GetMeta()))
-					}()
-					testT := setupTest(t, r.t().GetProvider(), test.GetMeta())
-
-					// after each hook
-					defer func() {
-						// Set default status to Passed if not set (before AfterEach hook)
-						// This allows AfterEach to see the test status
-						if result := testT.GetProvider().GetResult(); result != nil && result.Status == "" {
-							result.Status = allure.Passed
+
+					var (
+						meta     = test.GetMeta()
+						retries  = getRetries(test)
+						timeout  = getTimeout(test)
+						parallel = &sync.Once{}
+					)
+
+					for attempt := 1; ; attempt++ {
+						var (
+							last       = attempt > retries
+							nextResult *allure.Result
+						)
+
+						if !last {
+							nextResult = meta.GetResult().Clone()
 						}
-						_, _ = runHook(testT, afterEachHook)
-					}()
-
-					// catch panic in test body context
-					defer func() {
-						rec := recover()
-						if rec != nil {
-							ctxName := testT.GetProvider().ExecutionContext().GetName()
-							errMsg := fmt.Sprintf("%s panicked: %v\n%s", ctxName, rec, debug.Stack())
-							common.TestError(testT, testT.GetProvider(), testT.GetProvider().ExecutionContext().GetName(), errMsg)
+
+						attemptT := newAttemptT(t, !last, parallel)
+						// without retries and timeout the test runs on its own goroutine, as a plain go test does
+						attemptT.inline = retries == 0 && timeout <= 0
+						if !r.runAttempt(attemptT, attempt, test.GetBody(), meta, result, timeout, beforeEachHook, afterEachHook) {
+							break
 						}
-					}()
 
-					// before each hook
-					ok, err = runHook(testT, beforeEachHook)
-					if err != nil {
-						result = setupErrorHandler("Test Setup failed", err, test.GetMeta(), result)
-						return
-					}
-					if !ok {
-						result = setupErrorHandler("Test Setup failed", fmt.Errorf("assertion error due test setup"), test.GetMeta(), result)
-						return
+						t.Logf("Attempt %d of %d failed. Retrying", attempt, retries+1)
+						meta = adapter.NewTestMetaWithResult(nextResult.Begin())
+						containerMu.Lock()
+						result.GetContainer().AddChild(nextResult.UUID)
+						containerMu.Unlock()
 					}
-
-					testT.GetProvider().TestContext()
-					defer testT.WG().Wait()
-					test.GetBody()(testT)
 				})
 			}
 		})
@@ -234,6 +287,158 @@ func (r *runner) RunTests() SuiteResult {
 	return result
 }
 
+// runAttempt runs one attempt of the test and reports its result. Returns true if the test must be retried.
+// The result is reported in defer, because inline attempt stops the goroutine of the test on FailNow and Skip
+func (r *runner) runAttempt(
+	t *attemptT,
+	attempt int,
+	body TestBody,
+	meta provider.TestMeta,
+	result SuiteResult,
+	timeout time.Duration,
+	beforeEachHook, afterEachHook common.HookFunc,
+) (retry bool) {
+	defer func() {
+		retry = t.soft && !t.Skipped() && isFailed(t, meta.GetResult())
+		if retry || (attempt > 1 && meta.GetResult().Status == allure.Passed) {
+			meta.GetResult().StatusDetails.Flaky = true
+		}
+
+		result.NewResult(finishTest(t.T, meta))
+		listener.NotifyTestFinished(r.t().Listener(), meta.GetResult())
+		t.finish()
+	}()
+
+	r.runTest(t, body, meta, result, timeout, beforeEachHook, afterEachHook)
+
+	return false
+}
+
+// runTest runs one attempt of the test: before each hook and test body (limited by timeout) and after each hook.
+// Steps after the body are deferred, so they run after FailNow of inline attempt as well
+func (r *runner) runTest(
+	t *attemptT,
+	body TestBody,
+	meta provider.TestMeta,
+	result SuiteResult,
+	timeout time.Duration,
+	beforeEachHook, afterEachHook common.HookFunc,
+) {
+	testT := setupTest(t, r.t().GetProvider(), meta)
+	testT.SetListeners(r.t().Listener())
+	testT.SetContext(r.t().Context())
+	r.t().Listener().TestStarted(listener.TestEvent{Result: meta.GetResult()})
+
+	var (
+		hookT    = testT
+		timedOut bool
+		bodyMeta = meta
+		started  *allure.Result
+	)
+	if timeout > 0 {
+		// the body gets its own meta, so the result reported after the timeout is not changed by the abandoned goroutine
+		bodyMeta = &detachedMeta{TestMeta: meta, result: meta.GetResult()}
+		started = startedResult(meta.GetResult())
+		testT.SetTestMeta(bodyMeta)
+	}
+	defer func() { hookT.CancelContext() }()
+
+	// fixtures of the test are torn down after its after each hook
+	defer t.run(func() {
+		hookT.TeardownFixtures(common.TestScope)
+	})
+
+	// after each hook
+	defer t.run(func() {
+		// Set default status to Passed if not set (before AfterEach hook)
+		// This allows AfterEach to see the test status
+		if result := hookT.GetProvider().GetResult(); result != nil && result.Status == "" {
+			result.Status = allure.Passed
+			listener.NotifyStatus(hookT.Listener(), result)
+		}
+		_, _ = runHook(hookT, afterEachHook)
+	})
+
+	defer func() {
+		testT.CancelContext()
+
+		if timedOut {
+			// changes of the test made by before each hook and the body are left to the abandoned goroutine
+			listener.ForgetStatus(meta.GetResult())
+			meta.SetResult(started)
+			timeoutHandler(t, meta.GetResult(), timeout)
+			// test body goroutine is abandoned, so after each hook gets its own provider
+			hookT = newTestT(t, r.t().GetProvider(), meta)
+			hookT.SetListeners(r.t().Listener())
+		}
+		listener.NotifyStatus(hookT.Listener(), meta.GetResult())
+
+		// context of the test body is canceled, after each hook and teardown of fixtures get new one
+		hookT.SetContext(r.t().Context())
+	}()
+
+	timedOut = !t.runWithTimeout(timeout, func() {
+		// catch panic in test body context
+		defer func() {
+			rec := recover()
+			if rec != nil {
+				ctxName := testT.GetProvider().ExecutionContext().GetName()
+				errMsg := fmt.Sprintf("%s panicked: %v\n%s", ctxName, rec, debug.Stack())
+				common.TestError(testT, testT.GetProvider(), testT.GetProvider().ExecutionContext().GetName(), errMsg)
+			}
+		}()
+
+		// before each hook
+		ok, err := runHook(testT, beforeEachHook)
+		if err != nil {
+			setupErrorHandler("Test Setup failed", err, bodyMeta, result)
+			return
+		}
+		if !ok {
+			setupErrorHandler("Test Setup failed", fmt.Errorf("assertion error due test setup"), bodyMeta, result)
+			return
+		}
+
+		testT.GetProvider().TestContext()
+		defer testT.WG().Wait()
+		body(testT)
+	})
+}
+
+// detachedMeta is the meta of the test body with timeout. It keeps the result of the body,
+// when the runner replaces the result of the test on timeout
+type detachedMeta struct {
+	provider.TestMeta
+	result *allure.Result
+}
+
+func (m *detachedMeta) GetResult() *allure.Result       { return m.result }
+func (m *detachedMeta) SetResult(result *allure.Result) { m.result = result }
+
+// startedResult returns copy of the test result before the body is started. It is reported, if the body times out
+func startedResult(result *allure.Result) *allure.Result {
+	started := result.Clone()
+	started.UUID = result.UUID
+	started.Start = result.Start
+
+	return started
+}
+
+// timeoutHandler marks the test broken and attaches goroutine dump to its result
+func timeoutHandler(t TestingT, result *allure.Result, timeout time.Duration) {
+	msg := fmt.Sprintf("Test timed out after %s", timeout)
+	result.Status = allure.Broken
+	result.SetStatusMessage(msg)
+	result.SetStatusTrace(msg)
+	result.Attachments = append(result.Attachments, common.NewGoroutineDumpAttachment())
+	t.Error(msg)
+}
+
+// isFailed checks whether the test attempt failed
+func isFailed(t TestingT, result *allure.Result) bool {
+	return t.Failed() || result.Status == allure.Failed || result.Status == allure.Broken
+}
+
 func Run(t *testing.T, testName string, testBody func(provider.T), tags ...string) *allure.Result {
 	var (
 		newT        = common.NewT(t)
@@ -247,16 +452,33 @@ func Run(t *testing.T, testName string, testBody func(provider.T), tags ...strin
 	)
 	newT.SetProvider(newProvider)
 	newT.TestContext()
+	handleInterruptIfEnabled()
+	defer printRunInfo(t)
 
 	return newT.Run(testName, testBody, tags...)
 }
 
 func setupTest(t TestingT, parentProvider provider.Provider, meta provider.TestMeta) *common.Common {
+	testT := newTestT(t, parentProvider, meta)
+
+	parentTestMeta := parentProvider.GetTestMeta()
+	meta.SetBeforeEach(parentTestMeta.GetBeforeEach())
+	meta.SetAfterEach(parentTestMeta.GetAfterEach())
+	if parentSuite := testT.Provider.GetSuiteMeta().GetParentSuite(); parentSuite != "" {
+		meta.GetResult().WithParentSuite(parentSuite)
+	}
+	meta.SetResult(copyLabels(parentProvider.GetResult(), meta.GetResult()))
+	testT.SetTestMeta(meta)
+
+	return testT
+}
+
+// newTestT returns new provider.T of the test with its own provider
+func newTestT(t TestingT, parentProvider provider.Provider, meta provider.TestMeta) *common.Common {
 	var (
 		testT = common.NewT(t)
 
 		parentSuiteMeta = parentProvider.GetSuiteMeta()
-		parentTestMeta  = parentProvider.GetTestMeta()
 
 		packageName     = parentSuiteMeta.GetPackageName()
 		suiteName       = parentSuiteMeta.GetSuiteName()
@@ -271,14 +493,9 @@ func setupTest(t TestingT, parentProvider provider.Provider, meta provider.TestM
 			WithRunner(callers[0])
 	)
 	testT.SetProvider(manager.NewProvider(cfg))
+	testT.SetFixtureSuite(parentSuiteMeta.GetContainer())
 
 	testT.TestContext()
-	meta.SetBeforeEach(parentTestMeta.GetBeforeEach())
-	meta.SetAfterEach(parentTestMeta.GetAfterEach())
-	if parentSuite := testT.Provider.GetSuiteMeta().GetParentSuite(); parentSuite != "" {
-		meta.GetResult().WithParentSuite(parentSuite)
-	}
-	meta.SetResult(copyLabels(parentProvider.GetResult(), meta.GetResult()))
 	testT.SetTestMeta(meta)
 
 	return testT
@@ -294,9 +511,20 @@ func finishTest(t TestingT, meta provider.TestMeta) TestResult {
 	return testRes
 }
 
-func finishSuite(p provider.Provider) {
+func finishSuite(t TestingT, p provider.Provider) {
 	p.GetSuiteMeta().GetContainer().Finish()
 	_ = p.GetSuiteMeta().GetContainer().Print()
+	printRunInfo(t)
+}
+
+// printRunInfo prints environment.properties, executor.json and categories.json of the run.
+// Errors are reported to t, e.g. invalid file set in ALLURE_CATEGORIES_PATH
+func printRunInfo(t TestingT) {
+	for _, printFile := range []func() error{allure.PrintEnvironment, allure.PrintExecutor, allure.PrintCategories} {
+		if err := printFile(); err != nil {
+			t.Errorf("allure-go: %v", err)
+		}
+	}
 }
 
 func setupErrorHandler(
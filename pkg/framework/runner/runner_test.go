@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/adapter"
+	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/testplan"
 	"github.com/ozontech/allure-go/pkg/framework/core/common"
 	"github.com/ozontech/allure-go/pkg/framework/core/constants"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
@@ -508,3 +510,28 @@ func TestRunner_NewTest(t *testing.T) {
 	r.tests[testKey].GetBody()(r.t())
 	require.True(t, flag)
 }
+
+func TestRunner_filterByTestPlan(t *testing.T) {
+	selectedByID := adapter.NewTestMeta("TestRunner/Suite", "Suite", "TestA", "package")
+	selectedByID.GetResult().AddLabel(allure.IDAllureLabel("42"))
+	selectedBySelector := adapter.NewTestMeta("TestRunner/Suite", "Suite", "TestB", "package")
+	dropped := adapter.NewTestMeta("TestRunner/Suite", "Suite", "TestC", "package")
+	dropped.GetResult().AddLabel(allure.IDAllureLabel("43"))
+
+	r := runner{
+		tests: map[string]Test{
+			"TestA": &testFunc{testMeta: selectedByID},
+			"TestB": &testFunc{testMeta: selectedBySelector},
+			"TestC": &testFunc{testMeta: dropped},
+		},
+		testPlan: &testplan.TestPlan{Tests: []*testplan.TestCase{
+			{ID: "42"},
+			{Selector: "TestRunner/Suite/TestB*"},
+		}},
+	}
+
+	tests := r.filterByTestPlan()
+	require.Len(t, tests, 2)
+	require.Contains(t, tests, "TestA")
+	require.Contains(t, tests, "TestB")
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/ozontech/allure-go/pkg/allure"
@@ -87,8 +88,13 @@ func newSuiteRunner(
 		suite:       suite,
 	}
 
+	if ls, ok := suite.(ListenersSuite); ok {
+		r.AddListener(ls.GetListeners()...)
+	}
+
 	r.adjustTableTests = func() {
 		initializeParametrizedTests(r)
+		collectGenericParametrizedTests(r, suite)
 	}
 
 	collectTests(r, suite)
@@ -107,12 +113,27 @@ func collectTests(runner *suiteRunner, tSuite TestSuite) {
 		suiteName     = runner.internalT.GetProvider().GetSuiteMeta().GetSuiteName()
 		suiteFullName = runner.internalT.GetProvider().GetSuiteMeta().GetSuiteFullName()
 		getAllureID   func(string) string
+		getTags       func(string) []string
+		getRetries    func(string) int
+		getTimeout    func(string) time.Duration
 	)
 
 	if ais, ok := tSuite.(AllureIDSuite); ok {
 		getAllureID = ais.GetAllureID
 	}
 
+	if ts, ok := tSuite.(TagsSuite); ok {
+		getTags = ts.GetTags
+	}
+
+	if rs, ok := tSuite.(RetrySuite); ok {
+		getRetries = rs.GetRetries
+	}
+
+	if ts, ok := tSuite.(TimeoutSuite); ok {
+		getTimeout = ts.GetTimeout
+	}
+
 	for i := 0; i < methodFinder.NumMethod(); i++ {
 		method := methodFinder.Method(i)
 		ok, err := methodFilter(method.Name)
@@ -134,13 +155,34 @@ func collectTests(runner *suiteRunner, tSuite TestSuite) {
 			}
 		}
 
-		runner.tests[method.Name] = &testMethod{
+		if getTags != nil {
+			testMeta.GetResult().AddLabel(allure.TagLabels(getTags(method.Name)...)...)
+		}
+
+		// table tests are filtered by tag expression after expansion
+		if !strings.HasPrefix(method.Name, tableTestPrefix) && !runner.toRun(testMeta.GetResult()) {
+			continue
+		}
+
+		retries := -1
+		if getRetries != nil {
+			retries = getRetries(method.Name)
+		}
+
+		var timeout time.Duration
+		if getTimeout != nil {
+			timeout = getTimeout(method.Name)
+		}
+
+		runner.addTest(method.Name, &testMethod{
 			testMeta: testMeta,
 			testBody: method,
 			callArgs: []reflect.Value{
 				reflect.ValueOf(tSuite),
 			},
-		}
+			retries: retries,
+			timeout: timeout,
+		})
 	}
 }
 
@@ -168,35 +210,83 @@ func collectParametrizedTests(runner *suiteRunner, suite TestSuite) {
 	}
 }
 
-func initializeParametrizedTests(runner *suiteRunner) {
-	newTests := make(map[string]Test, len(runner.tests))
-	for k, v := range runner.tests {
-		newTests[k] = v
+// collectGenericParametrizedTests registers tests of GenericParametrizedSuite
+// and adds them to the suite container
+func collectGenericParametrizedTests(runner *suiteRunner, suite TestSuite) {
+	gps, ok := suite.(GenericParametrizedSuite)
+	if !ok {
+		return
 	}
 
-	for name, test := range runner.tests {
-		if strings.HasPrefix(name, tableTestPrefix) {
-			params, err := getParams(runner.suite, name)
-			if err != nil {
-				panic(err)
-			}
+	registered := make(map[string]bool, len(runner.tests))
+	for name := range runner.tests {
+		registered[name] = true
+	}
+
+	gps.ParametrizedTests(runner)
+
+	for _, name := range runner.orderedTestNames() {
+		if !registered[name] {
+			runner.internalT.GetProvider().GetSuiteMeta().GetContainer().AddChild(runner.tests[name].GetMeta().GetResult().UUID)
+		}
+	}
+}
+
+// initializeParametrizedTests replaces table tests of the runner with tests for every param.
+// Cases take place of their table test and keep order of params in the slice
+func initializeParametrizedTests(runner *suiteRunner) {
+	var (
+		names    = runner.orderedTestNames()
+		newTests = make(map[string]Test, len(runner.tests))
+		order    = make([]string, 0, len(names))
+	)
+
+	for _, name := range names {
+		test := runner.tests[name]
+		if !strings.HasPrefix(name, tableTestPrefix) {
+			newTests[name] = test
+			order = append(order, name)
+			continue
+		}
 
-			temp := getParamTests(test, params)
-			delete(newTests, name)
+		params, err := getParams(runner.suite, name)
+		if err != nil {
+			panic(err)
+		}
 
-			for tName, body := range temp {
-				tResult := body.GetMeta().GetResult()
-				newTests[tName] = body
-				runner.internalT.GetProvider().GetSuiteMeta().GetContainer().AddChild(tResult.UUID)
+		for _, pTest := range getParamTests(test, params) {
+			tResult := pTest.test.GetMeta().GetResult()
+			if !runner.toRun(tResult) {
+				continue
 			}
+
+			newTests[pTest.name] = pTest.test
+			order = append(order, pTest.name)
+			runner.internalT.GetProvider().GetSuiteMeta().GetContainer().AddChild(tResult.UUID)
 		}
 	}
+
 	runner.tests = newTests
+	runner.order = order
+}
+
+// tableParam is the element of the table test's params slice with the name of its test
+type tableParam struct {
+	name  string
+	value interface{}
+	err   error               // error of the case preparing, the case is reported broken
+	extra []*allure.Parameter // additional parameters of the case (e.g. source of the data)
+}
+
+// namedTest is the test with its name in the runner
+type namedTest struct {
+	name string
+	test Test
 }
 
 // getParamTests create instance of TestAdapter for every param from params
-// and returns map whose elements are a pair (<param name>, <pointer to instance of testMethod>)
-func getParamTests(parentTest Test, params map[string]interface{}) map[string]Test {
+// and returns slice whose elements are a pair (<param name>, <pointer to instance of testMethod>)
+func getParamTests(parentTest Test, params []tableParam) []namedTest {
 	if paramTest, ok := parentTest.(parametrizedTest); ok {
 
 		result := paramTest.GetMeta().GetResult()
@@ -217,24 +307,47 @@ func getParamTests(parentTest Test, params map[string]interface{}) map[string]Te
 			tags = append(tags, tag.GetValue())
 		}
 
-		res := make(map[string]Test, len(params))
+		retries := -1
+		if rt, ok := parentTest.(retryableTest); ok {
+			retries = rt.GetRetries()
+		}
+		timeout := getTimeout(parentTest)
+
+		res := make([]namedTest, 0, len(params))
 
-		for pName, param := range params {
-			meta := adapter.NewTestMeta(result.FullName, suiteName, pName, packageName, tags...)
+		for _, param := range params {
+			meta := adapter.NewTestMeta(result.FullName, suiteName, param.name, packageName, tags...)
 			if parentSuite, ok := result.GetFirstLabel(allure.ParentSuite); ok {
 				meta.GetResult().ReplaceLabel(parentSuite)
 			}
 
-			if ptp, ok := param.(ParametrizedTestParam); ok {
-				meta.GetResult().Name = ptp.GetAllureTitle()
-				meta.GetResult().AddLabel(allure.IDAllureLabel(ptp.GetAllureID()))
+			if param.err != nil {
+				meta.GetResult().Parameters = append(meta.GetResult().Parameters, param.extra...)
+				meta.GetResult().WithTestCaseFullName(result.FullName)
+				res = append(res, namedTest{
+					name: param.name,
+					test: newTestFunc(brokenCaseBody(param.err), meta, &testOptions{retries: 0, timeout: timeout}),
+				})
+				continue
 			}
 
-			res[pName] = &testMethod{
-				testMeta: meta,
-				testBody: paramTest.GetRawBody(),
-				callArgs: append(paramTest.GetArgs(), reflect.ValueOf(param)),
+			if ptp, ok := param.value.(ParametrizedTestParam); ok {
+				meta.GetResult().Name = ptp.GetAllureTitle()
+				meta.GetResult().AddLabel(allure.IDAllureLabel(ptp.GetAllureID()))
 			}
+			withCaseParameters(meta.GetResult(), result.FullName, strings.TrimPrefix(result.Name, tableTestPrefix), param.value)
+			meta.GetResult().Parameters = append(meta.GetResult().Parameters, param.extra...)
+
+			res = append(res, namedTest{
+				name: param.name,
+				test: &testMethod{
+					testMeta: meta,
+					testBody: paramTest.GetRawBody(),
+					callArgs: append(paramTest.GetArgs(), reflect.ValueOf(param.value)),
+					retries:  retries,
+					timeout:  timeout,
+				},
+			})
 		}
 
 		return res
@@ -244,12 +357,17 @@ func getParamTests(parentTest Test, params map[string]interface{}) map[string]Te
 }
 
 // getParams checks that the parameter extending the suite is of the slice type
-// and returns a map whose elements are a pair
-// (<method name without tableTestPrefix>_<value of slice element>, <value of slice element>)
-func getParams(suite TestSuite, methodName string) (map[string]interface{}, error) {
+// and returns its elements in order with names of their tests. Cases of the data file
+// set by DataProviderTag go after the elements.
+// Name is `<method name without tableTestPrefix>_<param>` for simple values and fmt.Stringer implementations,
+// `<method name without tableTestPrefix>_<index>` for others or the one returned by ParamNameSuite.
+// Duplicated names get `#<index>` suffix
+func getParams(suite TestSuite, methodName string) ([]tableParam, error) {
 	var (
 		structSuite = reflect.ValueOf(suite).Elem()
 		paramName   = strings.TrimPrefix(methodName, tableTestPrefix)
+		namer       = newParamNamer(paramName)
+		getName     func(string, interface{}, int) string
 	)
 
 	params := structSuite.FieldByName(tableParamPrefix + paramName)
@@ -257,14 +375,36 @@ func getParams(suite TestSuite, methodName string) (map[string]interface{}, erro
 		return nil, fmt.Errorf("cannot find appropriate params for %s", methodName)
 	}
 
-	res := make(map[string]interface{}, params.Len())
+	if pns, ok := suite.(ParamNameSuite); ok {
+		getName = pns.GetParamName
+	}
+
+	res := make([]tableParam, 0, params.Len())
 
 	for i := 0; i < params.Len(); i++ {
 		paramV := params.Index(i)
 		param := reflect.NewAt(paramV.Type(), unsafe.Pointer(paramV.UnsafeAddr())).Elem().Interface()
-		pName := fmt.Sprintf("%s_%+v", paramName, param)
 
-		res[pName] = param
+		res = append(res, tableParam{value: param})
+	}
+
+	field, _ := structSuite.Type().FieldByName(tableParamPrefix + paramName)
+	if path := field.Tag.Get(DataProviderTag); path != "" {
+		res = append(res, loadTableParams(path, paramName, params.Type().Elem())...)
+	}
+
+	for i := range res {
+		if res[i].err != nil {
+			res[i].name = namer.name(i, nil, res[i].name)
+			continue
+		}
+
+		var custom string
+		if getName != nil {
+			custom = getName(methodName, res[i].value, i)
+		}
+
+		res[i].name = namer.name(i, res[i].value, custom)
 	}
 
 	return res, nil
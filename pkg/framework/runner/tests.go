@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/bytedance/sonic"
 
@@ -146,6 +147,8 @@ type testMethod struct {
 	testMeta provider.TestMeta
 	testBody reflect.Method
 	callArgs []reflect.Value
+	retries  int
+	timeout  time.Duration
 }
 
 // GetArgs returns call args of the test
@@ -170,9 +173,21 @@ func (t *testMethod) GetMeta() provider.TestMeta {
 	return t.testMeta
 }
 
+// GetRetries returns number of retries of the test. Negative value means global default
+func (t *testMethod) GetRetries() int {
+	return t.retries
+}
+
+// GetTimeout returns timeout of the test. Zero value means no timeout
+func (t *testMethod) GetTimeout() time.Duration {
+	return t.timeout
+}
+
 type testFunc struct {
 	testBody TestBody
 	testMeta provider.TestMeta
+	retries  int
+	timeout  time.Duration
 }
 
 // GetBody returns test function
@@ -185,11 +200,49 @@ func (t *testFunc) GetMeta() provider.TestMeta {
 	return t.testMeta
 }
 
-func newTestFunc(body TestBody, testMeta provider.TestMeta) *testFunc {
+// GetRetries returns number of retries of the test. Negative value means global default
+func (t *testFunc) GetRetries() int {
+	return t.retries
+}
+
+// GetTimeout returns timeout of the test. Zero value means no timeout
+func (t *testFunc) GetTimeout() time.Duration {
+	return t.timeout
+}
+
+func newTestFunc(body TestBody, testMeta provider.TestMeta, options *testOptions) *testFunc {
 	return &testFunc{
 		testBody: body,
 		testMeta: testMeta,
+		retries:  options.retries,
+		timeout:  options.timeout,
+	}
+}
+
+type retryableTest interface {
+	GetRetries() int
+}
+
+// getRetries returns number of retries of the test: test's own setting if set, otherwise global default
+func getRetries(test Test) int {
+	if rt, ok := test.(retryableTest); ok && rt.GetRetries() >= 0 {
+		return rt.GetRetries()
+	}
+
+	return getDefaultRetries()
+}
+
+type timeoutTest interface {
+	GetTimeout() time.Duration
+}
+
+// getTimeout returns timeout of the test. Zero value means no timeout
+func getTimeout(test Test) time.Duration {
+	if tt, ok := test.(timeoutTest); ok {
+		return tt.GetTimeout()
 	}
+
+	return 0
 }
 
 func insert(a []reflect.Value, index int, value reflect.Value) []reflect.Value {
@@ -1,11 +1,17 @@
 package suite
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/tagfilter"
+	"github.com/ozontech/allure-go/pkg/framework/core/listener"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/runner"
 	"github.com/stretchr/testify/require"
@@ -168,3 +174,752 @@ func TestSuiteRunner_StartTimeAndDurationOfConsistentTests(t *testing.T) {
 	require.Equal(t, time.UnixMilli(results[0].GetResult().Stop-results[0].GetResult().Start).Second(), 1)
 	require.Equal(t, time.UnixMilli(results[1].GetResult().Stop-results[1].GetResult().Start).Second(), 1)
 }
+
+type TestSuiteRetries struct {
+	Suite
+	attempts int
+}
+
+func (s *TestSuiteRetries) GetRetries(testName string) int {
+	return 2
+}
+
+func (s *TestSuiteRetries) TestFlaky(t provider.T) {
+	s.attempts++
+	if s.attempts == 1 {
+		t.Errorf("first attempt failed")
+	}
+}
+
+func TestSuiteRunner_Retries(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	suite := new(TestSuiteRetries)
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	results := r.RunTests().GetAllTestResults()
+
+	require.Equal(t, 2, suite.attempts)
+	require.Len(t, results, 2)
+
+	first, second := results[0].GetResult(), results[1].GetResult()
+	require.Equal(t, allure.Failed, first.Status)
+	require.Equal(t, allure.Passed, second.Status)
+	require.True(t, first.StatusDetails.Flaky)
+	require.True(t, second.StatusDetails.Flaky)
+	require.Equal(t, first.HistoryID, second.HistoryID)
+	require.NotEqual(t, first.UUID, second.UUID)
+	require.False(t, t.Failed())
+}
+
+func TestRunner_NewTestWithOptions_retries(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	var attempts int
+	r := runner.NewRunner(t, "suiteName")
+	r.NewTestWithOptions("flaky", func(t provider.T) {
+		attempts++
+		if attempts < 3 {
+			t.Require().True(false)
+		}
+	}, runner.WithRetries(3), runner.WithTags("tag"))
+	results := r.RunTests().GetAllTestResults()
+
+	require.Equal(t, 3, attempts)
+	require.Len(t, results, 3)
+	for _, res := range results {
+		require.True(t, res.GetResult().StatusDetails.Flaky)
+		require.Len(t, res.GetResult().GetLabels(allure.Tag), 1)
+	}
+	require.Equal(t, allure.Passed, results[2].GetResult().Status)
+}
+
+func TestRunner_NewTestWithOptions_noRetries(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	var attempts int
+	r := runner.NewRunner(t, "suiteName")
+	r.NewTestWithOptions("passed", func(t provider.T) {
+		attempts++
+	}, runner.WithRetries(3))
+	results := r.RunTests().GetAllTestResults()
+
+	require.Equal(t, 1, attempts)
+	require.Len(t, results, 1)
+	require.False(t, results[0].GetResult().StatusDetails.Flaky)
+}
+
+type TestSuiteTimeout struct {
+	Suite
+	attempts  int32
+	afterEach int
+	release   chan struct{}
+}
+
+func (s *TestSuiteTimeout) GetTimeout(testName string) time.Duration {
+	return 50 * time.Millisecond
+}
+
+func (s *TestSuiteTimeout) GetRetries(testName string) int {
+	return 1
+}
+
+func (s *TestSuiteTimeout) AfterEach(t provider.T) {
+	s.afterEach++
+}
+
+func (s *TestSuiteTimeout) TestHangsOnce(t provider.T) {
+	if atomic.AddInt32(&s.attempts, 1) == 1 {
+		<-s.release
+	}
+}
+
+func TestSuiteRunner_Timeout(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	suite := &TestSuiteTimeout{release: make(chan struct{})}
+	defer close(suite.release)
+
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	results := r.RunTests().GetAllTestResults()
+
+	require.Equal(t, int32(2), atomic.LoadInt32(&suite.attempts))
+	require.Equal(t, 2, suite.afterEach)
+	require.Len(t, results, 2)
+
+	timedOut := results[0].GetResult()
+	require.Equal(t, allure.Broken, timedOut.Status)
+	require.Equal(t, "Test timed out after 50ms", timedOut.GetStatusMessage())
+	require.Len(t, timedOut.Attachments, 1)
+	require.Equal(t, "Goroutine dump", timedOut.Attachments[0].Name)
+	require.Equal(t, allure.Passed, results[1].GetResult().Status)
+}
+
+func TestRunner_TimeoutDetachesBody(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	var (
+		attempts int32
+		release  = make(chan struct{})
+		done     = make(chan struct{})
+	)
+	r := runner.NewRunner(t, "suiteName")
+	// the first attempt hangs, the retry passes, so the run isn't failed
+	r.NewTestWithOptions("hangsOnce", func(t provider.T) {
+		if atomic.AddInt32(&attempts, 1) > 1 {
+			return
+		}
+		defer close(done)
+
+		<-release
+		t.Owner("late")
+		t.WithNewStep("after timeout", func(sCtx provider.StepCtx) {})
+	}, runner.WithTimeout(50*time.Millisecond), runner.WithRetries(1))
+	results := r.RunTests().GetAllTestResults()
+
+	close(release)
+	<-done
+
+	require.Len(t, results, 2)
+	timedOut := results[0].GetResult()
+	require.Equal(t, allure.Broken, timedOut.Status)
+	require.Equal(t, "Test timed out after 50ms", timedOut.GetStatusMessage())
+	require.Empty(t, timedOut.Steps)
+	require.Empty(t, timedOut.GetLabels(allure.Owner))
+}
+
+type tagFilterParam struct {
+	id string
+}
+
+func (p tagFilterParam) GetAllureID() string {
+	return p.id
+}
+
+func (p tagFilterParam) GetAllureTitle() string {
+	return "param " + p.id
+}
+
+type TestSuiteTagFilter struct {
+	Suite
+	ParamParams []tagFilterParam
+
+	mu  sync.Mutex
+	ran []string
+}
+
+func (s *TestSuiteTagFilter) run(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ran = append(s.ran, name)
+}
+
+func (s *TestSuiteTagFilter) GetAllureID(testName string) string {
+	if testName == "TestSelected" {
+		return "1"
+	}
+	return "2"
+}
+
+func (s *TestSuiteTagFilter) TestSelected(t provider.T) {
+	s.run("TestSelected")
+}
+
+func (s *TestSuiteTagFilter) TestDropped(t provider.T) {
+	s.run("TestDropped")
+}
+
+func (s *TestSuiteTagFilter) TableTestParams(t provider.T, param tagFilterParam) {
+	s.run("param " + param.id)
+}
+
+func TestSuiteRunner_TagFilter(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	tagfilter.SetTagFilter(tagfilter.MustParse("ALLURE_ID=1 || ALLURE_ID=3"))
+	defer tagfilter.SetTagFilter(nil)
+
+	suite := &TestSuiteTagFilter{ParamParams: []tagFilterParam{{id: "3"}, {id: "4"}}}
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	results := r.RunTests().GetAllTestResults()
+
+	require.ElementsMatch(t, []string{"TestSelected", "param 3"}, suite.ran)
+	require.Len(t, results, 2)
+}
+
+type TestSuiteTags struct {
+	Suite
+	ParamParams []tagFilterParam
+
+	mu  sync.Mutex
+	ran []string
+}
+
+func (s *TestSuiteTags) run(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ran = append(s.ran, name)
+}
+
+func (s *TestSuiteTags) GetTags(testName string) []string {
+	switch testName {
+	case "TestSmoke":
+		return []string{"smoke"}
+	case "TestSlow":
+		return []string{"smoke", "slow"}
+	case "TableTestParams":
+		return []string{"smoke"}
+	}
+	return nil
+}
+
+func (s *TestSuiteTags) TestSmoke(t provider.T) {
+	s.run("TestSmoke")
+}
+
+func (s *TestSuiteTags) TestSlow(t provider.T) {
+	s.run("TestSlow")
+}
+
+func (s *TestSuiteTags) TestUntagged(t provider.T) {
+	s.run("TestUntagged")
+}
+
+func (s *TestSuiteTags) TableTestParams(t provider.T, param tagFilterParam) {
+	s.run("param " + param.id)
+}
+
+func TestSuiteRunner_TagFilterByTags(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	tagfilter.SetTagFilter(tagfilter.MustParse("smoke && !slow"))
+	defer tagfilter.SetTagFilter(nil)
+
+	suite := &TestSuiteTags{ParamParams: []tagFilterParam{{id: "1"}}}
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	results := r.RunTests().GetAllTestResults()
+
+	require.ElementsMatch(t, []string{"TestSmoke", "param 1"}, suite.ran)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		require.Equal(t, []*allure.Label{allure.TagLabel("smoke")}, result.GetResult().GetLabels(allure.Tag))
+	}
+}
+
+func TestRunner_TagFilter(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	tagfilter.SetTagFilter(tagfilter.MustParse("smoke && !slow"))
+	defer tagfilter.SetTagFilter(nil)
+
+	var ran []string
+	r := runner.NewRunner(t, "suiteName")
+	r.NewTest("smoke", func(t provider.T) {
+		ran = append(ran, "smoke")
+
+		t.Run("smoke subtest", func(t provider.T) {
+			ran = append(ran, "smoke subtest")
+		}, "smoke")
+		t.Run("slow subtest", func(t provider.T) {
+			ran = append(ran, "slow subtest")
+		}, "smoke", "slow")
+	}, "smoke")
+	r.NewTest("slow", func(t provider.T) {
+		ran = append(ran, "slow")
+	}, "smoke", "slow")
+	r.NewTestWithOptions("untagged", func(t provider.T) {
+		ran = append(ran, "untagged")
+	})
+	r.RunTests()
+
+	require.Equal(t, []string{"smoke", "smoke subtest"}, ran)
+}
+
+type TestSuiteGenericParametrized struct {
+	Suite
+	cities []string
+
+	mu  sync.Mutex
+	ran []string
+}
+
+func (s *TestSuiteGenericParametrized) BeforeAll(t provider.T) {
+	s.cities = []string{"Moscow", "Paris"}
+}
+
+func (s *TestSuiteGenericParametrized) ParametrizedTests(r runner.TestRunner) {
+	runner.NewParametrizedTest(r, "Cities", s.cities, s.checkCity,
+		runner.WithCaseName(func(city string) string { return "city " + city }),
+		runner.WithCaseAllureID(func(city string) string { return city }),
+	)
+}
+
+func (s *TestSuiteGenericParametrized) checkCity(t provider.T, city string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ran = append(s.ran, city)
+}
+
+func TestSuiteRunner_GenericParametrized(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	suite := new(TestSuiteGenericParametrized)
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	sr := r.RunTests()
+
+	require.ElementsMatch(t, []string{"Moscow", "Paris"}, suite.ran)
+	require.Len(t, sr.GetAllTestResults(), 2)
+	for _, city := range []string{"Moscow", "Paris"} {
+		res := sr.GetResultByName("city " + city)
+		require.NotNil(t, res)
+		require.Contains(t, sr.GetContainer().Children, res.GetResult().UUID)
+
+		id, ok := res.GetResult().GetFirstLabel(allure.AllureID)
+		require.True(t, ok)
+		require.Equal(t, city, id.GetValue())
+	}
+}
+
+type orderParam struct {
+	Name string
+}
+
+type TestSuiteTableOrder struct {
+	Suite
+	ParamNumbers []int
+	ParamStructs []orderParam
+
+	mu  sync.Mutex
+	ran []string
+}
+
+func (s *TestSuiteTableOrder) run(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ran = append(s.ran, name)
+}
+
+func (s *TestSuiteTableOrder) BeforeAll(t provider.T) {
+	s.ParamNumbers = []int{3, 1, 3}
+	s.ParamStructs = []orderParam{{Name: "first"}, {Name: ""}}
+}
+
+func (s *TestSuiteTableOrder) GetParamName(testName string, param interface{}, index int) string {
+	if p, ok := param.(orderParam); ok && p.Name != "" {
+		return "Structs_" + p.Name
+	}
+
+	return ""
+}
+
+func (s *TestSuiteTableOrder) TableTestNumbers(t provider.T, n int) {
+	s.run(t.Name())
+}
+
+func (s *TestSuiteTableOrder) TableTestStructs(t provider.T, p orderParam) {
+	s.run(t.Name())
+}
+
+func (s *TestSuiteTableOrder) TestZ(t provider.T) {
+	s.run(t.Name())
+}
+
+func TestSuiteRunner_TableTestsOrder(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	suite := new(TestSuiteTableOrder)
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	results := r.RunTests().GetAllTestResults()
+
+	expected := []string{"Numbers_3", "Numbers_1", "Numbers_3#2", "Structs_first", "Structs_1", "TestZ"}
+	require.Equal(t, expected, suite.ran)
+	require.Len(t, results, len(expected))
+	for i, res := range results {
+		require.Equal(t, expected[i], res.GetResult().Name)
+	}
+}
+
+type userParam struct {
+	Login string
+	Token string `allure:"token,masked,excluded"`
+}
+
+type TestSuiteTableParameters struct {
+	Suite
+	ParamUsers []userParam
+}
+
+func (s *TestSuiteTableParameters) TableTestUsers(t provider.T, user userParam) {
+	t.Require().NotEmpty(user.Login)
+}
+
+func TestSuiteRunner_TableTestParameters(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	suite := &TestSuiteTableParameters{
+		ParamUsers: []userParam{{Login: "alice", Token: "one"}, {Login: "alice", Token: "two"}, {Login: "bob"}},
+	}
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	results := r.RunTests().GetAllTestResults()
+	require.Len(t, results, 3)
+
+	first := results[0].GetResult()
+	require.Equal(t, []*allure.Parameter{
+		allure.NewParameter("Login", "alice"),
+		allure.NewMaskedParameter("token", "one").WithExcluded(true),
+	}, first.Parameters)
+
+	for _, res := range results {
+		require.Equal(t, first.TestCaseID, res.GetResult().TestCaseID)
+	}
+	// excluded token doesn't affect history
+	require.Equal(t, first.HistoryID, results[1].GetResult().HistoryID)
+	require.NotEqual(t, first.HistoryID, results[2].GetResult().HistoryID)
+}
+
+type dataCity struct {
+	Name       string `yaml:"name"`
+	Population int    `yaml:"population"`
+}
+
+type TestSuiteDataProvider struct {
+	Suite
+	ParamCities []dataCity `dataprovider:"testdata/cities.yaml"`
+
+	mu  sync.Mutex
+	ran []string
+}
+
+func (s *TestSuiteDataProvider) TableTestCities(t provider.T, city dataCity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ran = append(s.ran, city.Name)
+}
+
+func TestSuiteRunner_DataProvider(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	suite := &TestSuiteDataProvider{ParamCities: []dataCity{{Name: "Rome", Population: 3}}}
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	results := r.RunTests().GetAllTestResults()
+
+	require.Equal(t, []string{"Rome", "Moscow", "Paris"}, suite.ran)
+	require.Len(t, results, 3)
+
+	moscow := results[1].GetResult()
+	require.Equal(t, "Cities_1", moscow.Name)
+	require.Equal(t, []*allure.Parameter{
+		allure.NewParameter("Name", "Moscow"),
+		allure.NewParameter("Population", 12),
+		allure.NewExcludedParameter("Data source", "testdata/cities.yaml"),
+		allure.NewExcludedParameter("Data row", "1 (line 1)"),
+	}, moscow.Parameters)
+}
+
+type TestSuiteFixtures struct {
+	Suite
+	mu      sync.Mutex
+	events  []string
+	client  *runner.Fixture[string]
+	request *runner.Fixture[string]
+}
+
+func (s *TestSuiteFixtures) event(e string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, e)
+}
+
+func (s *TestSuiteFixtures) AfterEach(t provider.T) {
+	s.event("after each")
+}
+
+func (s *TestSuiteFixtures) AfterAll(t provider.T) {
+	s.event("after all")
+}
+
+func (s *TestSuiteFixtures) TestFirst(t provider.T) {
+	require.Equal(t, "request of client", s.request.Get(t))
+}
+
+func (s *TestSuiteFixtures) TestSecond(t provider.T) {
+	require.Equal(t, "client", s.client.Get(t))
+	require.Equal(t, "request of client", s.request.Get(t))
+}
+
+func TestSuiteRunner_Fixtures(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	suite := new(TestSuiteFixtures)
+	suite.client = runner.NewFixture("client", runner.SuiteScope,
+		func(t provider.T, ctx provider.StepCtx) (string, error) {
+			suite.event("setup client")
+			return "client", nil
+		},
+		runner.WithFixtureTeardown(func(ctx provider.StepCtx, value string) {
+			suite.event("teardown " + value)
+		}),
+	)
+	suite.request = runner.NewFixture("request", runner.TestScope,
+		func(t provider.T, ctx provider.StepCtx) (string, error) {
+			suite.event("setup request")
+			return "request of " + suite.client.Get(t), nil
+		},
+		runner.WithFixtureTeardown(func(ctx provider.StepCtx, value string) {
+			suite.event("teardown request")
+		}),
+	)
+
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	result := r.RunTests()
+
+	require.Equal(t, []string{
+		"setup request", "setup client", "after each", "teardown request",
+		"setup request", "after each", "teardown request",
+		"after all", "teardown client",
+	}, suite.events)
+
+	container := result.GetContainer()
+	require.Len(t, container.Befores, 1)
+	require.Equal(t, "Setup fixture client", container.Befores[0].Name)
+	require.Len(t, container.Afters, 1)
+	require.Equal(t, "Teardown fixture client", container.Afters[0].Name)
+
+	for _, res := range result.GetAllTestResults() {
+		require.Equal(t, allure.Passed, res.GetResult().Status)
+		require.Len(t, res.GetContainer().Befores, 1)
+		require.Equal(t, "Setup fixture request", res.GetContainer().Befores[0].Name)
+		require.Len(t, res.GetContainer().Afters, 1)
+		require.Equal(t, "Teardown fixture request", res.GetContainer().Afters[0].Name)
+	}
+}
+
+func TestRunner_Context(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	var (
+		testCtx      context.Context
+		afterEachErr error
+		subtestCtx   context.Context
+	)
+
+	r := runner.NewRunner(t, "suiteName")
+	r.AfterEach(func(t provider.T) {
+		afterEachErr = t.Context().Err()
+	})
+	r.NewTest("test", func(t provider.T) {
+		testCtx = t.Context()
+		steps, ok := provider.StepsFromContext(testCtx)
+		require.True(t, ok)
+		require.Equal(t, t, steps)
+
+		t.Run("subtest", func(t provider.T) {
+			subtestCtx = t.Context()
+			require.NoError(t, subtestCtx.Err())
+		})
+		require.ErrorIs(t, subtestCtx.Err(), context.Canceled)
+		require.NoError(t, testCtx.Err())
+	})
+	r.RunTests()
+
+	require.ErrorIs(t, testCtx.Err(), context.Canceled)
+	require.NoError(t, afterEachErr)
+}
+
+type recordingListener struct {
+	listener.Base
+
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *recordingListener) record(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+}
+
+func (l *recordingListener) SuiteStarted(e listener.SuiteEvent) { l.record("suite started " + e.Name) }
+func (l *recordingListener) SuiteFinished(e listener.SuiteEvent) {
+	l.record("suite finished " + e.Name)
+}
+func (l *recordingListener) TestScheduled(e listener.TestEvent) {
+	l.record("test scheduled " + e.Result.Name)
+}
+func (l *recordingListener) TestStarted(e listener.TestEvent) {
+	l.record("test started " + e.Result.Name)
+}
+func (l *recordingListener) TestFinished(e listener.TestEvent) {
+	l.record("test finished " + e.Result.Name + " " + string(e.Result.Status))
+}
+func (l *recordingListener) HookStarted(e listener.HookEvent) { l.record("hook started " + e.Hook) }
+func (l *recordingListener) HookFinished(e listener.HookEvent) {
+	l.record(fmt.Sprintf("hook finished %s %v", e.Hook, e.Err))
+}
+func (l *recordingListener) StepStarted(e listener.StepEvent) {
+	l.record("step started " + e.Step.Name)
+}
+func (l *recordingListener) StepFinished(e listener.StepEvent) {
+	l.record("step finished " + e.Step.Name)
+}
+func (l *recordingListener) AttachmentAdded(e listener.AttachmentEvent) {
+	l.record("attachment " + e.Attachment.Name)
+}
+func (l *recordingListener) StatusChanged(e listener.StatusEvent) {
+	l.record(fmt.Sprintf("status %s->%s", e.Previous, e.Result.Status))
+}
+
+type TestSuiteListeners struct {
+	Suite
+	listener *recordingListener
+	attempts int
+}
+
+func (s *TestSuiteListeners) GetListeners() []listener.Listener {
+	return []listener.Listener{s.listener}
+}
+
+func (s *TestSuiteListeners) GetRetries(testName string) int {
+	return 1
+}
+
+func (s *TestSuiteListeners) BeforeEach(t provider.T) {
+	t.WithNewStep("prepare", func(ctx provider.StepCtx) {})
+}
+
+func (s *TestSuiteListeners) TestFlaky(t provider.T) {
+	s.attempts++
+	t.WithNewStep("outer", func(ctx provider.StepCtx) {
+		ctx.WithNewStep("inner", func(ctx provider.StepCtx) {
+			ctx.WithNewAttachment("log", allure.Text, []byte("log"))
+		})
+	})
+	if s.attempts == 1 {
+		t.Errorf("first attempt failed")
+	}
+}
+
+func TestSuiteRunner_Listeners(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	var global []string
+	unregister := listener.Register(&globalListener{events: &global})
+	defer unregister()
+
+	suite := &TestSuiteListeners{listener: new(recordingListener)}
+	r := runner.NewSuiteRunner(t, "packageName", "suiteName", suite)
+	r.RunTests()
+
+	attempt := []string{
+		"test started TestFlaky",
+		"hook started BeforeEach",
+		"step started prepare",
+		"step finished prepare",
+		"hook finished BeforeEach <nil>",
+		"step started outer",
+		"step started inner",
+		"attachment log",
+		"step finished inner",
+		"step finished outer",
+	}
+	expected := []string{"suite started suiteName", "test scheduled TestFlaky"}
+	expected = append(expected, attempt...)
+	expected = append(expected, "status ->failed", "test finished TestFlaky failed")
+	expected = append(expected, attempt...)
+	expected = append(expected, "status ->passed", "test finished TestFlaky passed", "suite finished suiteName")
+	require.Equal(t, expected, suite.listener.events)
+	require.Equal(t, []string{"suiteName", "suiteName"}, global)
+}
+
+type globalListener struct {
+	listener.Base
+	events *[]string
+}
+
+func (l *globalListener) SuiteStarted(e listener.SuiteEvent)  { *l.events = append(*l.events, e.Name) }
+func (l *globalListener) SuiteFinished(e listener.SuiteEvent) { *l.events = append(*l.events, e.Name) }
+
+func TestRun_Listeners(t *testing.T) {
+	allureDir := "./allure-results"
+	defer os.RemoveAll(allureDir)
+
+	l := new(recordingListener)
+	unregister := listener.Register(l)
+	defer unregister()
+
+	runner.Run(t, "test", func(t provider.T) {
+		t.WithNewStep("step", func(ctx provider.StepCtx) {})
+		t.WithNewAttachment("log", allure.Text, []byte("log"))
+	})
+
+	require.Equal(t, []string{
+		"test scheduled test",
+		"test started test",
+		"step started step",
+		"step finished step",
+		"attachment log",
+		"status ->passed",
+		"test finished test passed",
+	}, l.events)
+}